@@ -31,6 +31,12 @@ type ResourceGraphDefinitionSpec struct {
 	//
 	// +kubebuilder:validation:Optional
 	Resources []*Resource `json:"resources,omitempty"`
+	// PropagationPolicy is the default propagation policy used when deleting
+	// the resources of an instance. The cluster default applies when empty.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Orphan;Background;Foreground
+	PropagationPolicy metav1.DeletionPropagation `json:"propagationPolicy,omitempty"`
 }
 
 // Schema represents the attributes that define an instance of
@@ -65,6 +71,12 @@ type Schema struct {
 	// of the resourcegraphdefinition. Each type definition is also adhering to
 	// the SimpleSchema spec.
 	Types runtime.RawExtension `json:"types,omitempty"`
+	// Imports is a list of TypeLibraries whose types are made available to the
+	// spec and types of the resourcegraphdefinition. Imported types can't be
+	// redefined locally.
+	//
+	// +kubebuilder:validation:Optional
+	Imports []TypeImport `json:"imports,omitempty"`
 
 	// The status of the resourcegraphdefinition. This is the status of the CRD
 	// that the resourcegraphdefinition is managing. This is adhering to the
@@ -83,6 +95,20 @@ type Schema struct {
 	AdditionalPrinterColumns []extv1.CustomResourceColumnDefinition `json:"additionalPrinterColumns,omitempty"`
 }
 
+// TypeImport is a reference to a TypeLibrary.
+type TypeImport struct {
+	// Name is the name of the TypeLibrary to import.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+	// Version pins the version of the TypeLibrary. If set, the
+	// resourcegraphdefinition is only accepted when the TypeLibrary
+	// spec.version matches.
+	//
+	// +kubebuilder:validation:Optional
+	Version string `json:"version,omitempty"`
+}
+
 type Validation struct {
 	Expression string `json:"expression,omitempty"`
 	Message    string `json:"message,omitempty"`
@@ -119,8 +145,47 @@ type Resource struct {
 	ReadyWhen []string `json:"readyWhen,omitempty"`
 	// +kubebuilder:validation:Optional
 	IncludeWhen []string `json:"includeWhen,omitempty"`
+	// DependsOn lists the ids of the resources this resource depends on, in
+	// addition to the ones its expressions refer to.
+	// +kubebuilder:validation:Optional
+	DependsOn []string `json:"dependsOn,omitempty"`
+	// PropagationPolicy is the propagation policy used when deleting this
+	// resource, overriding the one of the resourcegraphdefinition.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Orphan;Background;Foreground
+	PropagationPolicy metav1.DeletionPropagation `json:"propagationPolicy,omitempty"`
+	// UpdateStrategy is how the resource is updated once it exists. Apply, the
+	// default, applies every change. Recreate deletes and re-creates the resource
+	// when a change is rejected because it touches an immutable field.
+	// CreateOnly never updates the resource after its creation.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Apply;Recreate;CreateOnly
+	UpdateStrategy UpdateStrategy `json:"updateStrategy,omitempty"`
+	// IgnoreFields lists the paths of the fields, like spec.replicas, that are
+	// only set when the resource is created. kro leaves them to the other
+	// controllers managing them afterwards.
+	// +kubebuilder:validation:Optional
+	IgnoreFields []string `json:"ignoreFields,omitempty"`
+	// ForceApply takes over the fields managed by other field managers when
+	// applying the resource. When false, conflicts with other field managers
+	// are reported as errors of the resource. Defaults to true.
+	// +kubebuilder:validation:Optional
+	ForceApply *bool `json:"forceApply,omitempty"`
 }
 
+// UpdateStrategy defines how a resource is updated once it exists.
+type UpdateStrategy string
+
+const (
+	// UpdateStrategyApply applies every change to the resource.
+	UpdateStrategyApply UpdateStrategy = "Apply"
+	// UpdateStrategyRecreate deletes and re-creates the resource when a change
+	// touches an immutable field.
+	UpdateStrategyRecreate UpdateStrategy = "Recreate"
+	// UpdateStrategyCreateOnly never updates the resource after its creation.
+	UpdateStrategyCreateOnly UpdateStrategy = "CreateOnly"
+)
+
 // ResourceGraphDefinitionState defines the state of the resource graph definition.
 type ResourceGraphDefinitionState string
 
@@ -131,6 +196,24 @@ const (
 	ResourceGraphDefinitionStateInactive ResourceGraphDefinitionState = "Inactive"
 )
 
+// The states of the instances of a resource graph definition, reported in
+// their status.state field.
+const (
+	// InstanceStateInProgress is the state of an instance whose resources are
+	// being reconciled.
+	InstanceStateInProgress = "IN_PROGRESS"
+	// InstanceStateFailed is the state of an instance that failed to reconcile.
+	InstanceStateFailed = "FAILED"
+	// InstanceStateActive is the state of an instance whose resources are all
+	// reconciled and ready.
+	InstanceStateActive = "ACTIVE"
+	// InstanceStateDeleting is the state of an instance being deleted.
+	InstanceStateDeleting = "DELETING"
+	// InstanceStateError is the state of an instance whose reconcile failed
+	// with an error.
+	InstanceStateError = "ERROR"
+)
+
 // ResourceGraphDefinitionStatus defines the observed state of ResourceGraphDefinition
 type ResourceGraphDefinitionStatus struct {
 	// State is the state of the resourcegraphdefinition
@@ -141,6 +224,26 @@ type ResourceGraphDefinitionStatus struct {
 	Conditions Conditions `json:"conditions,omitempty"`
 	// Resources represents the resources, and their information (dependencies for now)
 	Resources []ResourceInformation `json:"resources,omitempty"`
+	// Errors are the problems found in the resource graph definition when it
+	// isn't accepted, each pointing at the field holding it.
+	Errors []ResourceGraphError `json:"errors,omitempty"`
+}
+
+// ResourceGraphError is a problem found in the resourcegraphdefinition
+type ResourceGraphError struct {
+	// Code identifies the kind of problem, e.g InvalidExpression
+	Code string `json:"code,omitempty"`
+	// ResourceID is the id of the resource holding the problem, if any
+	ResourceID string `json:"resourceID,omitempty"`
+	// Path is the path of the field holding the problem, e.g
+	// spec.resources[2].template.spec.replicas
+	Path string `json:"path,omitempty"`
+	// Expression is the offending expression, if any
+	Expression string `json:"expression,omitempty"`
+	// Suggestion is a hint on how to fix the problem, if any
+	Suggestion string `json:"suggestion,omitempty"`
+	// Message describes the problem
+	Message string `json:"message"`
 }
 
 // ResourceInformation defines the information about a resource
@@ -0,0 +1,61 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// TypeLibrarySpec defines the desired state of TypeLibrary
+type TypeLibrarySpec struct {
+	// Version is the version of the type library. ResourceGraphDefinitions
+	// importing the library can pin the version they expect, and will fail
+	// to build if the library is at a different version.
+	//
+	// +kubebuilder:validation:Optional
+	Version string `json:"version,omitempty"`
+	// Types is a map of custom type definitions shared by the
+	// resourcegraphdefinitions importing this library. Each type
+	// definition is adhering to the SimpleSchema spec.
+	//
+	// +kubebuilder:validation:Required
+	Types runtime.RawExtension `json:"types"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="VERSION",type=string,priority=0,JSONPath=`.spec.version`
+// +kubebuilder:printcolumn:name="AGE",type="date",priority=0,JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:shortName=tl,scope=Cluster
+
+// TypeLibrary is the Schema for the typelibraries API
+type TypeLibrary struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec TypeLibrarySpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TypeLibraryList contains a list of TypeLibrary
+type TypeLibraryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TypeLibrary `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TypeLibrary{}, &TypeLibraryList{})
+}
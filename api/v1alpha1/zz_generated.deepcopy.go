@@ -124,6 +124,21 @@ func (in *Resource) DeepCopyInto(out *Resource) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IgnoreFields != nil {
+		in, out := &in.IgnoreFields, &out.IgnoreFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ForceApply != nil {
+		in, out := &in.ForceApply, &out.ForceApply
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Resource.
@@ -248,6 +263,11 @@ func (in *ResourceGraphDefinitionStatus) DeepCopyInto(out *ResourceGraphDefiniti
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Errors != nil {
+		in, out := &in.Errors, &out.Errors
+		*out = make([]ResourceGraphError, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceGraphDefinitionStatus.
@@ -260,6 +280,21 @@ func (in *ResourceGraphDefinitionStatus) DeepCopy() *ResourceGraphDefinitionStat
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceGraphError) DeepCopyInto(out *ResourceGraphError) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceGraphError.
+func (in *ResourceGraphError) DeepCopy() *ResourceGraphError {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceGraphError)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceInformation) DeepCopyInto(out *ResourceInformation) {
 	*out = *in
@@ -285,6 +320,11 @@ func (in *Schema) DeepCopyInto(out *Schema) {
 	*out = *in
 	in.Spec.DeepCopyInto(&out.Spec)
 	in.Types.DeepCopyInto(&out.Types)
+	if in.Imports != nil {
+		in, out := &in.Imports, &out.Imports
+		*out = make([]TypeImport, len(*in))
+		copy(*out, *in)
+	}
 	in.Status.DeepCopyInto(&out.Status)
 	if in.Validation != nil {
 		in, out := &in.Validation, &out.Validation
@@ -308,6 +348,95 @@ func (in *Schema) DeepCopy() *Schema {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TypeImport) DeepCopyInto(out *TypeImport) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TypeImport.
+func (in *TypeImport) DeepCopy() *TypeImport {
+	if in == nil {
+		return nil
+	}
+	out := new(TypeImport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TypeLibrary) DeepCopyInto(out *TypeLibrary) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TypeLibrary.
+func (in *TypeLibrary) DeepCopy() *TypeLibrary {
+	if in == nil {
+		return nil
+	}
+	out := new(TypeLibrary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TypeLibrary) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TypeLibraryList) DeepCopyInto(out *TypeLibraryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TypeLibrary, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TypeLibraryList.
+func (in *TypeLibraryList) DeepCopy() *TypeLibraryList {
+	if in == nil {
+		return nil
+	}
+	out := new(TypeLibraryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TypeLibraryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TypeLibrarySpec) DeepCopyInto(out *TypeLibrarySpec) {
+	*out = *in
+	in.Types.DeepCopyInto(&out.Types)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TypeLibrarySpec.
+func (in *TypeLibrarySpec) DeepCopy() *TypeLibrarySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TypeLibrarySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Validation) DeepCopyInto(out *Validation) {
 	*out = *in
@@ -16,12 +16,15 @@ package main
 
 import (
 	"flag"
+	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"go.uber.org/zap/zapcore"
 	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -30,9 +33,11 @@ import (
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
 	xv1alpha1 "github.com/kubernetes-sigs/kro/api/v1alpha1"
+	krocel "github.com/kubernetes-sigs/kro/pkg/cel"
 	kroclient "github.com/kubernetes-sigs/kro/pkg/client"
 	resourcegraphdefinitionctrl "github.com/kubernetes-sigs/kro/pkg/controller/resourcegraphdefinition"
 	"github.com/kubernetes-sigs/kro/pkg/dynamiccontroller"
+	"github.com/kubernetes-sigs/kro/pkg/globalconfig"
 	"github.com/kubernetes-sigs/kro/pkg/graph"
 	//+kubebuilder:scaffold:imports
 )
@@ -80,6 +85,17 @@ func main() {
 		logLevel int
 		qps      float64
 		burst    int
+		// global configuration exposed to CEL expressions
+		globalConfigValues    = map[string]string{}
+		globalConfigConfigMap string
+		// cost limits of the CEL expressions
+		celExpressionCostLimit uint64
+		celEstimatedCostLimit  uint64
+		celReconcileCostLimit  uint64
+		// incremental reconciles of the instances
+		incrementalReconcile bool
+		// number of sub-resources of an instance processed in parallel
+		instanceResourceConcurrency int
 	)
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8078", "The address the metric endpoint binds to.")
@@ -125,6 +141,39 @@ func main() {
 	flag.IntVar(&burst, "client-burst", 150,
 		"The number of requests that can be stored for processing before the server starts enforcing the QPS limit")
 
+	// global configuration
+	flag.Func("global-config",
+		"A key=value pair exposed to the CEL expressions of every resource graph definition as kro.config.<key>. "+
+			"Can be repeated.",
+		func(value string) error {
+			key, val, ok := strings.Cut(value, "=")
+			if !ok || key == "" {
+				return fmt.Errorf("expected key=value, got %q", value)
+			}
+			globalConfigValues[key] = val
+			return nil
+		})
+	flag.StringVar(&globalConfigConfigMap, "global-config-configmap", "",
+		"The namespace/name of a ConfigMap whose data is exposed to the CEL expressions of every resource "+
+			"graph definition as kro.config.<key>. Its values take precedence over --global-config.")
+
+	// CEL cost limits
+	flag.Uint64Var(&celExpressionCostLimit, "cel-expression-cost-limit", krocel.DefaultExpressionCostLimit,
+		"The maximum actual cost of a single CEL expression, enforced when it is evaluated. "+
+			"0 disables the limit.")
+	flag.Uint64Var(&celEstimatedCostLimit, "cel-estimated-cost-limit", krocel.DefaultEstimatedCostLimit,
+		"The maximum estimated worst case cost of a single CEL expression, enforced when the resource graph "+
+			"definition is built. 0 disables the limit.")
+	flag.Uint64Var(&celReconcileCostLimit, "cel-reconcile-cost-limit", krocel.DefaultReconcileCostLimit,
+		"The maximum cost of all the CEL expressions evaluated while reconciling an instance. "+
+			"0 disables the limit.")
+
+	flag.BoolVar(&incrementalReconcile, "incremental-reconcile", false,
+		"Read the resources of the instances from informer caches, and skip applying the resources "+
+			"whose rendered content didn't change since they were last applied.")
+	flag.IntVar(&instanceResourceConcurrency, "instance-resource-concurrency", 10,
+		"The maximum number of independent resources of an instance processed in parallel. 0 disables the limit.")
+
 	flag.Parse()
 
 	opts := zap.Options{
@@ -184,20 +233,61 @@ func main() {
 		BurstLimit:      burstLimit,
 	}, set.Dynamic())
 
+	globalConfig := globalconfig.NewStore(globalConfigValues)
+	// Instances need to be reconciled again when the configuration changes. The
+	// resource graph definitions using it are enqueued by their controller.
+	globalConfig.OnChange(dc.EnqueueAll)
+	if globalConfigConfigMap != "" {
+		namespace, name, ok := strings.Cut(globalConfigConfigMap, "/")
+		if !ok || namespace == "" || name == "" {
+			setupLog.Error(nil, "invalid global configuration configmap, expected namespace/name",
+				"configmap", globalConfigConfigMap)
+			os.Exit(1)
+		}
+		watcher := globalconfig.NewConfigMapWatcher(
+			rootLogger,
+			set.Kubernetes(),
+			types.NamespacedName{Namespace: namespace, Name: name},
+			globalConfig,
+			time.Duration(resyncPeriod)*time.Second,
+		)
+		if err := mgr.Add(watcher); err != nil {
+			setupLog.Error(err, "unable to add global configuration watcher to manager")
+			os.Exit(1)
+		}
+	}
+
 	resourceGraphDefinitionGraphBuilder, err := graph.NewBuilder(
 		restConfig,
+		graph.WithGlobalConfig(globalConfig),
+		graph.WithCostLimits(krocel.CostLimits{
+			Expression: celExpressionCostLimit,
+			Estimated:  celEstimatedCostLimit,
+			Reconcile:  celReconcileCostLimit,
+		}),
 	)
 	if err != nil {
 		setupLog.Error(err, "unable to create resource graph definition graph builder")
 		os.Exit(1)
 	}
 
+	var objectCache *dynamiccontroller.ObjectCache
+	if incrementalReconcile {
+		objectCache = dynamiccontroller.NewObjectCache(rootLogger, set.Dynamic(), time.Duration(resyncPeriod)*time.Second)
+		if err := mgr.Add(objectCache); err != nil {
+			setupLog.Error(err, "unable to add object cache to manager")
+			os.Exit(1)
+		}
+	}
+
 	rgd := resourcegraphdefinitionctrl.NewResourceGraphDefinitionReconciler(
 		set,
 		allowCRDDeletion,
 		dc,
 		resourceGraphDefinitionGraphBuilder,
 		resourceGraphDefinitionConcurrentReconciles,
+		instanceResourceConcurrency,
+		objectCache,
 	)
 	if err := rgd.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ResourceGraphDefinition")
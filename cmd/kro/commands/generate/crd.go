@@ -15,6 +15,7 @@
 package generate
 
 import (
+	"context"
 	"fmt"
 	"os"
 
@@ -45,7 +46,7 @@ var generateCRDCmd = &cobra.Command{
 			return fmt.Errorf("failed to unmarshal ResourceGraphDefinition: %w", err)
 		}
 
-		if err = generateCRD(&rgd); err != nil {
+		if err = generateCRD(cmd.Context(), &rgd); err != nil {
 			return fmt.Errorf("failed to generate CRD: %w", err)
 		}
 
@@ -53,8 +54,8 @@ var generateCRDCmd = &cobra.Command{
 	},
 }
 
-func generateCRD(rgd *v1alpha1.ResourceGraphDefinition) error {
-	rgdGraph, err := createGraphBuilder(rgd)
+func generateCRD(ctx context.Context, rgd *v1alpha1.ResourceGraphDefinition) error {
+	rgdGraph, err := createGraphBuilder(ctx, rgd)
 	if err != nil {
 		return fmt.Errorf("failed to setup rgd graph: %w", err)
 	}
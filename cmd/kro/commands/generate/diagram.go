@@ -15,7 +15,9 @@
 package generate
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/go-echarts/go-echarts/v2/charts"
@@ -24,13 +26,29 @@ import (
 	"sigs.k8s.io/yaml"
 
 	"github.com/kubernetes-sigs/kro/api/v1alpha1"
+	"github.com/kubernetes-sigs/kro/pkg/graph/diagram"
 )
 
+var diagramFormat string
+
+func init() {
+	// The diagram formats shadow the yaml and json output formats of the
+	// other generate commands.
+	generateDiagramCmd.Flags().StringVarP(&diagramFormat, "format", "o", "html",
+		"Diagram format (html|mermaid|dot|svg)")
+}
+
 var generateDiagramCmd = &cobra.Command{
 	Use:   "diagram",
 	Short: "Generate a diagram from a ResourceGraphDefinition",
 	Long: "Generate a diagram from a ResourceGraphDefinition file. This command reads the " +
-		"ResourceGraphDefinition and outputs the corresponding diagram",
+		"ResourceGraphDefinition and outputs the corresponding diagram: an interactive HTML page, " +
+		"a Mermaid flowchart or a Graphviz DOT graph to embed in Markdown, or an SVG image. " +
+		"The resources with includeWhen expressions have dashed borders (orange in HTML), and " +
+		"the external references are grey.",
+	Example: `  kro generate diagram -f my-rgd.yaml > diagram.html
+  kro generate diagram -f my-rgd.yaml --format mermaid
+  kro generate diagram -f my-rgd.yaml --format svg > diagram.svg`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if config.resourceGraphDefinitionFile == "" {
 			return fmt.Errorf("ResourceGraphDefinition file is required")
@@ -46,7 +64,7 @@ var generateDiagramCmd = &cobra.Command{
 			return fmt.Errorf("failed to unmarshal ResourceGraphDefinition: %w", err)
 		}
 
-		if err = generateDiagram(&rgd); err != nil {
+		if err = generateDiagram(cmd.Context(), &rgd, diagramFormat, cmd.OutOrStdout()); err != nil {
 			return fmt.Errorf("failed to generate diagram: %w", err)
 		}
 
@@ -54,12 +72,32 @@ var generateDiagramCmd = &cobra.Command{
 	},
 }
 
-func generateDiagram(rgd *v1alpha1.ResourceGraphDefinition) error {
-	rgdGraph, err := createGraphBuilder(rgd)
+func generateDiagram(ctx context.Context, rgd *v1alpha1.ResourceGraphDefinition, format string, out io.Writer) error {
+	switch format {
+	case "html", "mermaid", "dot", "svg":
+	default:
+		return fmt.Errorf("unsupported diagram format: %s", format)
+	}
+
+	rgdGraph, err := createGraphBuilder(ctx, rgd)
 	if err != nil {
 		return fmt.Errorf("failed to setup rgd graph: %w", err)
 	}
+	d := diagram.New(rgd.Name, rgdGraph)
+
+	switch format {
+	case "mermaid":
+		return d.WriteMermaid(out)
+	case "dot":
+		return d.WriteDOT(out)
+	case "svg":
+		return d.WriteSVG(out)
+	}
+	return renderHTML(d, rgdGraph.TopologicalOrder, out)
+}
 
+// renderHTML renders the diagram as an interactive HTML page.
+func renderHTML(d *diagram.Diagram, topologicalOrder []string, out io.Writer) error {
 	graph := charts.NewGraph()
 
 	// Graph layout
@@ -69,8 +107,8 @@ func generateDiagram(rgd *v1alpha1.ResourceGraphDefinition) error {
 			Height: "90vh",
 		}),
 		charts.WithTitleOpts(opts.Title{
-			Title:    fmt.Sprintf("Resource Graph: %s", rgd.Name),
-			Subtitle: fmt.Sprintf("Topological Order: %v", rgdGraph.TopologicalOrder),
+			Title:    fmt.Sprintf("Resource Graph: %s", d.Name),
+			Subtitle: fmt.Sprintf("Topological Order: %v", topologicalOrder),
 			TitleStyle: &opts.TextStyle{
 				FontSize:   18,
 				FontWeight: "bold",
@@ -85,44 +123,45 @@ func generateDiagram(rgd *v1alpha1.ResourceGraphDefinition) error {
 	)
 
 	resourceOrder := make(map[string]int)
-	for i, resourceName := range rgdGraph.TopologicalOrder {
+	for i, resourceName := range topologicalOrder {
 		resourceOrder[resourceName] = i + 1
 	}
 
 	// Graph nodes
-	nodes := make([]opts.GraphNode, 0, len(rgdGraph.Resources))
-	for resourceName := range rgdGraph.Resources {
-		order := resourceOrder[resourceName]
-
+	nodes := make([]opts.GraphNode, 0, len(d.Nodes))
+	for _, resource := range d.Nodes {
+		order := resourceOrder[resource.ID]
+
+		itemStyle := &opts.ItemStyle{
+			Color:       "#269103",
+			BorderColor: "#333",
+			BorderWidth: 1,
+		}
+		if resource.Conditional {
+			itemStyle.Color = "#e69d00"
+		}
+		if resource.ExternalRef {
+			itemStyle.Color = "#999"
+		}
 		node := opts.GraphNode{
-			Name:       fmt.Sprintf("%s\n(%d)", resourceName, order),
+			Name:       fmt.Sprintf("%s\n(%d)", resource.ID, order),
 			SymbolSize: 40.0,
-			ItemStyle: &opts.ItemStyle{
-				Color:       "#269103",
-				BorderColor: "#333",
-				BorderWidth: 1,
-			},
+			ItemStyle:  itemStyle,
 		}
 		nodes = append(nodes, node)
 	}
 
 	// Graph links
-	links := make([]opts.GraphLink, 0)
-	for resourceName, resource := range rgdGraph.Resources {
-		targetOrder := resourceOrder[resourceName]
-
-		for _, dependency := range resource.GetDependencies() {
-			sourceOrder := resourceOrder[dependency]
-
-			link := opts.GraphLink{
-				Source: fmt.Sprintf("%s\n(%d)", dependency, sourceOrder),
-				Target: fmt.Sprintf("%s\n(%d)", resourceName, targetOrder),
-				LineStyle: &opts.LineStyle{
-					Color: "#000",
-				},
-			}
-			links = append(links, link)
+	links := make([]opts.GraphLink, 0, len(d.Edges))
+	for _, edge := range d.Edges {
+		link := opts.GraphLink{
+			Source: fmt.Sprintf("%s\n(%d)", edge.From, resourceOrder[edge.From]),
+			Target: fmt.Sprintf("%s\n(%d)", edge.To, resourceOrder[edge.To]),
+			LineStyle: &opts.LineStyle{
+				Color: "#000",
+			},
 		}
+		links = append(links, link)
 	}
 
 	graph.AddSeries("resource", nodes, links).SetSeriesOptions(
@@ -138,5 +177,5 @@ func generateDiagram(rgd *v1alpha1.ResourceGraphDefinition) error {
 		),
 	)
 
-	return graph.Render(os.Stdout)
+	return graph.Render(out)
 }
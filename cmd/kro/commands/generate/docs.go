@@ -0,0 +1,55 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kubernetes-sigs/kro/api/v1alpha1"
+	"github.com/spf13/cobra"
+)
+
+var generateDocsCmd = &cobra.Command{
+	Use:   "docs",
+	Short: "Generate the Markdown reference of the instances",
+	Long: "Generate the Markdown reference of the instances of a ResourceGraphDefinition: " +
+		"the fields of their spec and status, with their types, defaults, allowed values " +
+		"and descriptions, from the schema of the synthesized CRD.",
+	Example: `  kro generate docs -f my-rgd.yaml > docs/webapp.md`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rgd, err := readResourceGraphDefinition()
+		if err != nil {
+			return err
+		}
+
+		if err = generateDocs(cmd.Context(), rgd); err != nil {
+			return fmt.Errorf("failed to generate docs: %w", err)
+		}
+
+		return nil
+	},
+}
+
+func generateDocs(ctx context.Context, rgd *v1alpha1.ResourceGraphDefinition) error {
+	api, err := createAPI(ctx, rgd)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(string(api.Markdown()))
+
+	return nil
+}
@@ -41,6 +41,10 @@ var generateCmd = &cobra.Command{
 func AddGenerateCommands(rootCmd *cobra.Command) {
 	generateCmd.AddCommand(generateCRDCmd)
 	generateCmd.AddCommand(generateDiagramCmd)
+	generateCmd.AddCommand(generateDocsCmd)
+	generateCmd.AddCommand(generateGoTypesCmd)
 	generateCmd.AddCommand(generateInstanceCmd)
+	generateCmd.AddCommand(generateJSONSchemaCmd)
+	generateCmd.AddCommand(generateTypeScriptTypesCmd)
 	rootCmd.AddCommand(generateCmd)
 }
@@ -15,17 +15,21 @@
 package generate
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"os"
 
 	kroclient "github.com/kubernetes-sigs/kro/pkg/client"
 
 	"github.com/kubernetes-sigs/kro/api/v1alpha1"
+	"github.com/kubernetes-sigs/kro/pkg/apigen"
 	"github.com/kubernetes-sigs/kro/pkg/graph"
 	"gopkg.in/yaml.v2"
+	sigsyaml "sigs.k8s.io/yaml"
 )
 
-func createGraphBuilder(rgd *v1alpha1.ResourceGraphDefinition) (*graph.Graph, error) {
+func createGraphBuilder(ctx context.Context, rgd *v1alpha1.ResourceGraphDefinition) (*graph.Graph, error) {
 	set, err := kroclient.NewSet(kroclient.Config{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create client set: %w", err)
@@ -38,7 +42,7 @@ func createGraphBuilder(rgd *v1alpha1.ResourceGraphDefinition) (*graph.Graph, er
 		return nil, fmt.Errorf("failed to create graph builder: %w", err)
 	}
 
-	rgdGraph, err := builder.NewResourceGraphDefinition(rgd)
+	rgdGraph, err := builder.NewResourceGraphDefinition(ctx, rgd)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create resource graph definition: %w", err)
 	}
@@ -46,6 +50,34 @@ func createGraphBuilder(rgd *v1alpha1.ResourceGraphDefinition) (*graph.Graph, er
 	return rgdGraph, nil
 }
 
+// readResourceGraphDefinition reads the ResourceGraphDefinition file.
+func readResourceGraphDefinition() (*v1alpha1.ResourceGraphDefinition, error) {
+	if config.resourceGraphDefinitionFile == "" {
+		return nil, fmt.Errorf("ResourceGraphDefinition file is required")
+	}
+
+	data, err := os.ReadFile(config.resourceGraphDefinitionFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ResourceGraphDefinition file: %w", err)
+	}
+
+	var rgd v1alpha1.ResourceGraphDefinition
+	if err = sigsyaml.Unmarshal(data, &rgd); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ResourceGraphDefinition: %w", err)
+	}
+	return &rgd, nil
+}
+
+// createAPI returns the instance API of the ResourceGraphDefinition, as
+// defined by its synthesized CRD.
+func createAPI(ctx context.Context, rgd *v1alpha1.ResourceGraphDefinition) (*apigen.API, error) {
+	rgdGraph, err := createGraphBuilder(ctx, rgd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to setup rgd graph: %w", err)
+	}
+	return apigen.NewAPI(rgdGraph.Instance.GetCRD())
+}
+
 func marshalObject(obj interface{}, outputFormat string) ([]byte, error) {
 	var b []byte
 	var err error
@@ -15,36 +15,53 @@
 package generate
 
 import (
+	"context"
 	"fmt"
-	"os"
+	"strings"
 
 	"github.com/kubernetes-sigs/kro/api/v1alpha1"
+	"github.com/kubernetes-sigs/kro/pkg/apigen"
+	"github.com/kubernetes-sigs/kro/pkg/graph"
 	"github.com/spf13/cobra"
-	"sigs.k8s.io/yaml"
 )
 
+var (
+	exampleInstance bool
+	minimalInstance bool
+)
+
+func init() {
+	generateInstanceCmd.Flags().BoolVar(&exampleInstance, "example", false,
+		"Generate a deterministic example instance from the schema alone, without a cluster")
+	generateInstanceCmd.Flags().BoolVar(&minimalInstance, "minimal", false,
+		"With --example, only set the required fields of the instance")
+}
+
 var generateInstanceCmd = &cobra.Command{
 	Use:   "instance",
 	Short: "Generate an instance of the ResourceGraphDefinition",
 	Long: "Generate a ResourceGraphDefinition (RGD) instance from a " +
 		"ResourceGraphDefinition file. This command reads the " +
-		"ResourceGraphDefinition and outputs the corresponding RGD instance",
+		"ResourceGraphDefinition and outputs the corresponding RGD instance. " +
+		"With --example, it outputs an example instance built from the schema " +
+		"alone, without a cluster, the same every time: the fields have their " +
+		"defaults, or else the first of their allowed values, or else the " +
+		"smallest values satisfying their constraints. In YAML, the descriptions " +
+		"of the fields are comments.",
+	Example: `  kro generate instance -f my-rgd.yaml
+  kro generate instance -f my-rgd.yaml --example
+  kro generate instance -f my-rgd.yaml --example --minimal`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if config.resourceGraphDefinitionFile == "" {
-			return fmt.Errorf("ResourceGraphDefinition file is required")
+		if minimalInstance && !exampleInstance {
+			return fmt.Errorf("--minimal requires --example")
 		}
 
-		data, err := os.ReadFile(config.resourceGraphDefinitionFile)
+		rgd, err := readResourceGraphDefinition()
 		if err != nil {
-			return fmt.Errorf("failed to read ResourceGraphDefinition file: %w", err)
-		}
-
-		var rgd v1alpha1.ResourceGraphDefinition
-		if err = yaml.Unmarshal(data, &rgd); err != nil {
-			return fmt.Errorf("failed to unmarshal ResourceGraphDefinition: %w", err)
+			return err
 		}
 
-		if err = generateInstance(&rgd); err != nil {
+		if err = generateInstance(cmd.Context(), rgd); err != nil {
 			return fmt.Errorf("failed to generate instance: %w", err)
 		}
 
@@ -52,8 +69,12 @@ var generateInstanceCmd = &cobra.Command{
 	},
 }
 
-func generateInstance(rgd *v1alpha1.ResourceGraphDefinition) error {
-	rgdGraph, err := createGraphBuilder(rgd)
+func generateInstance(ctx context.Context, rgd *v1alpha1.ResourceGraphDefinition) error {
+	if exampleInstance {
+		return generateExampleInstance(rgd)
+	}
+
+	rgdGraph, err := createGraphBuilder(ctx, rgd)
 	if err != nil {
 		return fmt.Errorf("failed to create resource graph definition: %w", err)
 	}
@@ -72,3 +93,30 @@ func generateInstance(rgd *v1alpha1.ResourceGraphDefinition) error {
 
 	return nil
 }
+
+// generateExampleInstance prints the example instance of the
+// ResourceGraphDefinition, generated from its schema without a cluster.
+func generateExampleInstance(rgd *v1alpha1.ResourceGraphDefinition) error {
+	crd, err := graph.SynthesizeInstanceCRD(rgd)
+	if err != nil {
+		return fmt.Errorf("failed to synthesize instance CRD: %w", err)
+	}
+	api, err := apigen.NewAPI(crd)
+	if err != nil {
+		return fmt.Errorf("failed to create instance API: %w", err)
+	}
+
+	var b []byte
+	if config.outputFormat == "yaml" {
+		b, err = api.ExampleYAML(minimalInstance)
+	} else {
+		b, err = marshalObject(api.Example(minimalInstance), config.outputFormat)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to marshal instance: %w", err)
+	}
+
+	fmt.Println(strings.TrimSuffix(string(b), "\n"))
+
+	return nil
+}
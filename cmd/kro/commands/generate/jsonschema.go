@@ -0,0 +1,61 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kubernetes-sigs/kro/api/v1alpha1"
+	"github.com/spf13/cobra"
+)
+
+var generateJSONSchemaCmd = &cobra.Command{
+	Use:   "jsonschema",
+	Short: "Generate the JSON Schema of the instances",
+	Long: "Generate the JSON Schema of the instances of a ResourceGraphDefinition, " +
+		"to validate instance files in editors. With the YAML language server, " +
+		"reference it from the instance files with a modeline comment: " +
+		"# yaml-language-server: $schema=<path-to-schema>",
+	Example: `  kro generate jsonschema -f my-rgd.yaml > webapp.schema.json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rgd, err := readResourceGraphDefinition()
+		if err != nil {
+			return err
+		}
+
+		if err = generateJSONSchema(cmd.Context(), rgd); err != nil {
+			return fmt.Errorf("failed to generate JSON Schema: %w", err)
+		}
+
+		return nil
+	},
+}
+
+func generateJSONSchema(ctx context.Context, rgd *v1alpha1.ResourceGraphDefinition) error {
+	api, err := createAPI(ctx, rgd)
+	if err != nil {
+		return err
+	}
+
+	b, err := api.JSONSchema()
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON Schema: %w", err)
+	}
+
+	fmt.Println(string(b))
+
+	return nil
+}
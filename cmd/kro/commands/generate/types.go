@@ -0,0 +1,102 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kubernetes-sigs/kro/api/v1alpha1"
+	"github.com/spf13/cobra"
+)
+
+var goPackageName string
+
+func init() {
+	generateGoTypesCmd.Flags().StringVar(&goPackageName, "package", "",
+		"Name of the Go package (default: the version of the API, e.g. v1alpha1)")
+}
+
+var generateGoTypesCmd = &cobra.Command{
+	Use:   "go-types",
+	Short: "Generate the Go types of the instances",
+	Long: "Generate the Go types of the instances of a ResourceGraphDefinition, " +
+		"with the json tags of their spec and status fields, to create and read " +
+		"instances with Go clients.",
+	Example: `  kro generate go-types -f my-rgd.yaml --package webapp > webapp/types.go`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rgd, err := readResourceGraphDefinition()
+		if err != nil {
+			return err
+		}
+
+		if err = generateGoTypes(cmd.Context(), rgd); err != nil {
+			return fmt.Errorf("failed to generate Go types: %w", err)
+		}
+
+		return nil
+	},
+}
+
+var generateTypeScriptTypesCmd = &cobra.Command{
+	Use:   "ts-types",
+	Short: "Generate the TypeScript types of the instances",
+	Long: "Generate the TypeScript interfaces of the instances of a " +
+		"ResourceGraphDefinition, to create and read instances with TypeScript clients.",
+	Example: `  kro generate ts-types -f my-rgd.yaml > webapp.ts`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rgd, err := readResourceGraphDefinition()
+		if err != nil {
+			return err
+		}
+
+		if err = generateTypeScriptTypes(cmd.Context(), rgd); err != nil {
+			return fmt.Errorf("failed to generate TypeScript types: %w", err)
+		}
+
+		return nil
+	},
+}
+
+func generateGoTypes(ctx context.Context, rgd *v1alpha1.ResourceGraphDefinition) error {
+	api, err := createAPI(ctx, rgd)
+	if err != nil {
+		return err
+	}
+
+	packageName := goPackageName
+	if packageName == "" {
+		packageName = api.Version
+	}
+	b, err := api.GoTypes(packageName)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(string(b))
+
+	return nil
+}
+
+func generateTypeScriptTypes(ctx context.Context, rgd *v1alpha1.ResourceGraphDefinition) error {
+	api, err := createAPI(ctx, rgd)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(string(api.TypeScriptTypes()))
+
+	return nil
+}
@@ -18,10 +18,14 @@ import (
 	"github.com/spf13/cobra"
 
 	generate "github.com/kubernetes-sigs/kro/cmd/kro/commands/generate"
+	snapshot "github.com/kubernetes-sigs/kro/cmd/kro/commands/snapshot"
+	test "github.com/kubernetes-sigs/kro/cmd/kro/commands/test"
 	validate "github.com/kubernetes-sigs/kro/cmd/kro/commands/validate"
 )
 
 func AddCommands(root *cobra.Command) {
 	generate.AddGenerateCommands(root)
+	snapshot.AddSnapshotCommands(root)
+	test.AddTestCommands(root)
 	validate.AddValidateCommands(root)
 }
@@ -0,0 +1,142 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/cel/openapi/resolver"
+	"k8s.io/client-go/discovery"
+
+	kroclient "github.com/kubernetes-sigs/kro/pkg/client"
+	"github.com/kubernetes-sigs/kro/pkg/graph/schema/offline"
+)
+
+type SnapshotConfig struct {
+	outputFile string
+	groups     []string
+}
+
+var config = &SnapshotConfig{}
+
+func init() {
+	snapshotSchemasCmd.Flags().StringVarP(&config.outputFile, "output", "o", "",
+		"Path to the schema bundle file to write (default: stdout)")
+	snapshotSchemasCmd.Flags().StringSliceVar(&config.groups, "group", nil,
+		"API group of the resources to snapshot, core for the core group (can be repeated)")
+}
+
+var snapshotSchemasCmd = &cobra.Command{
+	Use:   "snapshot-schemas [KIND[.VERSION][.GROUP]]...",
+	Short: "Snapshot the OpenAPI schemas of the cluster resources",
+	Long: "Snapshot the OpenAPI schemas of the resources served by the cluster, " +
+		"and whether they are namespaced, into a schema bundle. The bundle can be " +
+		"used to validate ResourceGraphDefinitions without the cluster, e.g. in CI. " +
+		"All the resources are snapshotted unless some kinds or groups are selected. " +
+		"A kind without a group selects the resources of that kind in every group.",
+	Example: `  kro snapshot-schemas -o schemas.json
+  kro snapshot-schemas Deployment.apps --group ec2.services.k8s.aws -o schemas.json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		set, err := kroclient.NewSet(kroclient.Config{})
+		if err != nil {
+			return fmt.Errorf("failed to create client set: %w", err)
+		}
+		discoveryClient, err := discovery.NewDiscoveryClientForConfig(set.RESTConfig())
+		if err != nil {
+			return fmt.Errorf("failed to create discovery client: %w", err)
+		}
+
+		bundle, err := offline.SnapshotSchemas(
+			discoveryClient,
+			&resolver.ClientDiscoveryResolver{Discovery: discoveryClient},
+			newSelector(args, config.groups),
+		)
+		if bundle == nil {
+			return fmt.Errorf("failed to snapshot schemas: %w", err)
+		}
+		if err != nil {
+			// The resources that couldn't be snapshotted are only warned about.
+			fmt.Fprintf(cmd.ErrOrStderr(), "Warning: some schemas were not snapshotted: %v\n", err)
+		}
+
+		if err := writeBundle(bundle, config.outputFile, cmd.OutOrStdout()); err != nil {
+			return fmt.Errorf("failed to write schema bundle: %w", err)
+		}
+		if config.outputFile != "" {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Snapshotted %d schemas to %s\n", len(bundle.Schemas), config.outputFile)
+		}
+		return nil
+	},
+}
+
+// newSelector returns the selector of the resources of the given kinds, in the
+// KIND[.VERSION][.GROUP] form, and groups. A kind without a group selects the
+// resources of that kind in every group. It selects all the resources when
+// there are neither kinds nor groups.
+func newSelector(kinds, groups []string) func(schema.GroupVersionKind) bool {
+	if len(kinds) == 0 && len(groups) == 0 {
+		return nil
+	}
+	for i, group := range groups {
+		if group == "core" {
+			groups[i] = ""
+		}
+	}
+	return func(gvk schema.GroupVersionKind) bool {
+		if slices.Contains(groups, gvk.Group) {
+			return true
+		}
+		for _, kind := range kinds {
+			if !strings.Contains(kind, ".") {
+				if kind == gvk.Kind {
+					return true
+				}
+				continue
+			}
+			fullySpecified, groupKind := schema.ParseKindArg(kind)
+			if (fullySpecified != nil && *fullySpecified == gvk) || groupKind == gvk.GroupKind() {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// writeBundle writes the bundle to the given file, or to stdout when the file
+// is empty.
+func writeBundle(bundle *offline.SchemaBundle, outputFile string, stdout io.Writer) error {
+	if outputFile == "" {
+		return bundle.Write(stdout)
+	}
+	f, err := os.Create(outputFile)
+	if err != nil {
+		return err
+	}
+	if err := bundle.Write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func AddSnapshotCommands(rootCmd *cobra.Command) {
+	rootCmd.AddCommand(snapshotSchemasCmd)
+}
@@ -0,0 +1,58 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestNewSelector(t *testing.T) {
+	deployment := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+	customDeployment := schema.GroupVersionKind{Group: "example.com", Version: "v1alpha1", Kind: "Deployment"}
+	service := schema.GroupVersionKind{Version: "v1", Kind: "Service"}
+	bucket := schema.GroupVersionKind{Group: "s3.services.k8s.aws", Version: "v1alpha1", Kind: "Bucket"}
+
+	assert.Nil(t, newSelector(nil, nil))
+
+	tests := []struct {
+		name     string
+		kinds    []string
+		groups   []string
+		selected []schema.GroupVersionKind
+	}{
+		{"kind in every group", []string{"Deployment"}, nil, []schema.GroupVersionKind{deployment, customDeployment}},
+		{"kind and group", []string{"Deployment.apps"}, nil, []schema.GroupVersionKind{deployment}},
+		{"kind, version and group", []string{"Deployment.v1alpha1.example.com"}, nil, []schema.GroupVersionKind{customDeployment}},
+		{"core kind", []string{"Service"}, nil, []schema.GroupVersionKind{service}},
+		{"core group", nil, []string{"core"}, []schema.GroupVersionKind{service}},
+		{"kinds and groups", []string{"Deployment.apps"}, []string{"s3.services.k8s.aws"}, []schema.GroupVersionKind{deployment, bucket}},
+		{"unknown kind", []string{"Deploymnt"}, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selector := newSelector(tt.kinds, tt.groups)
+			var selected []schema.GroupVersionKind
+			for _, gvk := range []schema.GroupVersionKind{deployment, customDeployment, service, bucket} {
+				if selector(gvk) {
+					selected = append(selected, gvk)
+				}
+			}
+			assert.Equal(t, tt.selected, selected)
+		})
+	}
+}
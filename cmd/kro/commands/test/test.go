@@ -0,0 +1,174 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kubernetes-sigs/kro/pkg/graph"
+	"github.com/kubernetes-sigs/kro/pkg/graph/schema/offline"
+	"github.com/kubernetes-sigs/kro/pkg/rgdtest"
+)
+
+// suiteFileSuffix is the suffix of the test suite files found in directories.
+const suiteFileSuffix = "_test.yaml"
+
+type TestConfig struct {
+	schemaBundles []string
+	crdPaths      []string
+}
+
+var config = &TestConfig{}
+
+func init() {
+	testCmd.Flags().StringSliceVar(&config.schemaBundles, "schema-bundle", nil,
+		"Path to a schema bundle, or directory of bundles, written by kro snapshot-schemas (can be repeated)")
+	testCmd.Flags().StringSliceVar(&config.crdPaths, "crd-path", nil,
+		"Path to a CustomResourceDefinition file, or directory of files, of the resources "+
+			"not built into Kubernetes (can be repeated)")
+	testCmd.MarkFlagsMutuallyExclusive("schema-bundle", "crd-path")
+}
+
+var testCmd = &cobra.Command{
+	Use:   "test [PATH]...",
+	Short: "Run the unit tests of ResourceGraphDefinitions",
+	Long: "Run the unit tests of ResourceGraphDefinitions, without a cluster. The paths are test " +
+		"suite files, or directories searched for *" + suiteFileSuffix + " files, the current " +
+		"directory by default. Each suite names a ResourceGraphDefinition file and lists instances, " +
+		"the states observed for their resources, and the expected manifests, readiness and status. " +
+		"The schemas of the resources are resolved from the schema bundles, or else from the " +
+		"built-in Kubernetes types and the given CustomResourceDefinitions.",
+	Example: `  kro test ./rgds
+  kro test webapp_test.yaml --schema-bundle schemas.json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			args = []string{"."}
+		}
+		suites, err := findSuites(args)
+		if err != nil {
+			return err
+		}
+		if len(suites) == 0 {
+			return fmt.Errorf("no test suites found")
+		}
+
+		builder, err := newGraphBuilder()
+		if err != nil {
+			return fmt.Errorf("failed to create graph builder: %w", err)
+		}
+
+		passed, failed := 0, 0
+		for _, path := range suites {
+			p, f := runSuite(cmd.Context(), cmd.OutOrStdout(), builder, path)
+			passed += p
+			failed += f
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "\n%d passed, %d failed\n", passed, failed)
+		if failed > 0 {
+			return fmt.Errorf("%d tests failed", failed)
+		}
+		return nil
+	},
+}
+
+// runSuite runs the test suite in the given file, prints the results, and
+// returns the numbers of passed and failed tests. A suite that can't run counts
+// as a failed test.
+func runSuite(ctx context.Context, out io.Writer, builder *graph.Builder, path string) (passed, failed int) {
+	suite, err := rgdtest.LoadSuite(path)
+	if err != nil {
+		fmt.Fprintf(out, "FAIL %s\n    %v\n", path, err)
+		return 0, 1
+	}
+	results, err := suite.Run(ctx, builder)
+	if err != nil {
+		fmt.Fprintf(out, "FAIL %s\n    %v\n", path, err)
+		return 0, 1
+	}
+
+	for _, result := range results {
+		if result.Passed() {
+			fmt.Fprintf(out, "PASS %s: %s\n", path, result.Name)
+			passed++
+			continue
+		}
+		fmt.Fprintf(out, "FAIL %s: %s\n", path, result.Name)
+		for _, failure := range result.Failures {
+			fmt.Fprintf(out, "    %s\n", failure)
+		}
+		failed++
+	}
+	return passed, failed
+}
+
+// findSuites returns the given test suite files, and the test suite files in
+// the given directories and their subdirectories.
+func findSuites(paths []string) ([]string, error) {
+	var suites []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			suites = append(suites, path)
+			continue
+		}
+		err = filepath.WalkDir(path, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.IsDir() && strings.HasSuffix(d.Name(), suiteFileSuffix) {
+				suites = append(suites, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to find test suites in %s: %w", path, err)
+		}
+	}
+	return suites, nil
+}
+
+// newGraphBuilder creates a graph builder resolving the schemas from the
+// schema bundles when there are some, or else from the built-in types and the
+// CustomResourceDefinitions.
+func newGraphBuilder() (*graph.Builder, error) {
+	if len(config.schemaBundles) > 0 {
+		bundleResolver, err := offline.LoadBundleResolver(config.schemaBundles...)
+		if err != nil {
+			return nil, err
+		}
+		return graph.NewOfflineBuilder(bundleResolver, bundleResolver), nil
+	}
+
+	localResolver := offline.NewLocalResolver()
+	if err := localResolver.LoadCRDs(config.crdPaths...); err != nil {
+		return nil, err
+	}
+	return graph.NewOfflineBuilder(localResolver, localResolver), nil
+}
+
+func AddTestCommands(rootCmd *cobra.Command) {
+	rootCmd.AddCommand(testCmd)
+}
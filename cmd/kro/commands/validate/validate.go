@@ -15,12 +15,14 @@
 package validate
 
 import (
+	"context"
 	"fmt"
 	"os"
 
 	"github.com/kubernetes-sigs/kro/api/v1alpha1"
 	kroclient "github.com/kubernetes-sigs/kro/pkg/client"
 	"github.com/kubernetes-sigs/kro/pkg/graph"
+	"github.com/kubernetes-sigs/kro/pkg/graph/schema/offline"
 	"github.com/spf13/cobra"
 	"sigs.k8s.io/yaml"
 )
@@ -32,11 +34,17 @@ var validateCmd = &cobra.Command{
 		`if the ResourceGraphDefinition is valid and can be used to create a ResourceGraph.`,
 }
 
-var resourceGroupDefinitionFile string
+var (
+	resourceGroupDefinitionFile string
+	schemaBundles               []string
+)
 
 func init() {
 	validateRGDCmd.PersistentFlags().StringVarP(&resourceGroupDefinitionFile, "file", "f", "",
 		"Path to the ResourceGroupDefinition file")
+	validateRGDCmd.PersistentFlags().StringSliceVar(&schemaBundles, "schema-bundle", nil,
+		"Path to a schema bundle, or directory of bundles, written by kro snapshot-schemas "+
+			"to validate against instead of the cluster (can be repeated)")
 }
 
 var validateRGDCmd = &cobra.Command{
@@ -57,7 +65,7 @@ var validateRGDCmd = &cobra.Command{
 			return fmt.Errorf("failed to unmarshal ResourceGroupDefinition: %w", err)
 		}
 
-		if err := validateRGD(&rgd); err != nil {
+		if err := validateRGD(cmd.Context(), &rgd); err != nil {
 			return fmt.Errorf("validation failed: %w", err)
 		}
 
@@ -66,20 +74,13 @@ var validateRGDCmd = &cobra.Command{
 	},
 }
 
-func validateRGD(rgd *v1alpha1.ResourceGraphDefinition) error {
-	set, err := kroclient.NewSet(kroclient.Config{})
-	if err != nil {
-		return fmt.Errorf("failed to create client set: %w", err)
-	}
-
-	restConfig := set.RESTConfig()
-
-	builder, err := graph.NewBuilder(restConfig)
+func validateRGD(ctx context.Context, rgd *v1alpha1.ResourceGraphDefinition) error {
+	builder, err := newGraphBuilder()
 	if err != nil {
 		return fmt.Errorf("failed to create graph builder: %w", err)
 	}
 
-	_, err = builder.NewResourceGraphDefinition(rgd)
+	_, err = builder.NewResourceGraphDefinition(ctx, rgd)
 	if err != nil {
 		return fmt.Errorf("failed to create ResourceGraphDefinition: %w", err)
 	}
@@ -87,6 +88,24 @@ func validateRGD(rgd *v1alpha1.ResourceGraphDefinition) error {
 	return nil
 }
 
+// newGraphBuilder creates a graph builder resolving the schemas from the
+// schema bundles when there are some, or else from the cluster.
+func newGraphBuilder() (*graph.Builder, error) {
+	if len(schemaBundles) > 0 {
+		bundleResolver, err := offline.LoadBundleResolver(schemaBundles...)
+		if err != nil {
+			return nil, err
+		}
+		return graph.NewBuilder(nil, graph.WithSchemaResolver(bundleResolver, bundleResolver))
+	}
+
+	set, err := kroclient.NewSet(kroclient.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client set: %w", err)
+	}
+	return graph.NewBuilder(set.RESTConfig())
+}
+
 func AddValidateCommands(rootCmd *cobra.Command) {
 	validateCmd.AddCommand(validateRGDCmd)
 	rootCmd.AddCommand(validateCmd)
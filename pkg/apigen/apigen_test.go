@@ -0,0 +1,339 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apigen
+
+import (
+	"context"
+	"encoding/json"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kubernetes-sigs/kro/api/v1alpha1"
+	"github.com/kubernetes-sigs/kro/pkg/graph"
+	"github.com/kubernetes-sigs/kro/pkg/graph/schema/offline"
+)
+
+const webappRGD = `apiVersion: kro.run/v1alpha1
+kind: ResourceGraphDefinition
+metadata:
+  name: webapp
+spec:
+  schema:
+    apiVersion: v1alpha1
+    kind: WebApp
+    spec:
+      image: string | required=true description="Container image to run"
+      replicas: integer | default=2 minimum=1 maximum=10
+      tier: string | enum="web,worker" default=web
+      apiURL: string | immutable=true
+      env: "map[string]string"
+      ports: "[]integer"
+      ingress:
+        enabled: boolean | default=false
+        host: string
+    status:
+      availableReplicas: ${settings.data.?replicas.orValue("0")}
+  resources:
+  - id: settings
+    template:
+      apiVersion: v1
+      kind: ConfigMap
+      metadata:
+        name: ${schema.metadata.name}
+      data:
+        image: ${schema.spec.image}
+`
+
+func newWebappAPI(t *testing.T) *API {
+	rgd := &v1alpha1.ResourceGraphDefinition{}
+	require.NoError(t, yaml.Unmarshal([]byte(webappRGD), rgd))
+	local := offline.NewLocalResolver()
+	g, err := graph.NewOfflineBuilder(local, local).NewResourceGraphDefinition(context.Background(), rgd)
+	require.NoError(t, err)
+	api, err := NewAPI(g.Instance.GetCRD())
+	require.NoError(t, err)
+	return api
+}
+
+func TestNewAPI(t *testing.T) {
+	api := newWebappAPI(t)
+	assert.Equal(t, "kro.run", api.Group)
+	assert.Equal(t, "v1alpha1", api.Version)
+	assert.Equal(t, "WebApp", api.Kind)
+	assert.Equal(t, "kro.run/v1alpha1", api.APIVersion())
+	assert.Contains(t, api.Spec.Properties, "image")
+	assert.Contains(t, api.Status.Properties, "availableReplicas")
+
+	_, err := NewAPI(&extv1.CustomResourceDefinition{})
+	assert.ErrorContains(t, err, "has no schema")
+}
+
+func TestAPI_JSONSchema(t *testing.T) {
+	data, err := newWebappAPI(t).JSONSchema()
+	require.NoError(t, err)
+
+	var schema map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &schema))
+	assert.Equal(t, "http://json-schema.org/draft-07/schema#", schema["$schema"])
+	assert.Equal(t, []interface{}{"apiVersion", "kind", "metadata"}, schema["required"])
+
+	properties := schema["properties"].(map[string]interface{})
+	assert.Equal(t, []interface{}{"kro.run/v1alpha1"}, properties["apiVersion"].(map[string]interface{})["enum"])
+	assert.NotContains(t, properties, "status")
+
+	spec := properties["spec"].(map[string]interface{})
+	assert.Equal(t, false, spec["additionalProperties"])
+	assert.Equal(t, []interface{}{"image"}, spec["required"])
+	specProperties := spec["properties"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{
+		"type":        "string",
+		"description": "Container image to run",
+	}, specProperties["image"])
+	assert.Equal(t, map[string]interface{}{
+		"type":    "integer",
+		"default": float64(2),
+		"minimum": float64(1),
+		"maximum": float64(10),
+	}, specProperties["replicas"])
+	assert.Equal(t, []interface{}{"web", "worker"}, specProperties["tier"].(map[string]interface{})["enum"])
+	assert.Equal(t, map[string]interface{}{"type": "string"},
+		specProperties["env"].(map[string]interface{})["additionalProperties"])
+}
+
+func TestJSONSchema_KubernetesExtensions(t *testing.T) {
+	preserve := true
+	assert.Equal(t, map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"port": map[string]interface{}{
+				"anyOf": []interface{}{
+					map[string]interface{}{"type": "integer"},
+					map[string]interface{}{"type": "string"},
+				},
+			},
+			"note": map[string]interface{}{"type": []string{"string", "null"}},
+		},
+	}, jsonSchema(extv1.JSONSchemaProps{
+		Type:                   "object",
+		XPreserveUnknownFields: &preserve,
+		Properties: map[string]extv1.JSONSchemaProps{
+			"port": {XIntOrString: true},
+			"note": {Type: "string", Nullable: true},
+		},
+	}))
+}
+
+func TestAPI_GoTypes(t *testing.T) {
+	src, err := newWebappAPI(t).GoTypes("v1alpha1")
+	require.NoError(t, err)
+
+	_, err = parser.ParseFile(token.NewFileSet(), "types.go", src, parser.AllErrors)
+	require.NoError(t, err)
+	for _, expected := range []string{
+		"package v1alpha1\n",
+		"type WebApp struct {",
+		"type WebAppList struct {",
+		"\t// Container image to run\n\tImage    string             `json:\"image\"`\n",
+		"\tIngress  *WebAppSpecIngress `json:\"ingress,omitempty\"`\n",
+		"\tEnv    map[string]string `json:\"env,omitempty\"`\n",
+		"\tAPIURL *string           `json:\"apiURL,omitempty\"`\n",
+		"\tPorts    []int64            `json:\"ports,omitempty\"`\n",
+		"type WebAppSpecIngress struct {\n\tEnabled *bool   `json:\"enabled,omitempty\"`\n",
+		"\tConditions        []WebAppStatusConditionsItem `json:\"conditions,omitempty\"`\n",
+	} {
+		assert.Contains(t, string(src), expected)
+	}
+}
+
+func TestAPI_TypeScriptTypes(t *testing.T) {
+	ts := string(newWebappAPI(t).TypeScriptTypes())
+	for _, expected := range []string{
+		"export interface WebApp {\n  apiVersion: \"kro.run/v1alpha1\";\n  kind: \"WebApp\";\n",
+		"  /** Container image to run */\n  image: string;\n",
+		"  tier?: \"web\" | \"worker\";\n",
+		"  ports?: number[];\n",
+		"  env?: Record<string, string>;\n",
+		"  conditions?: WebAppStatusConditionsItem[];\n",
+	} {
+		assert.Contains(t, ts, expected)
+	}
+}
+
+func TestTypeScriptType(t *testing.T) {
+	enum := typeRef{kind: kindString, enum: []string{`"a"`, `"b"`}}
+	assert.Equal(t, `("a" | "b")[]`, typeScriptType(typeRef{kind: kindList, elem: &enum}))
+	assert.Equal(t, "number | string", typeScriptType(typeRef{kind: kindIntOrString}))
+	assert.Equal(t, "unknown", typeScriptType(typeRef{kind: kindAny}))
+}
+
+func TestAPI_Markdown(t *testing.T) {
+	md := string(newWebappAPI(t).Markdown())
+	for _, expected := range []string{
+		"# WebApp\n\nAPI version: `kro.run/v1alpha1`, kind: `WebApp`.\n",
+		"| `image` | string | yes |  | Container image to run. |\n",
+		"| `replicas` | integer |  | `2` | Minimum: 1. Maximum: 10. |\n",
+		"| `tier` | string |  | `\"web\"` | One of `\"web\"`, `\"worker\"`. |\n",
+		"| `apiURL` | string |  |  | Validation: field is immutable. |\n",
+		"| `ingress.enabled` | boolean |  | `false` |  |\n",
+		"| `env` | map[string]string |  |  |  |\n",
+		"## Status\n\n| Field | Type | Description |\n",
+		"| `conditions[].reason` | string |  |\n",
+	} {
+		assert.Contains(t, md, expected)
+	}
+}
+
+func TestExportedName(t *testing.T) {
+	for name, expected := range map[string]string{
+		"image":       "Image",
+		"apiURL":      "APIURL",
+		"userId":      "UserID",
+		"max-retries": "MaxRetries",
+		"dns.name":    "DNSName",
+		"9lives":      "X9lives",
+	} {
+		assert.Equal(t, expected, exportedName(name), name)
+	}
+}
+
+func TestAPI_Example(t *testing.T) {
+	api := newWebappAPI(t)
+
+	assert.Equal(t, map[string]interface{}{
+		"apiVersion": "kro.run/v1alpha1",
+		"kind":       "WebApp",
+		"metadata":   map[string]interface{}{"name": "webapp-sample"},
+		"spec": map[string]interface{}{
+			"apiURL":   "apiURL",
+			"env":      map[string]interface{}{"key": "value"},
+			"image":    "image",
+			"ingress":  map[string]interface{}{"enabled": false, "host": "host"},
+			"ports":    []interface{}{int64(0)},
+			"replicas": float64(2),
+			"tier":     "web",
+		},
+	}, api.Example(false))
+	assert.Equal(t, map[string]interface{}{"image": "image"}, api.Example(true)["spec"])
+}
+
+func TestAPI_ExampleYAML(t *testing.T) {
+	api := newWebappAPI(t)
+
+	full, err := api.ExampleYAML(false)
+	require.NoError(t, err)
+	assert.Equal(t, `apiVersion: kro.run/v1alpha1
+kind: WebApp
+metadata:
+  name: webapp-sample
+spec:
+  apiURL: apiURL
+  env:
+    key: value
+  # Container image to run
+  image: image
+  ingress:
+    enabled: false
+    host: host
+  ports:
+    - 0
+  replicas: 2
+  tier: web
+`, string(full))
+
+	minimal, err := api.ExampleYAML(true)
+	require.NoError(t, err)
+	assert.Equal(t, `apiVersion: kro.run/v1alpha1
+kind: WebApp
+metadata:
+  name: webapp-sample
+spec:
+  # Container image to run
+  image: image
+`, string(minimal))
+}
+
+func TestExampleValue(t *testing.T) {
+	float := func(f float64) *float64 { return &f }
+	integer := func(i int64) *int64 { return &i }
+
+	tests := []struct {
+		name     string
+		props    extv1.JSONSchemaProps
+		expected interface{}
+	}{
+		{"minimum", extv1.JSONSchemaProps{Type: "integer", Minimum: float(3)}, int64(3)},
+		{"exclusive minimum", extv1.JSONSchemaProps{Type: "integer", Minimum: float(0), ExclusiveMinimum: true}, int64(1)},
+		{"negative maximum", extv1.JSONSchemaProps{Type: "integer", Maximum: float(-2)}, int64(-2)},
+		{"multiple of", extv1.JSONSchemaProps{Type: "integer", Minimum: float(5), MultipleOf: float(4)}, int64(8)},
+		{"number", extv1.JSONSchemaProps{Type: "number", Minimum: float(0.5)}, 0.5},
+		{"int or string", extv1.JSONSchemaProps{XIntOrString: true}, int64(0)},
+		{"enum", extv1.JSONSchemaProps{Type: "integer", Enum: []extv1.JSON{{Raw: []byte("7")}}}, float64(7)},
+		{"format", extv1.JSONSchemaProps{Type: "string", Format: "email"}, "user@example.com"},
+		{"min length", extv1.JSONSchemaProps{Type: "string", MinLength: integer(6)}, "fieldx"},
+		{"max length", extv1.JSONSchemaProps{Type: "string", MaxLength: integer(2)}, "fi"},
+		{"pattern", extv1.JSONSchemaProps{Type: "string", Pattern: `^[a-z]([-a-z0-9]*[a-z0-9])?$`}, "a"},
+		{
+			"pattern and min length",
+			extv1.JSONSchemaProps{Type: "string", Pattern: `^[a-z]([-a-z0-9]*[a-z0-9])?$`, MinLength: integer(3)},
+			"aaa",
+		},
+		{
+			"pattern and lengths",
+			extv1.JSONSchemaProps{Type: "string", Pattern: `^[a-z]+$`, MinLength: integer(3), MaxLength: integer(4)},
+			"aaa",
+		},
+		{
+			"min items",
+			extv1.JSONSchemaProps{
+				Type:     "array",
+				MinItems: integer(2),
+				Items:    &extv1.JSONSchemaPropsOrArray{Schema: &extv1.JSONSchemaProps{Type: "boolean"}},
+			},
+			[]interface{}{false, false},
+		},
+		{"any", extv1.JSONSchemaProps{}, map[string]interface{}{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, exampleValue("field", tt.props, false))
+		})
+	}
+}
+
+func TestPatternExample(t *testing.T) {
+	for pattern, expected := range map[string]string{
+		`^\d{3}-[A-Z]{2}$`:  "000-AA",
+		`(foo|bar)+baz`:     "foobaz",
+		`^v[0-9]+\.[0-9]+$`: "v0.0",
+		`^[^a-z]*x$`:        "x",
+	} {
+		value, ok := patternExample(pattern, nil, nil)
+		assert.True(t, ok, pattern)
+		assert.Equal(t, expected, value, pattern)
+	}
+
+	_, ok := patternExample(`(`, nil, nil)
+	assert.False(t, ok)
+
+	maxLength := int64(2)
+	_, ok = patternExample(`^[a-z]{3}$`, nil, &maxLength)
+	assert.False(t, ok)
+}
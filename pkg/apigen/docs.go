@@ -0,0 +1,162 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apigen
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// Markdown returns the Markdown reference of the fields of the spec and
+// status of the instances: their types, defaults, allowed values, constraints
+// and descriptions. The fields of nested objects are listed after them, with
+// their full paths.
+func (a *API) Markdown() []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "# %s\n\n", a.Kind)
+	fmt.Fprintf(&b, "API version: `%s`, kind: `%s`.\n\n", a.APIVersion(), a.Kind)
+
+	fmt.Fprintf(&b, "## Spec\n\n")
+	if len(a.Spec.Properties) == 0 {
+		fmt.Fprintf(&b, "The spec has no fields.\n")
+	} else {
+		fmt.Fprintln(&b, "| Field | Type | Required | Default | Description |")
+		fmt.Fprintln(&b, "| --- | --- | --- | --- | --- |")
+		writeMarkdownFields(&b, "", a.Spec, true)
+	}
+
+	fmt.Fprintf(&b, "\n## Status\n\n")
+	if len(a.Status.Properties) == 0 {
+		fmt.Fprintf(&b, "The status has no fields.\n")
+	} else {
+		fmt.Fprintln(&b, "| Field | Type | Description |")
+		fmt.Fprintln(&b, "| --- | --- | --- |")
+		writeMarkdownFields(&b, "", a.Status, false)
+	}
+	return b.Bytes()
+}
+
+// writeMarkdownFields writes the table rows of the fields of the object
+// schema, at the given path, followed by those of their nested fields. The
+// rows of the spec fields have the required and default columns.
+func writeMarkdownFields(b *bytes.Buffer, path string, schema extv1.JSONSchemaProps, spec bool) {
+	required := map[string]bool{}
+	for _, property := range schema.Required {
+		required[property] = true
+	}
+	for _, property := range sortedProperties(schema) {
+		props := schema.Properties[property]
+		fieldPath := property
+		if path != "" {
+			fieldPath = path + "." + property
+		}
+
+		if spec {
+			requiredColumn, defaultColumn := "", ""
+			if required[property] {
+				requiredColumn = "yes"
+			}
+			if props.Default != nil {
+				defaultColumn = "`" + string(props.Default.Raw) + "`"
+			}
+			fmt.Fprintf(b, "| `%s` | %s | %s | %s | %s |\n", fieldPath, markdownType(props),
+				requiredColumn, escapeMarkdown(defaultColumn), markdownDescription(props))
+		} else {
+			fmt.Fprintf(b, "| `%s` | %s | %s |\n", fieldPath, markdownType(props), markdownDescription(props))
+		}
+
+		// The fields of the objects in lists and maps are listed as well.
+		nested := props
+		for {
+			switch {
+			case nested.Type == "array" && nested.Items != nil && nested.Items.Schema != nil:
+				nested = *nested.Items.Schema
+				fieldPath += "[]"
+				continue
+			case nested.Type == "object" && len(nested.Properties) == 0 &&
+				nested.AdditionalProperties != nil && nested.AdditionalProperties.Schema != nil:
+				nested = *nested.AdditionalProperties.Schema
+				fieldPath += ".*"
+				continue
+			}
+			break
+		}
+		if len(nested.Properties) > 0 {
+			writeMarkdownFields(b, fieldPath, nested, spec)
+		}
+	}
+}
+
+// markdownType returns the type of a field in the reference.
+func markdownType(props extv1.JSONSchemaProps) string {
+	switch {
+	case props.XIntOrString:
+		return "integer or string"
+	case props.Type == "array" && props.Items != nil && props.Items.Schema != nil:
+		return "[]" + markdownType(*props.Items.Schema)
+	case props.Type == "object" && len(props.Properties) == 0 &&
+		props.AdditionalProperties != nil && props.AdditionalProperties.Schema != nil:
+		return "map[string]" + markdownType(*props.AdditionalProperties.Schema)
+	case props.Type == "":
+		return "any"
+	}
+	return props.Type
+}
+
+// markdownDescription returns the description of a field in the reference,
+// followed by its allowed values and constraints.
+func markdownDescription(props extv1.JSONSchemaProps) string {
+	var sentences []string
+	if description := strings.TrimSpace(props.Description); description != "" {
+		description = strings.Join(strings.Fields(description), " ")
+		if !strings.HasSuffix(description, ".") {
+			description += "."
+		}
+		sentences = append(sentences, description)
+	}
+	if len(props.Enum) > 0 {
+		values := make([]string, 0, len(props.Enum))
+		for _, value := range props.Enum {
+			values = append(values, "`"+string(value.Raw)+"`")
+		}
+		sentences = append(sentences, "One of "+strings.Join(values, ", ")+".")
+	}
+	if props.Minimum != nil {
+		sentences = append(sentences, "Minimum: "+strconv.FormatFloat(*props.Minimum, 'f', -1, 64)+".")
+	}
+	if props.Maximum != nil {
+		sentences = append(sentences, "Maximum: "+strconv.FormatFloat(*props.Maximum, 'f', -1, 64)+".")
+	}
+	if props.Pattern != "" {
+		sentences = append(sentences, "Pattern: `"+props.Pattern+"`.")
+	}
+	for _, validation := range props.XValidations {
+		if validation.Message != "" {
+			sentences = append(sentences, "Validation: "+validation.Message+".")
+		} else {
+			sentences = append(sentences, "Validation: `"+validation.Rule+"`.")
+		}
+	}
+	return escapeMarkdown(strings.Join(sentences, " "))
+}
+
+// escapeMarkdown escapes the pipes of a table cell, which would end it.
+func escapeMarkdown(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
@@ -0,0 +1,379 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apigen
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"regexp"
+	"regexp/syntax"
+	"sort"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// formatExamples are the example values of the string formats.
+var formatExamples = map[string]string{
+	"date":      "2025-01-01",
+	"date-time": "2025-01-01T00:00:00Z",
+	"duration":  "1h",
+	"email":     "user@example.com",
+	"hostname":  "example.com",
+	"ipv4":      "192.0.2.1",
+	"ipv6":      "2001:db8::1",
+	"uri":       "https://example.com",
+	"uuid":      "00000000-0000-0000-0000-000000000000",
+}
+
+// Example returns an example instance, the same every time: the fields have
+// their default values, or else the first of their allowed values, or else
+// the smallest values satisfying their constraints. The strings are named
+// after their fields. When minimal, only the required fields are set.
+func (a *API) Example(minimal bool) map[string]interface{} {
+	spec := exampleValue("spec", a.Spec, minimal)
+	if _, ok := spec.(map[string]interface{}); !ok {
+		spec = map[string]interface{}{}
+	}
+	return map[string]interface{}{
+		"apiVersion": a.APIVersion(),
+		"kind":       a.Kind,
+		"metadata": map[string]interface{}{
+			"name": strings.ToLower(a.Kind) + "-sample",
+		},
+		"spec": spec,
+	}
+}
+
+// ExampleYAML returns the example instance in YAML, with the descriptions of
+// the fields as comments.
+func (a *API) ExampleYAML(minimal bool) ([]byte, error) {
+	example := a.Example(minimal)
+	root := &yaml.Node{Kind: yaml.MappingNode}
+	for _, key := range []string{"apiVersion", "kind", "metadata"} {
+		value := &yaml.Node{}
+		if err := value.Encode(example[key]); err != nil {
+			return nil, err
+		}
+		root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, value)
+	}
+	spec, err := yamlNode(example["spec"], a.Spec)
+	if err != nil {
+		return nil, err
+	}
+	root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: "spec"}, spec)
+
+	var b bytes.Buffer
+	encoder := yaml.NewEncoder(&b)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(root); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// yamlNode returns the YAML node of an example value of the schema, with the
+// descriptions of the fields of objects as comments.
+func yamlNode(value interface{}, props extv1.JSONSchemaProps) (*yaml.Node, error) {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		node := &yaml.Node{Kind: yaml.MappingNode}
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fieldProps, ok := props.Properties[key]
+			if !ok && props.AdditionalProperties != nil && props.AdditionalProperties.Schema != nil {
+				fieldProps = *props.AdditionalProperties.Schema
+			}
+			child, err := yamlNode(value[key], fieldProps)
+			if err != nil {
+				return nil, err
+			}
+			keyNode := &yaml.Node{Kind: yaml.ScalarNode, Value: key}
+			if ok && fieldProps.Description != "" {
+				keyNode.HeadComment = strings.TrimSpace(fieldProps.Description)
+			}
+			node.Content = append(node.Content, keyNode, child)
+		}
+		if len(node.Content) == 0 {
+			node.Style = yaml.FlowStyle
+		}
+		return node, nil
+	case []interface{}:
+		node := &yaml.Node{Kind: yaml.SequenceNode}
+		itemProps := extv1.JSONSchemaProps{}
+		if props.Items != nil && props.Items.Schema != nil {
+			itemProps = *props.Items.Schema
+		}
+		for _, item := range value {
+			child, err := yamlNode(item, itemProps)
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, child)
+		}
+		if len(node.Content) == 0 {
+			node.Style = yaml.FlowStyle
+		}
+		return node, nil
+	}
+	node := &yaml.Node{}
+	if err := node.Encode(value); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+// exampleValue returns the example value of the field with the given name and
+// schema.
+func exampleValue(name string, props extv1.JSONSchemaProps, minimal bool) interface{} {
+	var defaultValue interface{}
+	if props.Default != nil {
+		if err := json.Unmarshal(props.Default.Raw, &defaultValue); err == nil && len(props.Properties) == 0 {
+			return defaultValue
+		}
+	}
+	if len(props.Enum) > 0 {
+		var value interface{}
+		if err := json.Unmarshal(props.Enum[0].Raw, &value); err == nil {
+			return value
+		}
+	}
+
+	switch {
+	case props.XIntOrString, props.Type == "integer":
+		return int64(exampleNumber(props, true))
+	case props.Type == "number":
+		return exampleNumber(props, false)
+	case props.Type == "boolean":
+		return false
+	case props.Type == "string":
+		return exampleString(name, props)
+	case props.Type == "array":
+		items := 1
+		if minimal {
+			items = 0
+		}
+		if props.MinItems != nil {
+			items = max(items, int(*props.MinItems))
+		}
+		if props.MaxItems != nil {
+			items = min(items, int(*props.MaxItems))
+		}
+		list := make([]interface{}, 0, items)
+		if props.Items != nil && props.Items.Schema != nil {
+			for i := 0; i < items; i++ {
+				list = append(list, exampleValue(name, *props.Items.Schema, minimal))
+			}
+		}
+		return list
+	case len(props.Properties) > 0:
+		// The fields of the default object, like the {} defaulting the
+		// objects with defaulted fields, are completed with the others.
+		object, ok := defaultValue.(map[string]interface{})
+		if !ok {
+			object = map[string]interface{}{}
+		}
+		required := map[string]bool{}
+		for _, property := range props.Required {
+			required[property] = true
+		}
+		for property, propertyProps := range props.Properties {
+			if _, ok := object[property]; ok || (minimal && !required[property]) {
+				continue
+			}
+			object[property] = exampleValue(property, propertyProps, minimal)
+		}
+		return object
+	case props.AdditionalProperties != nil && props.AdditionalProperties.Schema != nil:
+		object := map[string]interface{}{}
+		if !minimal || (props.MinProperties != nil && *props.MinProperties > 0) {
+			object["key"] = exampleValue("value", *props.AdditionalProperties.Schema, minimal)
+		}
+		return object
+	}
+	return map[string]interface{}{}
+}
+
+// exampleNumber returns the number closest to zero within the bounds of the
+// schema, an integer if asked.
+func exampleNumber(props extv1.JSONSchemaProps, integer bool) float64 {
+	step := 0.0
+	if props.MultipleOf != nil {
+		step = *props.MultipleOf
+	}
+	if integer && step == 0 {
+		step = 1
+	}
+
+	value := 0.0
+	if props.Minimum != nil && value <= *props.Minimum {
+		value = *props.Minimum
+		if step > 0 {
+			value = math.Ceil(value/step) * step
+		}
+		if props.ExclusiveMinimum && value == *props.Minimum {
+			value = nextAbove(value, step)
+		}
+	}
+	if props.Maximum != nil && value >= *props.Maximum {
+		value = *props.Maximum
+		if step > 0 {
+			value = math.Floor(value/step) * step
+		}
+		if props.ExclusiveMaximum && value == *props.Maximum {
+			value = -nextAbove(-value, step)
+		}
+	}
+	return value
+}
+
+// nextAbove returns the next value above the given one, a step above when
+// there is a step.
+func nextAbove(value, step float64) float64 {
+	if step > 0 {
+		return value + step
+	}
+	return math.Nextafter(value, math.Inf(1))
+}
+
+// exampleString returns an example string for the field with the given name:
+// one matching its pattern, the example of its format, or else its name, in
+// the bounds of its length.
+func exampleString(name string, props extv1.JSONSchemaProps) string {
+	if props.Pattern != "" {
+		if value, ok := patternExample(props.Pattern, props.MinLength, props.MaxLength); ok {
+			return value
+		}
+	}
+	value, ok := formatExamples[props.Format]
+	if !ok {
+		value = name
+	}
+	if props.MinLength != nil && int64(len(value)) < *props.MinLength {
+		value += strings.Repeat("x", int(*props.MinLength)-len(value))
+	}
+	if props.MaxLength != nil && int64(len(value)) > *props.MaxLength {
+		value = value[:*props.MaxLength]
+	}
+	return value
+}
+
+// patternExample returns a short string matching the regular expression,
+// within the length bounds, if it can find one.
+func patternExample(pattern string, minLength, maxLength *int64) (string, bool) {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return "", false
+	}
+	compiled, err := regexp.Compile(pattern)
+	if err != nil {
+		return "", false
+	}
+	re = re.Simplify()
+
+	// Each extra repetition of an optional or repeated expression makes the
+	// example longer, until it is long enough.
+	maxExtra := 0
+	if minLength != nil {
+		maxExtra = int(*minLength)
+	}
+	for extra := 0; extra <= maxExtra; extra++ {
+		var b strings.Builder
+		remaining := extra
+		writeRegexpExample(&b, re, &remaining)
+		value := b.String()
+		if maxLength != nil && int64(len(value)) > *maxLength {
+			return "", false
+		}
+		if minLength != nil && int64(len(value)) < *minLength {
+			continue
+		}
+		if compiled.MatchString(value) {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// writeRegexpExample writes the shortest string matching the regular
+// expression, preferring letters in character classes. The first extra
+// optional or repeated expressions are repeated once more than needed.
+func writeRegexpExample(b *strings.Builder, re *syntax.Regexp, extra *int) {
+	switch re.Op {
+	case syntax.OpLiteral:
+		b.WriteString(string(re.Rune))
+	case syntax.OpCharClass:
+		b.WriteRune(charClassExample(re.Rune))
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		b.WriteRune('a')
+	case syntax.OpCapture:
+		writeRegexpExample(b, re.Sub[0], extra)
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		minimum, maximum := repeatBounds(re)
+		for i := 0; i < minimum; i++ {
+			writeRegexpExample(b, re.Sub[0], extra)
+		}
+		for i := minimum; *extra > 0 && (maximum < 0 || i < maximum); i++ {
+			*extra--
+			writeRegexpExample(b, re.Sub[0], extra)
+		}
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			writeRegexpExample(b, sub, extra)
+		}
+	case syntax.OpAlternate:
+		writeRegexpExample(b, re.Sub[0], extra)
+	}
+	// The empty matches, like anchors, write nothing.
+}
+
+// repeatBounds returns the minimum and maximum numbers of repetitions of a
+// repeated expression, the maximum being -1 when unbounded.
+func repeatBounds(re *syntax.Regexp) (int, int) {
+	switch re.Op {
+	case syntax.OpStar:
+		return 0, -1
+	case syntax.OpPlus:
+		return 1, -1
+	case syntax.OpQuest:
+		return 0, 1
+	}
+	return re.Min, re.Max
+}
+
+// charClassExample returns a rune of the character class, given as ranges,
+// preferring lowercase letters, then letters and digits.
+func charClassExample(ranges []rune) rune {
+	for _, prefer := range []func(rune) bool{unicode.IsLower, unicode.IsLetter, unicode.IsDigit} {
+		for i := 0; i+1 < len(ranges); i += 2 {
+			for r := ranges[i]; r <= ranges[i+1] && r-ranges[i] < 128; r++ {
+				if prefer(r) {
+					return r
+				}
+			}
+		}
+	}
+	return ranges[0]
+}
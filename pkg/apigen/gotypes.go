@@ -0,0 +1,140 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apigen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+)
+
+// GoTypes returns the source of a Go package with the types of the instances
+// and their lists, like the Kubernetes API types: the optional fields are
+// pointers, omitted when empty, and the objects without known fields are
+// runtime.RawExtension.
+func (a *API) GoTypes(packageName string) ([]byte, error) {
+	var defs []typeDef
+	defs = append(defs, collectTypes(a.Kind+"Spec", a.Spec)...)
+	defs = append(defs, collectTypes(a.Kind+"Status", a.Status)...)
+
+	var body bytes.Buffer
+	imports := map[string]bool{"metav1 \"k8s.io/apimachinery/pkg/apis/meta/v1\"": true}
+	for _, def := range defs {
+		writeGoComment(&body, "", def.description)
+		fmt.Fprintf(&body, "type %s struct {\n", def.name)
+		for _, f := range def.fields {
+			typ := goType(f.typ, imports)
+			tag := f.name
+			if !f.required {
+				tag += ",omitempty"
+				if f.typ.kind != kindList && f.typ.kind != kindMap && f.typ.kind != kindAny {
+					typ = "*" + typ
+				}
+			}
+			writeGoComment(&body, "\t", f.description)
+			fmt.Fprintf(&body, "\t%s %s `json:\"%s\"`\n", exportedName(f.name), typ, tag)
+		}
+		fmt.Fprintf(&body, "}\n\n")
+	}
+
+	specType, statusType := a.Kind+"Spec", a.Kind+"Status"
+	if len(a.Spec.Properties) == 0 {
+		specType = "runtime.RawExtension"
+		imports["\"k8s.io/apimachinery/pkg/runtime\""] = true
+	}
+	if len(a.Status.Properties) == 0 {
+		statusType = "runtime.RawExtension"
+		imports["\"k8s.io/apimachinery/pkg/runtime\""] = true
+	}
+
+	var src bytes.Buffer
+	fmt.Fprintf(&src, "// Code generated by kro generate go-types. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&src, "// Package %s has the types of the %s API, %s.\n", packageName, a.Kind, a.APIVersion())
+	fmt.Fprintf(&src, "package %s\n\n", packageName)
+	fmt.Fprintf(&src, "import (\n")
+	for _, path := range sortedKeys(imports) {
+		fmt.Fprintf(&src, "\t%s\n", path)
+	}
+	fmt.Fprintf(&src, ")\n\n")
+	fmt.Fprintf(&src, "// %s is an instance of the %s API.\n", a.Kind, a.APIVersion())
+	fmt.Fprintf(&src, "type %s struct {\n", a.Kind)
+	fmt.Fprintf(&src, "\tmetav1.TypeMeta `json:\",inline\"`\n")
+	fmt.Fprintf(&src, "\tmetav1.ObjectMeta `json:\"metadata,omitempty\"`\n\n")
+	fmt.Fprintf(&src, "\tSpec %s `json:\"spec,omitempty\"`\n", specType)
+	fmt.Fprintf(&src, "\tStatus %s `json:\"status,omitempty\"`\n", statusType)
+	fmt.Fprintf(&src, "}\n\n")
+	fmt.Fprintf(&src, "// %sList is a list of %s instances.\n", a.Kind, a.Kind)
+	fmt.Fprintf(&src, "type %sList struct {\n", a.Kind)
+	fmt.Fprintf(&src, "\tmetav1.TypeMeta `json:\",inline\"`\n")
+	fmt.Fprintf(&src, "\tmetav1.ListMeta `json:\"metadata,omitempty\"`\n\n")
+	fmt.Fprintf(&src, "\tItems []%s `json:\"items\"`\n", a.Kind)
+	fmt.Fprintf(&src, "}\n\n")
+	src.Write(body.Bytes())
+
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format Go types: %w", err)
+	}
+	return formatted, nil
+}
+
+// goType returns the Go type of a field, and adds the import it needs to the
+// imports.
+func goType(t typeRef, imports map[string]bool) string {
+	switch t.kind {
+	case kindString:
+		return "string"
+	case kindInteger:
+		return "int64"
+	case kindNumber:
+		return "float64"
+	case kindBoolean:
+		return "bool"
+	case kindIntOrString:
+		imports["\"k8s.io/apimachinery/pkg/util/intstr\""] = true
+		return "intstr.IntOrString"
+	case kindObject:
+		return t.name
+	case kindList:
+		return "[]" + goType(*t.elem, imports)
+	case kindMap:
+		return "map[string]" + goType(*t.elem, imports)
+	}
+	imports["\"k8s.io/apimachinery/pkg/runtime\""] = true
+	return "*runtime.RawExtension"
+}
+
+// writeGoComment writes the description of a type or field as its doc
+// comment.
+func writeGoComment(b *bytes.Buffer, indent, description string) {
+	if description == "" {
+		return
+	}
+	for _, line := range strings.Split(strings.TrimSpace(description), "\n") {
+		fmt.Fprintf(b, "%s%s\n", indent, strings.TrimSpace("// "+line))
+	}
+}
+
+// sortedKeys returns the keys of the map, sorted.
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,150 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apigen
+
+import (
+	"encoding/json"
+
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// jsonSchemaDraft is the JSON Schema dialect of the generated schemas, the
+// one supported by most editors.
+const jsonSchemaDraft = "http://json-schema.org/draft-07/schema#"
+
+// JSONSchema returns the JSON Schema of the instance manifests, to validate
+// them in editors, e.g. with the yaml-language-server modeline
+//
+//	# yaml-language-server: $schema=webapp.schema.json
+//
+// The status, set by kro, isn't part of the manifests. Unlike the API server,
+// the schema rejects unknown fields, which would be pruned. The CEL validation
+// rules of the fields can't be expressed in JSON Schema and are left out.
+func (a *API) JSONSchema() ([]byte, error) {
+	stringMap := map[string]interface{}{
+		"type":                 "object",
+		"additionalProperties": map[string]interface{}{"type": "string"},
+	}
+	schema := map[string]interface{}{
+		"$schema": jsonSchemaDraft,
+		"title":   a.Kind,
+		"type":    "object",
+		"properties": map[string]interface{}{
+			"apiVersion": map[string]interface{}{"type": "string", "enum": []string{a.APIVersion()}},
+			"kind":       map[string]interface{}{"type": "string", "enum": []string{a.Kind}},
+			"metadata": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"name":        map[string]interface{}{"type": "string"},
+					"namespace":   map[string]interface{}{"type": "string"},
+					"labels":      stringMap,
+					"annotations": stringMap,
+				},
+			},
+			"spec": jsonSchema(a.Spec),
+		},
+		"required": []string{"apiVersion", "kind", "metadata"},
+	}
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+// jsonSchema converts an OpenAPI schema of a CustomResourceDefinition to JSON
+// Schema.
+func jsonSchema(props extv1.JSONSchemaProps) map[string]interface{} {
+	schema := map[string]interface{}{}
+	switch {
+	case props.XIntOrString:
+		schema["anyOf"] = []interface{}{
+			map[string]interface{}{"type": "integer"},
+			map[string]interface{}{"type": "string"},
+		}
+	case props.Type != "" && props.Nullable:
+		schema["type"] = []string{props.Type, "null"}
+	case props.Type != "":
+		schema["type"] = props.Type
+	}
+
+	if props.Description != "" {
+		schema["description"] = props.Description
+	}
+	if props.Default != nil {
+		schema["default"] = json.RawMessage(props.Default.Raw)
+	}
+	if len(props.Enum) > 0 {
+		enum := make([]json.RawMessage, 0, len(props.Enum))
+		for _, value := range props.Enum {
+			enum = append(enum, json.RawMessage(value.Raw))
+		}
+		schema["enum"] = enum
+	}
+	if props.Format != "" {
+		schema["format"] = props.Format
+	}
+	if props.Pattern != "" {
+		schema["pattern"] = props.Pattern
+	}
+	if props.Minimum != nil {
+		schema["minimum"] = *props.Minimum
+		if props.ExclusiveMinimum {
+			// Draft 6 made exclusiveMinimum a number.
+			schema["exclusiveMinimum"] = *props.Minimum
+			delete(schema, "minimum")
+		}
+	}
+	if props.Maximum != nil {
+		schema["maximum"] = *props.Maximum
+		if props.ExclusiveMaximum {
+			schema["exclusiveMaximum"] = *props.Maximum
+			delete(schema, "maximum")
+		}
+	}
+	for keyword, value := range map[string]*int64{
+		"minLength":     props.MinLength,
+		"maxLength":     props.MaxLength,
+		"minItems":      props.MinItems,
+		"maxItems":      props.MaxItems,
+		"minProperties": props.MinProperties,
+		"maxProperties": props.MaxProperties,
+	} {
+		if value != nil {
+			schema[keyword] = *value
+		}
+	}
+	if props.UniqueItems {
+		schema["uniqueItems"] = true
+	}
+
+	if props.Items != nil && props.Items.Schema != nil {
+		schema["items"] = jsonSchema(*props.Items.Schema)
+	}
+	if len(props.Properties) > 0 {
+		properties := make(map[string]interface{}, len(props.Properties))
+		for name, property := range props.Properties {
+			properties[name] = jsonSchema(property)
+		}
+		schema["properties"] = properties
+		if len(props.Required) > 0 {
+			schema["required"] = props.Required
+		}
+	}
+	switch {
+	case props.AdditionalProperties != nil && props.AdditionalProperties.Schema != nil:
+		schema["additionalProperties"] = jsonSchema(*props.AdditionalProperties.Schema)
+	case props.Type == "object" && len(props.Properties) > 0 &&
+		(props.XPreserveUnknownFields == nil || !*props.XPreserveUnknownFields):
+		schema["additionalProperties"] = false
+	}
+	return schema
+}
@@ -0,0 +1,225 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package apigen generates artifacts for the consumers of the instance APIs of
+// ResourceGraphDefinitions, from the CustomResourceDefinitions synthesized for
+// them: JSON Schemas, Go and TypeScript types, Markdown references and example
+// instances.
+package apigen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// API is the instance API of a ResourceGraphDefinition.
+type API struct {
+	// Group, Version and Kind identify the API.
+	Group   string
+	Version string
+	Kind    string
+	// Spec and Status are the schemas of the spec and status of the
+	// instances.
+	Spec   extv1.JSONSchemaProps
+	Status extv1.JSONSchemaProps
+}
+
+// NewAPI returns the instance API defined by the CustomResourceDefinition of a
+// ResourceGraphDefinition.
+func NewAPI(crd *extv1.CustomResourceDefinition) (*API, error) {
+	if len(crd.Spec.Versions) == 0 ||
+		crd.Spec.Versions[0].Schema == nil ||
+		crd.Spec.Versions[0].Schema.OpenAPIV3Schema == nil {
+		return nil, fmt.Errorf("CustomResourceDefinition %s has no schema", crd.Name)
+	}
+	schema := crd.Spec.Versions[0].Schema.OpenAPIV3Schema
+	return &API{
+		Group:   crd.Spec.Group,
+		Version: crd.Spec.Versions[0].Name,
+		Kind:    crd.Spec.Names.Kind,
+		Spec:    schema.Properties["spec"],
+		Status:  schema.Properties["status"],
+	}, nil
+}
+
+// APIVersion returns the apiVersion of the instances.
+func (a *API) APIVersion() string {
+	return a.Group + "/" + a.Version
+}
+
+// typeKind is the kind of the type of a field.
+type typeKind int
+
+const (
+	kindAny typeKind = iota
+	kindString
+	kindInteger
+	kindNumber
+	kindBoolean
+	kindIntOrString
+	// kindObject is an object with known fields, which has a named type.
+	kindObject
+	kindList
+	kindMap
+)
+
+// typeRef is the type of a field.
+type typeRef struct {
+	kind typeKind
+	// name is the name of the type of objects.
+	name string
+	// elem is the type of the items of lists, and of the values of maps.
+	elem *typeRef
+	// enum are the JSON encoded values allowed for strings and numbers, if
+	// restricted.
+	enum []string
+}
+
+// typeDef is the named type of an object.
+type typeDef struct {
+	name        string
+	description string
+	fields      []field
+}
+
+// field is a field of an object.
+type field struct {
+	// name is the name of the field in JSON.
+	name        string
+	description string
+	typ         typeRef
+	required    bool
+}
+
+// collectTypes returns the named types of the object schema and of its nested
+// objects, the object first. The nested types are named after their parent
+// type and field.
+func collectTypes(name string, schema extv1.JSONSchemaProps) []typeDef {
+	var defs []typeDef
+	var collect func(name string, schema extv1.JSONSchemaProps) typeRef
+	collect = func(name string, schema extv1.JSONSchemaProps) typeRef {
+		switch {
+		case schema.XIntOrString:
+			return typeRef{kind: kindIntOrString}
+		case schema.Type == "string":
+			return typeRef{kind: kindString, enum: enumValues(schema)}
+		case schema.Type == "integer":
+			return typeRef{kind: kindInteger, enum: enumValues(schema)}
+		case schema.Type == "number":
+			return typeRef{kind: kindNumber, enum: enumValues(schema)}
+		case schema.Type == "boolean":
+			return typeRef{kind: kindBoolean}
+		case schema.Type == "array" && schema.Items != nil && schema.Items.Schema != nil:
+			elem := collect(name+"Item", *schema.Items.Schema)
+			return typeRef{kind: kindList, elem: &elem}
+		case schema.Type == "object" && len(schema.Properties) > 0:
+			index := len(defs)
+			defs = append(defs, typeDef{name: name, description: schema.Description})
+			required := map[string]bool{}
+			for _, property := range schema.Required {
+				required[property] = true
+			}
+			var fields []field
+			for _, property := range sortedProperties(schema) {
+				fields = append(fields, field{
+					name:        property,
+					description: schema.Properties[property].Description,
+					typ:         collect(name+exportedName(property), schema.Properties[property]),
+					required:    required[property],
+				})
+			}
+			defs[index].fields = fields
+			return typeRef{kind: kindObject, name: name}
+		case schema.Type == "object" && schema.AdditionalProperties != nil &&
+			schema.AdditionalProperties.Schema != nil:
+			elem := collect(name+"Value", *schema.AdditionalProperties.Schema)
+			return typeRef{kind: kindMap, elem: &elem}
+		}
+		return typeRef{kind: kindAny}
+	}
+	collect(name, schema)
+	return defs
+}
+
+// enumValues returns the JSON encoded values allowed by the schema, if
+// restricted.
+func enumValues(schema extv1.JSONSchemaProps) []string {
+	var values []string
+	for _, value := range schema.Enum {
+		values = append(values, string(value.Raw))
+	}
+	return values
+}
+
+// sortedProperties returns the names of the properties of the schema, sorted.
+func sortedProperties(schema extv1.JSONSchemaProps) []string {
+	properties := make([]string, 0, len(schema.Properties))
+	for property := range schema.Properties {
+		properties = append(properties, property)
+	}
+	sort.Strings(properties)
+	return properties
+}
+
+// initialisms are the words written in upper case in exported names, like
+// the Kubernetes API types do.
+var initialisms = map[string]bool{
+	"api": true, "cpu": true, "dns": true, "http": true, "https": true, "id": true, "ip": true,
+	"json": true, "tcp": true, "tls": true, "ttl": true, "udp": true, "uid": true, "uri": true,
+	"url": true, "uuid": true,
+}
+
+// exportedName returns the exported Go and TypeScript name of a field: its
+// camelCase words capitalized, without the characters invalid in
+// identifiers.
+func exportedName(name string) string {
+	var words []string
+	var word []rune
+	for _, r := range name {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			words = append(words, string(word))
+			word = nil
+			continue
+		case unicode.IsUpper(r) && len(word) > 0 && !unicode.IsUpper(word[len(word)-1]):
+			words = append(words, string(word))
+			word = nil
+		}
+		word = append(word, r)
+	}
+	words = append(words, string(word))
+
+	var b strings.Builder
+	for _, word := range words {
+		if word == "" {
+			continue
+		}
+		if initialisms[strings.ToLower(word)] {
+			b.WriteString(strings.ToUpper(word))
+			continue
+		}
+		runes := []rune(word)
+		b.WriteRune(unicode.ToUpper(runes[0]))
+		b.WriteString(string(runes[1:]))
+	}
+	exported := b.String()
+	if exported == "" || unicode.IsDigit([]rune(exported)[0]) {
+		exported = "X" + exported
+	}
+	return exported
+}
@@ -0,0 +1,127 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apigen
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// typeScriptIdentifier matches the property names that don't need quoting.
+var typeScriptIdentifier = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// TypeScriptTypes returns a TypeScript module with the interfaces of the
+// instances. The enums are unions of their values.
+func (a *API) TypeScriptTypes() []byte {
+	var defs []typeDef
+	defs = append(defs, collectTypes(a.Kind+"Spec", a.Spec)...)
+	defs = append(defs, collectTypes(a.Kind+"Status", a.Status)...)
+
+	specType, statusType := a.Kind+"Spec", a.Kind+"Status"
+	if len(a.Spec.Properties) == 0 {
+		specType = "Record<string, unknown>"
+	}
+	if len(a.Status.Properties) == 0 {
+		statusType = "Record<string, unknown>"
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by kro generate ts-types. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "/** An instance of the %s API. */\n", a.APIVersion())
+	fmt.Fprintf(&b, "export interface %s {\n", a.Kind)
+	fmt.Fprintf(&b, "  apiVersion: %q;\n", a.APIVersion())
+	fmt.Fprintf(&b, "  kind: %q;\n", a.Kind)
+	fmt.Fprintf(&b, "  metadata: ObjectMeta;\n")
+	fmt.Fprintf(&b, "  spec?: %s;\n", specType)
+	fmt.Fprintf(&b, "  status?: %s;\n", statusType)
+	fmt.Fprintf(&b, "}\n\n")
+	fmt.Fprintf(&b, "/** The metadata of an instance. */\n")
+	fmt.Fprintf(&b, "export interface ObjectMeta {\n")
+	fmt.Fprintf(&b, "  name?: string;\n")
+	fmt.Fprintf(&b, "  namespace?: string;\n")
+	fmt.Fprintf(&b, "  labels?: Record<string, string>;\n")
+	fmt.Fprintf(&b, "  annotations?: Record<string, string>;\n")
+	fmt.Fprintf(&b, "  [key: string]: unknown;\n")
+	fmt.Fprintf(&b, "}\n")
+
+	for _, def := range defs {
+		fmt.Fprintln(&b)
+		writeTypeScriptComment(&b, "", def.description)
+		fmt.Fprintf(&b, "export interface %s {\n", def.name)
+		for _, f := range def.fields {
+			name := f.name
+			if !typeScriptIdentifier.MatchString(name) {
+				name = fmt.Sprintf("%q", name)
+			}
+			if !f.required {
+				name += "?"
+			}
+			writeTypeScriptComment(&b, "  ", f.description)
+			fmt.Fprintf(&b, "  %s: %s;\n", name, typeScriptType(f.typ))
+		}
+		fmt.Fprintf(&b, "}\n")
+	}
+	return b.Bytes()
+}
+
+// typeScriptType returns the TypeScript type of a field.
+func typeScriptType(t typeRef) string {
+	switch t.kind {
+	case kindString, kindInteger, kindNumber:
+		if len(t.enum) > 0 {
+			return strings.Join(t.enum, " | ")
+		}
+		if t.kind == kindString {
+			return "string"
+		}
+		return "number"
+	case kindBoolean:
+		return "boolean"
+	case kindIntOrString:
+		return "number | string"
+	case kindObject:
+		return t.name
+	case kindList:
+		elem := typeScriptType(*t.elem)
+		if strings.Contains(elem, " ") {
+			elem = "(" + elem + ")"
+		}
+		return elem + "[]"
+	case kindMap:
+		return "Record<string, " + typeScriptType(*t.elem) + ">"
+	}
+	return "unknown"
+}
+
+// writeTypeScriptComment writes the description of an interface or property
+// as its JSDoc comment.
+func writeTypeScriptComment(b *bytes.Buffer, indent, description string) {
+	if description == "" {
+		return
+	}
+	description = strings.ReplaceAll(strings.TrimSpace(description), "*/", "*\\/")
+	lines := strings.Split(description, "\n")
+	if len(lines) == 1 {
+		fmt.Fprintf(b, "%s/** %s */\n", indent, lines[0])
+		return
+	}
+	fmt.Fprintf(b, "%s/**\n", indent)
+	for _, line := range lines {
+		fmt.Fprintf(b, "%s%s\n", indent, strings.TrimRight(" * "+line, " "))
+	}
+	fmt.Fprintf(b, "%s */\n", indent)
+}
@@ -25,6 +25,9 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
 	"sort"
@@ -39,7 +42,10 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/dynamic"
+
+	"github.com/kubernetes-sigs/kro/pkg/graph/fieldpath"
 )
 
 type ToolingID struct {
@@ -122,6 +128,19 @@ type Config struct {
 	// Concurrency is the maximum number of concurrent apply and prune operations in a single applyset.
 	// If not provided, the default value is the number of objects in the applyset.
 	Concurrency int
+
+	// Reader is used to read the objects from the cluster when they are added, typically from an
+	// informer cache. If not provided, the objects are read with the dynamic client.
+	Reader ObjectReader
+
+	// HashAnnotation is the key of the annotation holding the hash of the applied objects. When set,
+	// the objects whose observed hash matches the hash of the desired object are not applied again.
+	HashAnnotation string
+}
+
+// ObjectReader reads objects from the cluster. It returns a NotFound error if the object doesn't exist.
+type ObjectReader interface {
+	Get(ctx context.Context, gvr schema.GroupVersionResource, namespace, name string) (*unstructured.Unstructured, error)
 }
 
 /*
@@ -194,8 +213,10 @@ func New(
 			},
 			// deleteOptions: metav1.DeleteOptions{},
 		},
-		log:         config.Log,
-		concurrency: config.Concurrency,
+		log:            config.Log,
+		concurrency:    config.Concurrency,
+		reader:         config.Reader,
+		hashAnnotation: config.HashAnnotation,
 	}
 
 	gvk := parent.GroupVersionKind()
@@ -270,6 +291,16 @@ type applySet struct {
 	// concurrency is the maximum number of concurrent apply and prune operations in a single applyset.
 	// If not provided, the default value is the number of objects in the applyset.
 	concurrency int
+
+	// reader is used to read the objects from the cluster, if provided.
+	reader ObjectReader
+
+	// hashAnnotation is the key of the annotation holding the hash of the applied objects, if provided.
+	hashAnnotation string
+
+	// mu protects the desired objects, REST mappings and namespaces, so that objects can be added
+	// concurrently.
+	mu sync.Mutex
 }
 
 func (a *applySet) getAndRecordNamespace(obj ApplyableObject, restMapping *meta.RESTMapping) error {
@@ -322,6 +353,11 @@ func (a *applySet) resourceClient(obj Applyable) (dynamic.ResourceInterface, err
 		// This should never happen, but if it does, we want to know about it.
 		return nil, fmt.Errorf("FATAL: rest mapping not found for %v", obj.GroupVersionKind())
 	}
+	return a.resourceClientFor(obj, restMapping), nil
+}
+
+// resourceClientFor returns the client of an object with the given REST mapping.
+func (a *applySet) resourceClientFor(obj Applyable, restMapping *meta.RESTMapping) dynamic.ResourceInterface {
 	dynResource := a.dynamicClient.Resource(restMapping.Resource)
 	if restMapping.Scope.Name() == meta.RESTScopeNameNamespace {
 		ns := obj.GetNamespace()
@@ -331,29 +367,26 @@ func (a *applySet) resourceClient(obj Applyable) (dynamic.ResourceInterface, err
 		if ns == "" {
 			ns = metav1.NamespaceDefault
 		}
-		return dynResource.Namespace(ns), nil
+		return dynResource.Namespace(ns)
 	}
-	return dynResource, nil
+	return dynResource
 }
 
+// Add adds an object to the applyset, and returns the object observed in the cluster, if any. It is safe
+// for concurrent use, as long as the added objects are not shared.
 func (a *applySet) Add(ctx context.Context, obj ApplyableObject) (*unstructured.Unstructured, error) {
+	a.mu.Lock()
 	restMapping, err := a.getRestMapping(obj)
-	if err != nil {
-		return nil, err
+	if err == nil {
+		err = a.getAndRecordNamespace(obj, restMapping)
 	}
-	if err := a.getAndRecordNamespace(obj, restMapping); err != nil {
+	a.mu.Unlock()
+	if err != nil {
 		return nil, err
 	}
 	obj.SetLabels(a.InjectApplysetLabels(a.injectToolLabels(obj.GetLabels())))
 
-	dynResource, err := a.resourceClient(obj)
-	if err != nil {
-		return nil, err
-	}
-	observed, err := dynResource.Get(ctx,
-		obj.GetName(),
-		metav1.GetOptions{},
-	)
+	observed, err := a.get(ctx, obj, restMapping)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			observed = nil
@@ -361,18 +394,91 @@ func (a *applySet) Add(ctx context.Context, obj ApplyableObject) (*unstructured.
 			return nil, fmt.Errorf("error getting object from cluster: %w", err)
 		}
 	}
+
+	// Ignored fields are only applied when the object is created. Afterwards, they are left out of
+	// the apply, so the values other field managers set are never overwritten, even when the
+	// observed object is stale.
+	if observed != nil && !obj.ExternalRef {
+		if err := removeFields(obj, obj.IgnoreFields); err != nil {
+			return nil, err
+		}
+	}
+	if a.hashAnnotation != "" && !obj.ExternalRef {
+		if err := a.setHash(obj); err != nil {
+			return nil, err
+		}
+	}
+
 	if observed != nil {
 		// Record the last read revision of the object.
 		obj.lastReadRevision = observed.GetResourceVersion()
+		if obj.CreateOnly && !obj.ExternalRef {
+			obj.kept = observed
+		}
+		if a.hashAnnotation != "" && !obj.ExternalRef &&
+			observed.GetAnnotations()[a.hashAnnotation] == obj.GetAnnotations()[a.hashAnnotation] {
+			obj.kept = observed
+		}
 	}
 	a.log.V(2).Info("adding object to applyset", "object", obj.String(), "cluster-revision", obj.lastReadRevision)
 
+	a.mu.Lock()
+	defer a.mu.Unlock()
 	if err := a.desired.Add(obj); err != nil {
 		return nil, err
 	}
 	return observed, nil
 }
 
+// get reads an object from the cluster. External references aren't managed by the applyset, so they
+// are always read with the dynamic client. The reader only serves the objects labeled by the
+// applyset: the objects whose apply depends on their observed state are read with the dynamic
+// client when the reader doesn't find them, since they may exist without being labeled yet.
+func (a *applySet) get(
+	ctx context.Context,
+	obj ApplyableObject,
+	restMapping *meta.RESTMapping,
+) (*unstructured.Unstructured, error) {
+	if a.reader != nil && !obj.ExternalRef {
+		namespace := ""
+		if restMapping.Scope.Name() == meta.RESTScopeNameNamespace {
+			namespace = obj.GetNamespace()
+			if namespace == "" {
+				namespace = a.parent.GetNamespace()
+			}
+			if namespace == "" {
+				namespace = metav1.NamespaceDefault
+			}
+		}
+		observed, err := a.reader.Get(ctx, restMapping.Resource, namespace, obj.GetName())
+		if !apierrors.IsNotFound(err) || (len(obj.IgnoreFields) == 0 && !obj.CreateOnly) {
+			return observed, err
+		}
+	}
+
+	return a.resourceClientFor(obj, restMapping).Get(ctx, obj.GetName(), metav1.GetOptions{})
+}
+
+// setHash sets the hash annotation of an object to the hash of its content.
+func (a *applySet) setHash(obj ApplyableObject) error {
+	annotations := obj.GetAnnotations()
+	delete(annotations, a.hashAnnotation)
+	obj.SetAnnotations(annotations)
+
+	data, err := json.Marshal(obj.Object)
+	if err != nil {
+		return fmt.Errorf("error hashing object %v: %w", obj.String(), err)
+	}
+	hash := sha256.Sum256(data)
+
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[a.hashAnnotation] = hex.EncodeToString(hash[:])
+	obj.SetAnnotations(annotations)
+	return nil
+}
+
 // ID is the label value that we are using to identify this applyset.
 // Format: base64(sha256(<name>.<namespace>.<kind>.<apiVersion>)), using the URL safe encoding of RFC4648.
 func (a *applySet) ID() string {
@@ -568,17 +674,47 @@ func (a *applySet) apply(ctx context.Context, dryRun bool) (*ApplyResult, error)
 	var mu sync.Mutex
 
 	for _, obj := range a.desired.objects {
+		if obj.kept != nil && !dryRun {
+			// The object was applied with the same content, or is create only. Record the observed
+			// object as the last applied one, so that it is neither counted as a mutation nor pruned.
+			message := ""
+			if obj.CreateOnly {
+				message = MessageKept
+			}
+			results.recordApplied(obj, obj.kept, nil, message)
+			a.log.V(2).Info("skipped object", "object", obj.String(),
+				"cluster-revision", obj.lastReadRevision)
+			continue
+		}
 		dynResource, err := a.resourceClient(obj)
 		if err != nil {
 			return results, err
 		}
 		eg.Go(func() error {
+			options := options
+			if obj.RejectConflicts {
+				options.Force = false
+			}
 			lastApplied, err := dynResource.Apply(egctx, obj.GetName(), obj.Unstructured, options)
+			if err != nil && obj.RejectConflicts && apierrors.IsConflict(err) {
+				err = fmt.Errorf("conflicts with other field managers: %w", err)
+			}
+			message := ""
+			if err != nil && obj.Recreate && !dryRun && isImmutableFieldError(err) {
+				lastApplied, err = a.recreate(egctx, dynResource, obj, options)
+				if err == nil {
+					message = MessageRecreated
+				}
+			}
 			mu.Lock()
 			defer mu.Unlock()
-			results.recordApplied(obj, lastApplied, err)
-			a.log.V(2).Info("applied object", "object", obj.String(), "applied-revision", lastApplied.GetResourceVersion(),
-				"error", err)
+			results.recordApplied(obj, lastApplied, err, message)
+			if err != nil {
+				// The client returns no object when the apply fails.
+				a.log.V(2).Info("failed to apply object", "object", obj.String(), "error", err)
+				return nil
+			}
+			a.log.V(2).Info("applied object", "object", obj.String(), "applied-revision", lastApplied.GetResourceVersion())
 			return nil
 		})
 	}
@@ -586,6 +722,91 @@ func (a *applySet) apply(ctx context.Context, dryRun bool) (*ApplyResult, error)
 	return results, eg.Wait()
 }
 
+// removeFields removes the fields at the given paths from the object.
+func removeFields(obj ApplyableObject, paths []string) error {
+	for _, path := range paths {
+		segments, err := fieldpath.Parse(path)
+		if err != nil {
+			return fmt.Errorf("error parsing ignored field %q of %v: %w", path, obj.String(), err)
+		}
+		removeField(obj.Object, segments)
+	}
+	return nil
+}
+
+// removeField removes the field at the path made of the given segments from the value. Missing
+// fields are ignored, and list items are kept, as removing them would shift the next ones.
+func removeField(value interface{}, segments []fieldpath.Segment) {
+	if len(segments) == 0 {
+		return
+	}
+	segment := segments[0]
+	if segment.Index != -1 {
+		list, ok := value.([]interface{})
+		if !ok || segment.Index >= len(list) {
+			return
+		}
+		removeField(list[segment.Index], segments[1:])
+		return
+	}
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return
+	}
+	if len(segments) == 1 {
+		delete(object, segment.Name)
+		return
+	}
+	removeField(object[segment.Name], segments[1:])
+}
+
+// recreate deletes an object whose apply was rejected because it changes an immutable field, and
+// applies it again. The delete is conditioned on the revision the object was read at, so that
+// changes made since then aren't lost. The apply fails while the previous object is being deleted.
+func (a *applySet) recreate(
+	ctx context.Context,
+	dynResource dynamic.ResourceInterface,
+	obj ApplyableObject,
+	options metav1.ApplyOptions,
+) (*unstructured.Unstructured, error) {
+	propagationPolicy := metav1.DeletePropagationBackground
+	deleteOptions := metav1.DeleteOptions{PropagationPolicy: &propagationPolicy}
+	if obj.lastReadRevision != "" {
+		deleteOptions.Preconditions = &metav1.Preconditions{ResourceVersion: &obj.lastReadRevision}
+	}
+	if err := dynResource.Delete(ctx, obj.GetName(), deleteOptions); err != nil && !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("unable to delete object to recreate it: %w", err)
+	}
+	a.log.V(2).Info("deleted object to recreate it", "object", obj.String())
+
+	lastApplied, err := dynResource.Apply(ctx, obj.GetName(), obj.Unstructured, options)
+	if err != nil {
+		return nil, fmt.Errorf("unable to recreate object: %w", err)
+	}
+	return lastApplied, nil
+}
+
+// isImmutableFieldError returns true if the error rejects a change to an immutable field. Such
+// changes are either rejected as immutable, or as forbidden updates, like for StatefulSets.
+func isImmutableFieldError(err error) bool {
+	if !apierrors.IsInvalid(err) {
+		return false
+	}
+	var status apierrors.APIStatus
+	if !errors.As(err, &status) || status.Status().Details == nil {
+		return strings.Contains(err.Error(), "field is immutable")
+	}
+	for _, cause := range status.Status().Details.Causes {
+		if strings.Contains(cause.Message, "immutable") {
+			return true
+		}
+		if string(cause.Type) == string(field.ErrorTypeForbidden) && strings.Contains(cause.Message, "updates to") {
+			return true
+		}
+	}
+	return false
+}
+
 func (a *applySet) prune(ctx context.Context, results *ApplyResult, dryRun bool) (*ApplyResult, error) {
 	pruneObjects, err := a.findAllObjectsToPrune(ctx, a.dynamicClient, results.AppliedUIDs())
 	if err != nil {
@@ -17,15 +17,18 @@ package applyset
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"testing"
 
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/dynamic/fake"
 	k8stesting "k8s.io/client-go/testing"
 )
@@ -59,6 +62,15 @@ func newTestApplySet(
 	t *testing.T,
 	parent *unstructured.Unstructured,
 	objs ...runtime.Object,
+) (Set, *fake.FakeDynamicClient) {
+	return newTestApplySetWithConfig(t, func(*Config) {}, parent, objs...)
+}
+
+func newTestApplySetWithConfig(
+	t *testing.T,
+	configure func(*Config),
+	parent *unstructured.Unstructured,
+	objs ...runtime.Object,
 ) (Set, *fake.FakeDynamicClient) {
 	allObjs := append([]runtime.Object{parent}, objs...)
 	gvrToListKind := map[schema.GroupVersionResource]string{}
@@ -79,6 +91,7 @@ func newTestApplySet(
 		FieldManager: "test-manager",
 		Log:          logr.Discard(),
 	}
+	configure(&config)
 
 	aset, err := New(parent, restMapper, dynamicClient, config)
 	assert.NoError(t, err)
@@ -606,3 +619,317 @@ func TestApplySet_PruneOldGVKs(t *testing.T) {
 	assert.Equal(t, 1, pruned)
 	assert.Len(t, result.PrunedObjects, 1)
 }
+
+// fakeReader serves objects from memory, keyed by namespace/name.
+type fakeReader map[string]*unstructured.Unstructured
+
+func (r fakeReader) Get(
+	_ context.Context,
+	gvr schema.GroupVersionResource,
+	namespace, name string,
+) (*unstructured.Unstructured, error) {
+	if obj, ok := r[namespace+"/"+name]; ok {
+		return obj.DeepCopy(), nil
+	}
+	return nil, apierrors.NewNotFound(gvr.GroupResource(), name)
+}
+
+func TestApplySet_SkipUnchanged(t *testing.T) {
+	const hashAnnotation = "test/applied-hash"
+	parent := parentObj(secretGVK, "parent-secret")
+
+	// apply adds the configmap to a new applyset reading from the given objects,
+	// applies it and returns the result and the applied configmaps.
+	apply := func(t *testing.T, cm ApplyableObject, observed fakeReader) (*ApplyResult, []*unstructured.Unstructured) {
+		aset, dynamicClient := newTestApplySetWithConfig(t, func(config *Config) {
+			config.Reader = observed
+			config.HashAnnotation = hashAnnotation
+		}, parent)
+
+		var applied []*unstructured.Unstructured
+		dynamicClient.PrependReactor("patch", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			var appliedCM unstructured.Unstructured
+			err := json.Unmarshal(action.(k8stesting.PatchAction).GetPatch(), &appliedCM)
+			assert.NoError(t, err)
+			appliedCM.SetResourceVersion("2")
+			applied = append(applied, &appliedCM)
+			return true, &appliedCM, nil
+		})
+		dynamicClient.PrependReactor("patch", "secrets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, parent, nil
+		})
+
+		_, err := aset.Add(context.Background(), cm)
+		assert.NoError(t, err)
+		result, err := aset.Apply(context.Background(), false)
+		assert.NoError(t, err)
+		assert.NoError(t, result.Errors())
+		return result, applied
+	}
+
+	// The first apply records the hash of the configmap.
+	_, applied := apply(t, configMap("test-cm", "default"), fakeReader{})
+	assert.Len(t, applied, 1)
+	hash := applied[0].GetAnnotations()[hashAnnotation]
+	assert.NotEmpty(t, hash)
+
+	observed := applied[0].DeepCopy()
+	observed.SetResourceVersion("2")
+	reader := fakeReader{"default/test-cm": observed}
+
+	t.Run("unchanged object is not applied", func(t *testing.T) {
+		result, applied := apply(t, configMap("test-cm", "default"), reader)
+		assert.Empty(t, applied)
+		assert.Len(t, result.AppliedObjects, 1)
+		assert.Equal(t, observed.GetUID(), result.AppliedObjects[0].LastApplied.GetUID())
+		assert.False(t, result.HasClusterMutation())
+	})
+
+	t.Run("changed object is applied", func(t *testing.T) {
+		cm := configMap("test-cm", "default")
+		cm.Object["data"] = map[string]interface{}{"key": "other"}
+		_, applied := apply(t, cm, reader)
+		assert.Len(t, applied, 1)
+		assert.NotEqual(t, hash, applied[0].GetAnnotations()[hashAnnotation])
+	})
+
+	t.Run("object applied with another content is applied", func(t *testing.T) {
+		modified := observed.DeepCopy()
+		modified.SetAnnotations(map[string]string{hashAnnotation: "other"})
+		_, applied := apply(t, configMap("test-cm", "default"), fakeReader{"default/test-cm": modified})
+		assert.Len(t, applied, 1)
+	})
+}
+
+func TestApplySet_UpdateStrategies(t *testing.T) {
+	parent := parentObj(secretGVK, "parent-secret")
+	immutableErr := apierrors.NewInvalid(configMapGVK.GroupKind(), "test-cm", field.ErrorList{
+		field.Invalid(field.NewPath("data"), "value", "field is immutable"),
+	})
+	invalidErr := apierrors.NewInvalid(configMapGVK.GroupKind(), "test-cm", field.ErrorList{
+		field.Required(field.NewPath("data"), "data is required"),
+	})
+
+	// apply adds the configmap to a new applyset reading from the given objects,
+	// applies it with the given apply errors and returns the result, the number
+	// of applies and the number of deletes.
+	apply := func(
+		t *testing.T, cm ApplyableObject, observed fakeReader, applyErrs ...error,
+	) (*ApplyResult, int, int) {
+		aset, dynamicClient := newTestApplySetWithConfig(t, func(config *Config) {
+			config.Reader = observed
+		}, parent)
+
+		applies, deletes := 0, 0
+		dynamicClient.PrependReactor("patch", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			applies++
+			if len(applyErrs) > 0 {
+				err := applyErrs[0]
+				applyErrs = applyErrs[1:]
+				return true, nil, err
+			}
+			var appliedCM unstructured.Unstructured
+			err := json.Unmarshal(action.(k8stesting.PatchAction).GetPatch(), &appliedCM)
+			assert.NoError(t, err)
+			return true, &appliedCM, nil
+		})
+		dynamicClient.PrependReactor("delete", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			deletes++
+			return true, nil, nil
+		})
+		dynamicClient.PrependReactor("patch", "secrets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, parent, nil
+		})
+
+		_, err := aset.Add(context.Background(), cm)
+		assert.NoError(t, err)
+		result, err := aset.Apply(context.Background(), false)
+		assert.NoError(t, err)
+		return result, applies, deletes
+	}
+
+	observed := configMap("test-cm", "default").Unstructured.DeepCopy()
+	observed.SetResourceVersion("1")
+	reader := fakeReader{"default/test-cm": observed}
+
+	t.Run("create only object is created", func(t *testing.T) {
+		cm := configMap("test-cm", "default")
+		cm.CreateOnly = true
+		result, applies, _ := apply(t, cm, fakeReader{})
+		assert.NoError(t, result.Errors())
+		assert.Equal(t, 1, applies)
+		assert.Empty(t, result.AppliedObjects[0].Message)
+	})
+
+	t.Run("existing create only object is kept", func(t *testing.T) {
+		cm := configMap("test-cm", "default")
+		cm.CreateOnly = true
+		cm.Object["data"] = map[string]interface{}{"key": "other"}
+		result, applies, _ := apply(t, cm, reader)
+		assert.NoError(t, result.Errors())
+		assert.Equal(t, 0, applies)
+		assert.Equal(t, MessageKept, result.AppliedObjects[0].Message)
+		assert.Equal(t, observed.GetResourceVersion(), result.AppliedObjects[0].LastApplied.GetResourceVersion())
+	})
+
+	t.Run("object is recreated after an immutable field change", func(t *testing.T) {
+		cm := configMap("test-cm", "default")
+		cm.Recreate = true
+		result, applies, deletes := apply(t, cm, reader, immutableErr)
+		assert.NoError(t, result.Errors())
+		assert.Equal(t, 2, applies)
+		assert.Equal(t, 1, deletes)
+		assert.Equal(t, MessageRecreated, result.AppliedObjects[0].Message)
+	})
+
+	t.Run("object is not recreated after other errors", func(t *testing.T) {
+		cm := configMap("test-cm", "default")
+		cm.Recreate = true
+		result, applies, deletes := apply(t, cm, reader, invalidErr)
+		assert.Error(t, result.Errors())
+		assert.Equal(t, 1, applies)
+		assert.Equal(t, 0, deletes)
+	})
+
+	t.Run("applied object is not recreated", func(t *testing.T) {
+		result, applies, deletes := apply(t, configMap("test-cm", "default"), reader, immutableErr)
+		assert.Error(t, result.Errors())
+		assert.Equal(t, 1, applies)
+		assert.Equal(t, 0, deletes)
+	})
+}
+
+func TestApplySet_FieldOwnership(t *testing.T) {
+	parent := parentObj(secretGVK, "parent-secret")
+	conflictErr := apierrors.NewConflict(schema.GroupResource{Resource: "configmaps"}, "test-cm",
+		errors.New("conflict with \"hpa\": .data.key"))
+
+	// apply adds the configmap to a new applyset reading from the given objects,
+	// or the objects of the cluster when they aren't found, applies it with the
+	// given apply error and returns the result and the patch actions.
+	apply := func(
+		t *testing.T, cm ApplyableObject, observed fakeReader, applyErr error, objs ...runtime.Object,
+	) (*ApplyResult, []k8stesting.PatchActionImpl) {
+		aset, dynamicClient := newTestApplySetWithConfig(t, func(config *Config) {
+			config.Reader = observed
+		}, parent, objs...)
+
+		var patches []k8stesting.PatchActionImpl
+		dynamicClient.PrependReactor("patch", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			patches = append(patches, action.(k8stesting.PatchActionImpl))
+			if applyErr != nil {
+				return true, nil, applyErr
+			}
+			var appliedCM unstructured.Unstructured
+			err := json.Unmarshal(action.(k8stesting.PatchAction).GetPatch(), &appliedCM)
+			assert.NoError(t, err)
+			return true, &appliedCM, nil
+		})
+		dynamicClient.PrependReactor("patch", "secrets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, parent, nil
+		})
+
+		_, err := aset.Add(context.Background(), cm)
+		assert.NoError(t, err)
+		result, err := aset.Apply(context.Background(), false)
+		assert.NoError(t, err)
+		return result, patches
+	}
+
+	appliedData := func(t *testing.T, patch k8stesting.PatchActionImpl) map[string]interface{} {
+		var applied unstructured.Unstructured
+		assert.NoError(t, json.Unmarshal(patch.Patch, &applied))
+		data, _, _ := unstructured.NestedMap(applied.Object, "data")
+		return data
+	}
+
+	// serverSideApply merges an apply of the configmap data into the observed
+	// object, like the API server does for the fields the applyset manager owns
+	// since the creation: the fields missing from the apply are removed.
+	serverSideApply := func(t *testing.T, observed *unstructured.Unstructured, patch k8stesting.PatchActionImpl) map[string]interface{} {
+		result := observed.DeepCopy()
+		data := appliedData(t, patch)
+		if data == nil {
+			unstructured.RemoveNestedField(result.Object, "data")
+		} else {
+			assert.NoError(t, unstructured.SetNestedMap(result.Object, data, "data"))
+		}
+		data, _, _ = unstructured.NestedMap(result.Object, "data")
+		return data
+	}
+
+	// The data of the configmap was changed by another controller.
+	observed := configMap("test-cm", "default").Unstructured.DeepCopy()
+	observed.SetResourceVersion("1")
+	assert.NoError(t, unstructured.SetNestedField(observed.Object, "scaled", "data", "key"))
+	reader := fakeReader{"default/test-cm": observed}
+
+	t.Run("ignored fields are applied on creation", func(t *testing.T) {
+		cm := configMap("test-cm", "default")
+		cm.IgnoreFields = []string{"data.key"}
+		result, patches := apply(t, cm, fakeReader{}, nil)
+		assert.NoError(t, result.Errors())
+		assert.Len(t, patches, 1)
+		assert.Equal(t, map[string]interface{}{"key": "value"}, appliedData(t, patches[0]))
+	})
+
+	t.Run("ignored fields aren't applied once the object exists", func(t *testing.T) {
+		cm := configMap("test-cm", "default")
+		cm.IgnoreFields = []string{"data.key", "spec.missing"}
+		result, patches := apply(t, cm, reader, nil)
+		assert.NoError(t, result.Errors())
+		assert.Len(t, patches, 1)
+		assert.Empty(t, appliedData(t, patches[0]))
+	})
+
+	t.Run("ignored fields aren't reverted to stale observed values", func(t *testing.T) {
+		// The cache still serves the value the other controller set before
+		// changing it again.
+		live := observed.DeepCopy()
+		live.SetResourceVersion("2")
+		assert.NoError(t, unstructured.SetNestedField(live.Object, "rescaled", "data", "key"))
+		cm := configMap("test-cm", "default")
+		cm.IgnoreFields = []string{"data.key"}
+		result, patches := apply(t, cm, reader, nil, live)
+		assert.NoError(t, result.Errors())
+		assert.Len(t, patches, 1)
+		assert.NotContains(t, appliedData(t, patches[0]), "key")
+	})
+
+	t.Run("fields not ignored are reset by the apply", func(t *testing.T) {
+		result, patches := apply(t, configMap("test-cm", "default"), reader, nil)
+		assert.NoError(t, result.Errors())
+		assert.Len(t, patches, 1)
+		assert.Equal(t, map[string]interface{}{"key": "value"}, serverSideApply(t, observed, patches[0]))
+	})
+
+	t.Run("ignored fields removed from the object aren't applied again", func(t *testing.T) {
+		removed := observed.DeepCopy()
+		unstructured.RemoveNestedField(removed.Object, "data", "key")
+		cm := configMap("test-cm", "default")
+		cm.IgnoreFields = []string{"data.key"}
+		result, patches := apply(t, cm, fakeReader{"default/test-cm": removed}, nil)
+		assert.NoError(t, result.Errors())
+		assert.Len(t, patches, 1)
+		assert.Empty(t, appliedData(t, patches[0]))
+	})
+
+	t.Run("objects with ignored fields missing from the reader are read from the cluster", func(t *testing.T) {
+		// The existing object isn't labeled by the applyset yet, so the reader
+		// doesn't serve it.
+		cm := configMap("test-cm", "default")
+		cm.IgnoreFields = []string{"data.key"}
+		result, patches := apply(t, cm, fakeReader{}, nil, observed.DeepCopy())
+		assert.NoError(t, result.Errors())
+		assert.Len(t, patches, 1)
+		assert.Empty(t, appliedData(t, patches[0]))
+	})
+
+	t.Run("conflicts are reported when they are rejected", func(t *testing.T) {
+		cm := configMap("test-cm", "default")
+		cm.RejectConflicts = true
+		result, patches := apply(t, cm, reader, conflictErr)
+		assert.Len(t, patches, 1)
+		assert.ErrorContains(t, result.Errors(), "conflicts with other field managers")
+	})
+}
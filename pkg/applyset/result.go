@@ -26,6 +26,13 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 )
 
+const (
+	// MessageKept is the message of the create only objects that exist, and are kept as is.
+	MessageKept = "kept the existing object"
+	// MessageRecreated is the message of the objects recreated after an immutable field change.
+	MessageRecreated = "recreated after an immutable field change"
+)
+
 // AppliedObject is a wrapper around an ApplyableObject that contains the last applied object
 // It is used to track the applied object and any errors that occurred while applying it.
 // It is also used to check if the object has been mutated in the cluster as part of the apply operation.
@@ -115,11 +122,13 @@ func (a *ApplyResult) recordApplied(
 	obj ApplyableObject,
 	lastApplied *unstructured.Unstructured,
 	err error,
+	message string,
 ) {
 	ao := AppliedObject{
 		ApplyableObject: obj,
 		LastApplied:     lastApplied,
 		Error:           err,
+		Message:         message,
 	}
 	a.AppliedObjects = append(a.AppliedObjects, ao)
 }
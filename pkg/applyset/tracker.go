@@ -53,9 +53,25 @@ type ApplyableObject struct {
 	// Lifecycle hints
 	// TODO(barney-s): need to exapnd on these: https://github.com/kubernetes-sigs/kro/issues/542
 	ExternalRef bool
+	// CreateOnly objects are never updated once they exist.
+	CreateOnly bool
+	// Recreate objects are deleted and applied again when their apply is
+	// rejected because it changes an immutable field.
+	Recreate bool
+	// IgnoreFields are the paths of the fields only set when the object is
+	// created, using the fieldpath syntax. They are left out of the applies
+	// afterwards, so the other field managers' changes are kept.
+	IgnoreFields []string
+	// RejectConflicts objects are applied without force: the fields managed by
+	// other field managers aren't taken over, and conflicts fail the apply.
+	RejectConflicts bool
 
 	// lastReadRevision is the revision of the object that was last read from the cluster.
 	lastReadRevision string
+
+	// kept is the object read from the cluster, if it is kept instead of being applied: it was
+	// applied with the same content, or it is create only.
+	kept *unstructured.Unstructured
 }
 
 func (a *ApplyableObject) String() string {
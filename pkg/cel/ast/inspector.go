@@ -100,11 +100,22 @@ type Inspector struct {
 	loopVars map[string]struct{}
 }
 
-// knownFunctions contains the list of all CEL functions that are supported
-var knownFunctions = []string{
-	"random.seededString",
-	"base64.decode",
-	"base64.encode",
+// namespacedFunctions returns the namespaced functions declared by the CEL
+// environment, like random.seededString, which are called on what looks like
+// an identifier. This covers the kro libraries declared by the default
+// environment, without having to list them here.
+func namespacedFunctions(env *cel.Env) map[string]struct{} {
+	functions := make(map[string]struct{})
+	if env == nil {
+		return functions
+	}
+	for name := range env.Functions() {
+		// Operators, like _?._, aren't called by name.
+		if strings.Contains(name, ".") && !strings.HasPrefix(name, "_") && !strings.HasPrefix(name, "@") {
+			functions[name] = struct{}{}
+		}
+	}
+	return functions
 }
 
 // DefaultInspector creates a new Inspector instance with the given resources and functions.
@@ -119,11 +130,9 @@ func DefaultInspector(resources []string, functions []string) (*Inspector, error
 		resourceMap[resource] = struct{}{}
 	}
 
-	functionMap := make(map[string]struct{})
 	for _, function := range functions {
 		fn := cel.Function(function, cel.Overload(function+"_any", []*cel.Type{cel.AnyType}, cel.AnyType))
 		declarations = append(declarations, fn)
-		functionMap[function] = struct{}{}
 	}
 
 	env, err := krocel.DefaultEnvironment(krocel.WithCustomDeclarations(declarations))
@@ -131,6 +140,12 @@ func DefaultInspector(resources []string, functions []string) (*Inspector, error
 		return nil, fmt.Errorf("failed to create CEL environment: %v", err)
 	}
 
+	// The functions of the libraries are declared by the default environment.
+	functionMap := namespacedFunctions(env)
+	for _, function := range functions {
+		functionMap[function] = struct{}{}
+	}
+
 	return &Inspector{
 		env:       env,
 		resources: resourceMap,
@@ -146,15 +161,10 @@ func NewInspectorWithEnv(env *cel.Env, resources []string) *Inspector {
 		resourceMap[resource] = struct{}{}
 	}
 
-	functionMap := make(map[string]struct{})
-	for _, function := range knownFunctions {
-		functionMap[function] = struct{}{}
-	}
-
 	return &Inspector{
 		env:       env,
 		resources: resourceMap,
-		functions: functionMap,
+		functions: namespacedFunctions(env),
 		loopVars:  make(map[string]struct{}),
 	}
 }
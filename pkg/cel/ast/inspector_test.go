@@ -18,6 +18,12 @@ import (
 	"reflect"
 	"sort"
 	"testing"
+
+	"github.com/google/cel-go/cel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	krocel "github.com/kubernetes-sigs/kro/pkg/cel"
 )
 
 func TestInspector_InspectionResults(t *testing.T) {
@@ -537,3 +543,56 @@ func Test_InvalidExpression(t *testing.T) {
 		t.Errorf("Expected error")
 	}
 }
+
+func TestInspector_LibraryFunctions(t *testing.T) {
+	expressions := []string{
+		`random.seededString(10, schema.metadata.uid)`,
+		`semver.compare(schema.spec.version, '1.28.0') >= 0`,
+		`url.hostname(database.status.endpoint)`,
+		`cidr.subnet(schema.spec.cidr, 8, 1)`,
+		`cidr.contains(schema.spec.cidr, ip.isIPv4(database.status.ip) ? database.status.ip : '')`,
+		`hash.sha256(json.marshal(schema.spec.config))`,
+		`yaml.unmarshal(yaml.marshal(schema.spec.config))`,
+		`names.truncate(names.dns1123(schema.metadata.name), 63) + names.hash(schema.metadata.uid, 5)`,
+	}
+
+	for _, expression := range expressions {
+		t.Run(expression, func(t *testing.T) {
+			inspector, err := DefaultInspector([]string{"schema", "database"}, nil)
+			require.NoError(t, err)
+
+			got, err := inspector.Inspect(expression)
+			require.NoError(t, err)
+			assert.Empty(t, got.UnknownResources)
+			assert.Empty(t, got.UnknownFunctions)
+			assert.NotEmpty(t, got.FunctionCalls)
+			for _, dependency := range got.ResourceDependencies {
+				assert.Contains(t, []string{"schema", "database"}, dependency.ID)
+			}
+		})
+	}
+}
+
+func TestInspector_EnvironmentFunctions(t *testing.T) {
+	env, err := krocel.DefaultEnvironment(
+		krocel.WithResourceIDs([]string{"schema"}),
+		krocel.WithCustomDeclarations([]cel.EnvOption{
+			cel.Function("acme.greet",
+				cel.Overload("acme.greet_string", []*cel.Type{cel.StringType}, cel.StringType)),
+		}),
+	)
+	require.NoError(t, err)
+	inspector := NewInspectorWithEnv(env, []string{"schema"})
+
+	// The functions declared by the environment are known, without being
+	// listed by the inspector.
+	got, err := inspector.Inspect(`acme.greet(schema.spec.name) + names.dns1123(schema.metadata.name)`)
+	require.NoError(t, err)
+	assert.Empty(t, got.UnknownResources)
+	assert.Empty(t, got.UnknownFunctions)
+	names := make([]string, 0, len(got.FunctionCalls))
+	for _, call := range got.FunctionCalls {
+		names = append(names, call.Name)
+	}
+	assert.ElementsMatch(t, []string{"acme.greet", "names.dns1123"}, names)
+}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/google/cel-go/common/types"
 	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/common/types/traits"
 )
 
 var (
@@ -42,9 +43,17 @@ func GoNativeType(v ref.Val) (interface{}, error) {
 	case types.StringType:
 		return v.Value().(string), nil
 	case types.ListType:
-		return v.ConvertToNative(reflect.TypeOf([]interface{}{}))
+		lister, ok := v.(traits.Lister)
+		if !ok {
+			return v.ConvertToNative(reflect.TypeOf([]interface{}{}))
+		}
+		return goNativeList(lister)
 	case types.MapType:
-		return v.ConvertToNative(reflect.TypeOf(map[string]interface{}{}))
+		mapper, ok := v.(traits.Mapper)
+		if !ok {
+			return v.ConvertToNative(reflect.TypeOf(map[string]interface{}{}))
+		}
+		return goNativeMap(mapper)
 	case types.OptionalType:
 		opt := v.(*types.Optional)
 		if !opt.HasValue() {
@@ -59,7 +68,55 @@ func GoNativeType(v ref.Val) (interface{}, error) {
 	}
 }
 
+// goNativeList converts a CEL list, and its items, into a Go slice. Lists
+// built by expressions hold CEL values, which ConvertToNative doesn't convert.
+func goNativeList(lister traits.Lister) ([]interface{}, error) {
+	list := make([]interface{}, 0, int(lister.Size().(types.Int)))
+	for it := lister.Iterator(); it.HasNext() == types.True; {
+		item, err := GoNativeType(it.Next())
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, item)
+	}
+	return list, nil
+}
+
+// goNativeMap converts a CEL map, and its values, into a Go map with string
+// keys.
+func goNativeMap(mapper traits.Mapper) (map[string]interface{}, error) {
+	object := make(map[string]interface{}, int(mapper.Size().(types.Int)))
+	for it := mapper.Iterator(); it.HasNext() == types.True; {
+		key := it.Next()
+		name, ok := key.Value().(string)
+		if !ok {
+			return nil, fmt.Errorf("%w: map key of type %v", ErrUnsupportedType, key.Type())
+		}
+		value, err := GoNativeType(mapper.Get(key))
+		if err != nil {
+			return nil, err
+		}
+		object[name] = value
+	}
+	return object, nil
+}
+
 // IsBoolType checks if the given ref.Val is of type BoolType
 func IsBoolType(v ref.Val) bool {
 	return v.Type() == types.BoolType
 }
+
+// IsStringType checks if the given ref.Val is of type StringType
+func IsStringType(v ref.Val) bool {
+	return v.Type() == types.StringType
+}
+
+// IsMapType checks if the given ref.Val is of type MapType
+func IsMapType(v ref.Val) bool {
+	return v.Type() == types.MapType
+}
+
+// IsListType checks if the given ref.Val is of type ListType
+func IsListType(v ref.Val) bool {
+	return v.Type() == types.ListType
+}
@@ -0,0 +1,177 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cel
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/checker"
+	"github.com/google/cel-go/interpreter"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+const (
+	// DefaultExpressionCostLimit is the default maximum cost of evaluating a
+	// single expression. It matches the per call limit Kubernetes applies to
+	// the CEL validation rules of CRDs.
+	DefaultExpressionCostLimit uint64 = 1_000_000
+	// DefaultReconcileCostLimit is the default maximum cost of all the
+	// expressions evaluated while reconciling an instance.
+	DefaultReconcileCostLimit uint64 = 10_000_000
+	// DefaultEstimatedCostLimit is the default maximum estimated worst case
+	// cost of a single expression. The estimate assumes the largest possible
+	// sizes, so it is much larger than the actual cost of most evaluations:
+	// the limit only rejects expressions that can't reasonably be evaluated,
+	// like comprehensions nested three levels deep over unbounded lists.
+	DefaultEstimatedCostLimit uint64 = 1_000_000_000
+
+	// estimatedMaxSize is the size assumed, when estimating the cost of an
+	// expression, for the lists, maps and strings whose size isn't bounded by
+	// their schema.
+	estimatedMaxSize uint64 = 1000
+
+	// interruptCheckFrequency is the number of comprehension iterations
+	// after which an evaluation checks whether it was cancelled.
+	interruptCheckFrequency uint = 100
+)
+
+// CostLimits bounds the cost of evaluating user expressions. The cost is an
+// abstract measure of the number and expense of the operations performed by
+// an expression. A zero limit disables it.
+type CostLimits struct {
+	// Expression is the maximum actual cost of a single expression, enforced
+	// when it is evaluated.
+	Expression uint64
+	// Estimated is the maximum estimated worst case cost of a single
+	// expression, enforced when it is compiled.
+	Estimated uint64
+	// Reconcile is the maximum cost of all the expressions evaluated while
+	// reconciling an instance.
+	Reconcile uint64
+}
+
+// DefaultCostLimits returns the default cost limits.
+func DefaultCostLimits() CostLimits {
+	return CostLimits{
+		Expression: DefaultExpressionCostLimit,
+		Estimated:  DefaultEstimatedCostLimit,
+		Reconcile:  DefaultReconcileCostLimit,
+	}
+}
+
+// CheckEstimatedCost returns an error if the worst case cost of the checked
+// expression exceeds the given limit. A zero limit disables the check.
+//
+// The sizes of the lists, maps and strings are bounded by the maxItems,
+// maxProperties and maxLength of their schemas, found by following the path of
+// the fields selected from the variables in the given schemas.
+func CheckEstimatedCost(env *cel.Env, ast *cel.Ast, limit uint64, schemas map[string]*spec.Schema) error {
+	if limit == 0 {
+		return nil
+	}
+	estimate, err := env.EstimateCost(ast, sizeEstimator{schemas: schemas})
+	if err != nil {
+		return fmt.Errorf("failed to estimate expression cost: %w", err)
+	}
+	if estimate.Max > limit {
+		return fmt.Errorf("estimated expression cost %d exceeds the cost limit of %d", estimate.Max, limit)
+	}
+	return nil
+}
+
+// CostLimitProgramOptions returns the program options tracking the cost of
+// evaluations, and stopping them once they exceed the given limit. A zero
+// limit only tracks the cost.
+func CostLimitProgramOptions(limit uint64) []cel.ProgramOption {
+	options := []cel.ProgramOption{
+		cel.CostTracking(nil),
+		cel.InterruptCheckFrequency(interruptCheckFrequency),
+	}
+	if limit > 0 {
+		options = append(options, cel.CostLimit(limit))
+	}
+	return options
+}
+
+// IsCostLimitExceeded returns true if the error is returned by an evaluation
+// that exceeded its cost limit.
+func IsCostLimitExceeded(err error) bool {
+	var cancelled interpreter.EvalCancelledError
+	return errors.As(err, &cancelled) && cancelled.Cause == interpreter.CostLimitExceeded
+}
+
+// sizeEstimator bounds the sizes CEL can't infer with the schemas of the
+// variables, or else to estimatedMaxSize, and otherwise uses the default cost
+// of functions.
+type sizeEstimator struct {
+	// schemas are the schemas of the variables, by name.
+	schemas map[string]*spec.Schema
+}
+
+func (e sizeEstimator) EstimateSize(node checker.AstNode) *checker.SizeEstimate {
+	if size, ok := schemaMaxSize(e.schemas, node.Path()); ok {
+		return &checker.SizeEstimate{Min: 0, Max: size}
+	}
+	return &checker.SizeEstimate{Min: 0, Max: estimatedMaxSize}
+}
+
+// schemaMaxSize returns the maximum size of the value at the given path, a
+// variable name followed by field names, if its schema bounds it. The items
+// of lists and the values of maps are selected by the @items and @values
+// elements CEL adds to the paths of comprehension variables.
+func schemaMaxSize(schemas map[string]*spec.Schema, path []string) (uint64, bool) {
+	if len(path) == 0 {
+		return 0, false
+	}
+	schema := schemas[path[0]]
+	for _, field := range path[1:] {
+		if schema == nil {
+			return 0, false
+		}
+		switch field {
+		case "@items":
+			if schema.Items == nil {
+				return 0, false
+			}
+			schema = schema.Items.Schema
+		case "@values":
+			if schema.AdditionalProperties == nil {
+				return 0, false
+			}
+			schema = schema.AdditionalProperties.Schema
+		default:
+			property, ok := schema.Properties[field]
+			if !ok {
+				return 0, false
+			}
+			schema = &property
+		}
+	}
+	if schema == nil {
+		return 0, false
+	}
+	for _, size := range []*int64{schema.MaxItems, schema.MaxLength, schema.MaxProperties} {
+		if size != nil && *size >= 0 {
+			return uint64(*size), true
+		}
+	}
+	return 0, false
+}
+
+func (sizeEstimator) EstimateCallCost(_, _ string, _ *checker.AstNode, _ []checker.AstNode) *checker.CallEstimate {
+	return nil
+}
@@ -0,0 +1,146 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+func TestCheckEstimatedCost(t *testing.T) {
+	env, err := DefaultEnvironment(WithResourceIDs([]string{"schema", "vpc"}))
+	require.NoError(t, err)
+
+	maxItems := int64(10)
+	maxLength := int64(64)
+	schemas := map[string]*spec.Schema{
+		"schema": {SchemaProps: spec.SchemaProps{Properties: map[string]spec.Schema{
+			"spec": {SchemaProps: spec.SchemaProps{Properties: map[string]spec.Schema{
+				"zones": {SchemaProps: spec.SchemaProps{
+					Type:     []string{"array"},
+					MaxItems: &maxItems,
+					Items: &spec.SchemaOrArray{Schema: &spec.Schema{SchemaProps: spec.SchemaProps{
+						Type:      []string{"string"},
+						MaxLength: &maxLength,
+					}}},
+				}},
+			}}},
+		}}},
+	}
+
+	tests := []struct {
+		name       string
+		expression string
+		limit      uint64
+		wantErr    string
+	}{
+		{
+			name:       "cheap expression",
+			expression: "schema.spec.name + '-app'",
+			limit:      DefaultEstimatedCostLimit,
+		},
+		{
+			name:       "single comprehension",
+			expression: "schema.spec.ports.map(p, p.port)",
+			limit:      DefaultEstimatedCostLimit,
+		},
+		{
+			name:       "nested comprehensions",
+			expression: "schema.spec.zones.all(a, vpc.status.subnets.exists(b, a == b))",
+			limit:      DefaultEstimatedCostLimit,
+		},
+		{
+			name:       "three nested comprehensions",
+			expression: "schema.spec.a.map(x, schema.spec.b.map(y, schema.spec.c.map(z, [x, y, z])))",
+			limit:      DefaultEstimatedCostLimit,
+			wantErr:    "exceeds the cost limit of 1000000000",
+		},
+		{
+			name:       "three nested comprehensions over bounded lists",
+			expression: "schema.spec.zones.map(x, schema.spec.zones.map(y, schema.spec.zones.map(z, [x, y, z])))",
+			limit:      DefaultEstimatedCostLimit,
+		},
+		{
+			name:       "bounded lists are cheaper than the expression limit",
+			expression: "schema.spec.zones.all(a, schema.spec.zones.exists(b, a == b))",
+			limit:      DefaultExpressionCostLimit,
+		},
+		{
+			name:       "unbounded lists are more expensive than the expression limit",
+			expression: "schema.spec.regions.all(a, schema.spec.regions.exists(b, a == b))",
+			limit:      DefaultExpressionCostLimit,
+			wantErr:    "exceeds the cost limit of 1000000",
+		},
+		{
+			name:       "zero limit disables the check",
+			expression: "schema.spec.a.map(x, schema.spec.b.map(y, schema.spec.c.map(z, [x, y, z])))",
+			limit:      0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ast, issues := env.Compile(tt.expression)
+			require.NoError(t, issues.Err())
+
+			err := CheckEstimatedCost(env, ast, tt.limit, schemas)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestCostLimitProgramOptions(t *testing.T) {
+	env, err := DefaultEnvironment()
+	require.NoError(t, err)
+
+	ast, issues := env.Compile("[1, 2, 3, 4, 5].map(x, [1, 2, 3, 4, 5].map(y, x * y))")
+	require.NoError(t, issues.Err())
+
+	t.Run("evaluation within the limit", func(t *testing.T) {
+		program, err := env.Program(ast, CostLimitProgramOptions(DefaultExpressionCostLimit)...)
+		require.NoError(t, err)
+
+		_, details, err := program.Eval(map[string]interface{}{})
+		require.NoError(t, err)
+		require.NotNil(t, details.ActualCost())
+		assert.Positive(t, *details.ActualCost())
+	})
+
+	t.Run("evaluation exceeding the limit", func(t *testing.T) {
+		program, err := env.Program(ast, CostLimitProgramOptions(10)...)
+		require.NoError(t, err)
+
+		_, _, err = program.Eval(map[string]interface{}{})
+		require.Error(t, err)
+		assert.True(t, IsCostLimitExceeded(err))
+	})
+
+	t.Run("zero limit only tracks the cost", func(t *testing.T) {
+		program, err := env.Program(ast, CostLimitProgramOptions(0)...)
+		require.NoError(t, err)
+
+		_, details, err := program.Eval(map[string]interface{}{})
+		require.NoError(t, err)
+		require.NotNil(t, details.ActualCost())
+	})
+}
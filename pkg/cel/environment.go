@@ -21,6 +21,22 @@ import (
 	"github.com/kubernetes-sigs/kro/pkg/cel/library"
 )
 
+// KroVariable is the name of the CEL variable exposing the values provided by
+// kro itself. Its config field holds the global configuration values, as a map
+// of strings, e.g. kro.config.clusterName. The variable is namespaced under the
+// reserved kro identifier so that it can't shadow the resources of existing
+// ResourceGraphDefinitions.
+const KroVariable = "kro"
+
+// KroValue returns the value of the kro variable holding the given global
+// configuration values.
+func KroValue(config map[string]string) map[string]interface{} {
+	if config == nil {
+		config = map[string]string{}
+	}
+	return map[string]interface{}{"config": config}
+}
+
 // EnvOption is a function that modifies the environment options.
 type EnvOption func(*envOptions)
 
@@ -33,6 +49,8 @@ type envOptions struct {
 	resourceIDs []string
 	// customDeclarations will be added to the CEL environment.
 	customDeclarations []cel.EnvOption
+	// config declares the kro variable, holding the global configuration.
+	config bool
 }
 
 // WithResourceIDs adds resource ids that will be declared as CEL variables.
@@ -49,6 +67,14 @@ func WithCustomDeclarations(declarations []cel.EnvOption) EnvOption {
 	}
 }
 
+// WithConfig declares the kro variable, of type
+// map(string, map(string, string)).
+func WithConfig() EnvOption {
+	return func(opts *envOptions) {
+		opts.config = true
+	}
+}
+
 // DefaultEnvironment returns the default CEL environment.
 func DefaultEnvironment(options ...EnvOption) (*cel.Env, error) {
 	declarations := []cel.EnvOption{
@@ -57,6 +83,12 @@ func DefaultEnvironment(options ...EnvOption) (*cel.Env, error) {
 		cel.OptionalTypes(),
 		ext.Encoders(),
 		library.Random(),
+		library.Semver(),
+		library.URL(),
+		library.Network(),
+		library.Hash(),
+		library.Encoding(),
+		library.Names(),
 	}
 
 	opts := &envOptions{}
@@ -70,5 +102,10 @@ func DefaultEnvironment(options ...EnvOption) (*cel.Env, error) {
 		declarations = append(declarations, cel.Variable(name, cel.AnyType))
 	}
 
+	if opts.config {
+		declarations = append(declarations, cel.Variable(KroVariable,
+			cel.MapType(cel.StringType, cel.MapType(cel.StringType, cel.StringType))))
+	}
+
 	return cel.NewEnv(declarations...)
 }
@@ -140,6 +140,32 @@ func TestDefaultEnvironment(t *testing.T) {
 		})
 	}
 }
+
+func TestWithConfig(t *testing.T) {
+	env, err := DefaultEnvironment(WithConfig())
+	require.NoError(t, err)
+
+	ast, iss := env.Compile(`kro.config.registry + "/nginx"`)
+	require.NoError(t, iss.Err())
+	assert.Equal(t, cel.StringType, ast.OutputType())
+
+	// config values are strings.
+	_, iss = env.Compile(`kro.config.replicas + 1`)
+	assert.Error(t, iss.Err())
+
+	program, err := env.Program(ast)
+	require.NoError(t, err)
+	out, _, err := program.Eval(map[string]interface{}{
+		KroVariable: KroValue(map[string]string{"registry": "ghcr.io"}),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "ghcr.io/nginx", out.Value())
+
+	// config isn't declared, so that resources can still be named after it.
+	_, iss = env.Compile(`config.registry`)
+	assert.Error(t, iss.Err())
+}
+
 func Test_CELEnvHasFunction(t *testing.T) {
 	env, err := DefaultEnvironment()
 	require.NoError(t, err, "failed to create CEL env")
@@ -152,6 +178,14 @@ func Test_CELEnvHasFunction(t *testing.T) {
 		"int", "uint", "double", "bool", "string", "bytes", "timestamp", "duration", "type",
 		// Custom functions
 		"random.seededString",
+		"semver.compare",
+		"url.hostname",
+		"cidr.subnet",
+		"ip.isValid",
+		"hash.sha256",
+		"json.marshal",
+		"yaml.unmarshal",
+		"names.truncate",
 	}
 	for _, fn := range expectedFns {
 		t.Run(fn, func(t *testing.T) {
@@ -0,0 +1,182 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package library
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"google.golang.org/protobuf/types/known/structpb"
+	"sigs.k8s.io/yaml"
+)
+
+// jsonValueType is the type CEL values are converted to before being marshalled.
+var jsonValueType = reflect.TypeOf(&structpb.Value{})
+
+// Encoding returns a CEL library that provides functions to marshal values to,
+// and unmarshal values from, JSON and YAML documents.
+//
+// Library functions:
+//
+// json.marshal(dyn) and yaml.marshal(dyn) return the JSON or YAML document of a
+// value. Map keys are sorted, so that the output is stable.
+//
+// json.unmarshal(string) and yaml.unmarshal(string) return the value of a JSON
+// or YAML document. Whole numbers are returned as integers.
+//
+// Example usage:
+//
+//	json.marshal(schema.spec.config)
+//
+// This is typically used to embed configuration files in ConfigMaps.
+func Encoding() cel.EnvOption {
+	return cel.Lib(&encodingLibrary{})
+}
+
+type encodingLibrary struct{}
+
+func (l *encodingLibrary) LibraryName() string {
+	return "encoding"
+}
+
+func (l *encodingLibrary) CompileOptions() []cel.EnvOption {
+	return []cel.EnvOption{
+		cel.Function("json.marshal",
+			cel.Overload("json.marshal_dyn",
+				[]*cel.Type{cel.DynType},
+				cel.StringType,
+				cel.UnaryBinding(jsonMarshal),
+			),
+		),
+		cel.Function("json.unmarshal",
+			cel.Overload("json.unmarshal_string",
+				[]*cel.Type{cel.StringType},
+				cel.DynType,
+				cel.UnaryBinding(jsonUnmarshal),
+			),
+		),
+		cel.Function("yaml.marshal",
+			cel.Overload("yaml.marshal_dyn",
+				[]*cel.Type{cel.DynType},
+				cel.StringType,
+				cel.UnaryBinding(yamlMarshal),
+			),
+		),
+		cel.Function("yaml.unmarshal",
+			cel.Overload("yaml.unmarshal_string",
+				[]*cel.Type{cel.StringType},
+				cel.DynType,
+				cel.UnaryBinding(yamlUnmarshal),
+			),
+		),
+	}
+}
+
+func (l *encodingLibrary) ProgramOptions() []cel.ProgramOption {
+	return nil
+}
+
+// toNative converts a CEL value to its JSON compatible Go representation.
+func toNative(function string, val ref.Val) (interface{}, ref.Val) {
+	native, err := val.ConvertToNative(jsonValueType)
+	if err != nil {
+		return nil, types.NewErr("%s: unsupported value: %v", function, err)
+	}
+	return native.(*structpb.Value).AsInterface(), nil
+}
+
+func jsonMarshal(val ref.Val) ref.Val {
+	native, errVal := toNative("json.marshal", val)
+	if errVal != nil {
+		return errVal
+	}
+	data, err := json.Marshal(native)
+	if err != nil {
+		return types.NewErr("json.marshal: %v", err)
+	}
+	return types.String(data)
+}
+
+func yamlMarshal(val ref.Val) ref.Val {
+	native, errVal := toNative("yaml.marshal", val)
+	if errVal != nil {
+		return errVal
+	}
+	data, err := yaml.Marshal(native)
+	if err != nil {
+		return types.NewErr("yaml.marshal: %v", err)
+	}
+	return types.String(data)
+}
+
+func jsonUnmarshal(val ref.Val) ref.Val {
+	s, ok := val.(types.String)
+	if !ok {
+		return types.NewErr("json.unmarshal argument must be a string")
+	}
+	return unmarshalJSON("json.unmarshal", []byte(s))
+}
+
+func yamlUnmarshal(val ref.Val) ref.Val {
+	s, ok := val.(types.String)
+	if !ok {
+		return types.NewErr("yaml.unmarshal argument must be a string")
+	}
+	data, err := yaml.YAMLToJSON([]byte(s))
+	if err != nil {
+		return types.NewErr("yaml.unmarshal: %v", err)
+	}
+	return unmarshalJSON("yaml.unmarshal", data)
+}
+
+func unmarshalJSON(function string, data []byte) ref.Val {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var native interface{}
+	if err := decoder.Decode(&native); err != nil {
+		return types.NewErr("%s: %v", function, err)
+	}
+	return types.DefaultTypeAdapter.NativeToValue(normalizeNumbers(native))
+}
+
+// normalizeNumbers replaces the json.Number values with integers when they are
+// whole numbers, and floats otherwise.
+func normalizeNumbers(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = normalizeNumbers(item)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = normalizeNumbers(item)
+		}
+		return v
+	default:
+		return v
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package library
+
+import (
+	"testing"
+)
+
+func TestEncoding(t *testing.T) {
+	runLibraryTests(t, Encoding(), []libraryTestCase{
+		{
+			name: "json marshal map",
+			expr: `json.marshal({"b": [1, 2.5, "x"], "a": true, "c": null})`,
+			want: `{"a":true,"b":[1,2.5,"x"],"c":null}`,
+		},
+		{
+			name: "json marshal string",
+			expr: `json.marshal("hello")`,
+			want: `"hello"`,
+		},
+		{
+			name: "yaml marshal map",
+			expr: `yaml.marshal({"server": {"port": 8080, "hosts": ["a", "b"]}})`,
+			want: "server:\n  hosts:\n  - a\n  - b\n  port: 8080\n",
+		},
+		{
+			name: "json unmarshal",
+			expr: `json.unmarshal('{"replicas": 3, "ratio": 0.5, "tags": ["a"]}')`,
+			want: map[string]interface{}{
+				"replicas": int64(3),
+				"ratio":    0.5,
+				"tags":     []interface{}{"a"},
+			},
+		},
+		{
+			name: "json unmarshal field access",
+			expr: `json.unmarshal('{"replicas": 3}').replicas + 1`,
+			want: int64(4),
+		},
+		{
+			name: "yaml unmarshal",
+			expr: `yaml.unmarshal('port: 8080\nhosts:\n- a\n').port`,
+			want: int64(8080),
+		},
+		{
+			name: "round trip",
+			expr: `yaml.unmarshal(yaml.marshal({"a": {"b": "c"}})) == {"a": {"b": "c"}}`,
+			want: true,
+		},
+		{
+			name:    "invalid json",
+			expr:    `json.unmarshal('{')`,
+			wantErr: "json.unmarshal: unexpected EOF",
+		},
+	})
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package library
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"hash"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+)
+
+// Hash returns a CEL library that provides hashing functions. Every function
+// accepts a string or bytes, and returns the hex encoded digest.
+//
+// Library functions:
+//
+// hash.sha256(string|bytes), hash.sha512(string|bytes), hash.sha1(string|bytes)
+// and hash.md5(string|bytes).
+//
+// Example usage:
+//
+//	hash.sha256(json.marshal(configmap.data))
+//
+// This is typically used to annotate a workload with the checksum of its
+// configuration, so that it is rolled out when the configuration changes.
+func Hash() cel.EnvOption {
+	return cel.Lib(&hashLibrary{})
+}
+
+type hashLibrary struct{}
+
+func (l *hashLibrary) LibraryName() string {
+	return "hash"
+}
+
+func (l *hashLibrary) CompileOptions() []cel.EnvOption {
+	hashFunction := func(name string, newHash func() hash.Hash) cel.EnvOption {
+		binding := cel.UnaryBinding(func(val ref.Val) ref.Val {
+			var data []byte
+			switch v := val.(type) {
+			case types.String:
+				data = []byte(v)
+			case types.Bytes:
+				data = v
+			default:
+				return types.NewErr("%s argument must be a string or bytes", name)
+			}
+			h := newHash()
+			h.Write(data)
+			return types.String(hex.EncodeToString(h.Sum(nil)))
+		})
+		return cel.Function(name,
+			cel.Overload(name+"_string", []*cel.Type{cel.StringType}, cel.StringType, binding),
+			cel.Overload(name+"_bytes", []*cel.Type{cel.BytesType}, cel.StringType, binding),
+		)
+	}
+
+	return []cel.EnvOption{
+		hashFunction("hash.sha256", sha256.New),
+		hashFunction("hash.sha512", sha512.New),
+		hashFunction("hash.sha1", sha1.New),
+		hashFunction("hash.md5", md5.New),
+	}
+}
+
+func (l *hashLibrary) ProgramOptions() []cel.ProgramOption {
+	return nil
+}
@@ -0,0 +1,54 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package library
+
+import (
+	"testing"
+)
+
+func TestHash(t *testing.T) {
+	runLibraryTests(t, Hash(), []libraryTestCase{
+		{
+			name: "sha256",
+			expr: "hash.sha256('kro')",
+			want: "53478db94ea65ea77f0cd9056cd16926d7e6605e0b7f74257194ea8553521854",
+		},
+		{
+			name: "sha256 bytes",
+			expr: "hash.sha256(b'kro') == hash.sha256('kro')",
+			want: true,
+		},
+		{
+			name: "sha512",
+			expr: "size(hash.sha512('kro'))",
+			want: int64(128),
+		},
+		{
+			name: "sha1",
+			expr: "hash.sha1('')",
+			want: "da39a3ee5e6b4b0d3255bfef95601890afd80709",
+		},
+		{
+			name: "md5",
+			expr: "hash.md5('')",
+			want: "d41d8cd98f00b204e9800998ecf8427e",
+		},
+		{
+			name:    "non string argument",
+			expr:    "hash.sha256(1)",
+			wantErr: "found no matching overload",
+		},
+	})
+}
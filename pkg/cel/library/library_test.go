@@ -0,0 +1,66 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package library
+
+import (
+	"testing"
+
+	"github.com/google/cel-go/cel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// libraryTestCase is an expression evaluated in an environment with a single
+// library, and its expected result.
+type libraryTestCase struct {
+	name string
+	expr string
+	// want is the expected native value of the result.
+	want interface{}
+	// wantErr is a substring of the expected compile or evaluation error.
+	wantErr string
+}
+
+// runLibraryTests compiles and evaluates the test cases in an environment
+// with the given library.
+func runLibraryTests(t *testing.T, lib cel.EnvOption, tests []libraryTestCase) {
+	t.Helper()
+
+	env, err := cel.NewEnv(lib)
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ast, issues := env.Compile(tt.expr)
+			if issues != nil && issues.Err() != nil {
+				require.NotEmpty(t, tt.wantErr, "unexpected compile error: %v", issues.Err())
+				assert.Contains(t, issues.String(), tt.wantErr)
+				return
+			}
+
+			program, err := env.Program(ast)
+			require.NoError(t, err)
+
+			out, _, err := program.Eval(map[string]interface{}{})
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, out.Value())
+		})
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package library
+
+import (
+	"crypto/sha256"
+	"encoding/base32"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+)
+
+const (
+	// nameHashLength is the length of the hash suffix appended to truncated
+	// names.
+	nameHashLength = 8
+	// dns1123LabelMaxLength is the maximum length of a DNS-1123 label.
+	dns1123LabelMaxLength = 63
+)
+
+// nameEncoding is a lowercase base32 encoding, without padding, whose output
+// is safe to use in DNS names.
+var nameEncoding = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
+// Names returns a CEL library that provides functions to build valid
+// Kubernetes object names.
+//
+// Library functions:
+//
+// names.hash(string, int) returns a hash of the string, of the given length,
+// using lowercase base32 characters only.
+//
+// names.truncate(string, int) returns the string if it is short enough, or
+// truncates it and appends a hash of the whole string so that truncated names
+// remain unique. The result is at most of the given length, and at least 10.
+//
+// names.dns1123(string) returns a DNS-1123 label: the string in lowercase,
+// with invalid characters replaced by '-', and with no leading or trailing
+// '-'. Labels longer than 63 characters are truncated like names.truncate
+// does. Strings without any valid character are an error.
+//
+// Example usage:
+//
+//	names.truncate(schema.metadata.name + '-' + schema.spec.component, 63)
+func Names() cel.EnvOption {
+	return cel.Lib(&namesLibrary{})
+}
+
+type namesLibrary struct{}
+
+func (l *namesLibrary) LibraryName() string {
+	return "names"
+}
+
+func (l *namesLibrary) CompileOptions() []cel.EnvOption {
+	return []cel.EnvOption{
+		cel.Function("names.hash",
+			cel.Overload("names.hash_string_int",
+				[]*cel.Type{cel.StringType, cel.IntType},
+				cel.StringType,
+				cel.BinaryBinding(namesHash),
+			),
+		),
+		cel.Function("names.truncate",
+			cel.Overload("names.truncate_string_int",
+				[]*cel.Type{cel.StringType, cel.IntType},
+				cel.StringType,
+				cel.BinaryBinding(namesTruncate),
+			),
+		),
+		cel.Function("names.dns1123",
+			cel.Overload("names.dns1123_string",
+				[]*cel.Type{cel.StringType},
+				cel.StringType,
+				cel.UnaryBinding(namesDNS1123),
+			),
+		),
+	}
+}
+
+func (l *namesLibrary) ProgramOptions() []cel.ProgramOption {
+	return nil
+}
+
+// nameHash returns the lowercase base32 encoding of the sha256 of s.
+func nameHash(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return nameEncoding.EncodeToString(sum[:])
+}
+
+func namesHash(val, length ref.Val) ref.Val {
+	s, ok := val.(types.String)
+	if !ok {
+		return types.NewErr("names.hash argument must be a string")
+	}
+	n, ok := length.(types.Int)
+	if !ok {
+		return types.NewErr("names.hash length must be an integer")
+	}
+	hash := nameHash(string(s))
+	if n <= 0 || int(n) > len(hash) {
+		return types.NewErr("names.hash length must be between 1 and %d", len(hash))
+	}
+	return types.String(hash[:n])
+}
+
+func namesTruncate(val, maxLength ref.Val) ref.Val {
+	s, ok := val.(types.String)
+	if !ok {
+		return types.NewErr("names.truncate argument must be a string")
+	}
+	n, ok := maxLength.(types.Int)
+	if !ok {
+		return types.NewErr("names.truncate length must be an integer")
+	}
+	if n < nameHashLength+2 {
+		return types.NewErr("names.truncate length must be at least %d", nameHashLength+2)
+	}
+
+	return types.String(truncateName(string(s), int(n)))
+}
+
+// truncateName returns the name if it is at most maxLength long, or else its
+// prefix followed by a hash of the whole name, maxLength long at most.
+func truncateName(name string, maxLength int) string {
+	if len(name) <= maxLength {
+		return name
+	}
+	prefix := strings.TrimRight(name[:maxLength-nameHashLength-1], "-.")
+	return prefix + "-" + nameHash(name)[:nameHashLength]
+}
+
+func namesDNS1123(val ref.Val) ref.Val {
+	s, ok := val.(types.String)
+	if !ok {
+		return types.NewErr("names.dns1123 argument must be a string")
+	}
+	label := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + ('a' - 'A')
+		default:
+			return '-'
+		}
+	}, string(s))
+	label = strings.Trim(label, "-")
+	if label == "" {
+		return types.NewErr("names.dns1123 argument %q has no valid label characters", string(s))
+	}
+	return types.String(truncateName(label, dns1123LabelMaxLength))
+}
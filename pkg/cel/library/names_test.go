@@ -0,0 +1,76 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package library
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNames(t *testing.T) {
+	long := strings.Repeat("a", 70)
+	runLibraryTests(t, Names(), []libraryTestCase{
+		{name: "hash length", expr: "size(names.hash('my-app', 10))", want: int64(10)},
+		{name: "hash is deterministic", expr: "names.hash('my-app', 10) == names.hash('my-app', 10)", want: true},
+		{name: "hash depends on input", expr: "names.hash('my-app', 10) != names.hash('my-app2', 10)", want: true},
+		{name: "hash is dns safe", expr: "names.hash('my-app', 52).matches('^[a-z2-7]+$')", want: true},
+		{name: "short name is not truncated", expr: "names.truncate('my-app', 63)", want: "my-app"},
+		{name: "truncated length", expr: "size(names.truncate('" + long + "', 63))", want: int64(63)},
+		{
+			name: "truncated names are unique",
+			expr: "names.truncate('" + long + "-x', 63) != names.truncate('" + long + "-y', 63)",
+			want: true,
+		},
+		{
+			name: "truncated name keeps the prefix",
+			expr: "names.truncate('my-application-frontend', 20).startsWith('my-applicat-')",
+			want: true,
+		},
+		{name: "dns1123", expr: "names.dns1123('My_App.Frontend!')", want: "my-app-frontend"},
+		{name: "dns1123 trims", expr: "names.dns1123('--app--')", want: "app"},
+		{name: "dns1123 length", expr: "size(names.dns1123('" + long + "'))", want: int64(63)},
+		{name: "dns1123 short label", expr: "names.dns1123('" + long[:63] + "')", want: long[:63]},
+		{
+			name: "dns1123 truncated labels are unique",
+			expr: "names.dns1123('" + long + "-x') != names.dns1123('" + long + "-y')",
+			want: true,
+		},
+		{
+			name: "dns1123 truncated label is valid",
+			expr: "names.dns1123('My_App.' + '" + long + "').matches('^[a-z0-9]([-a-z0-9]*[a-z0-9])?$')",
+			want: true,
+		},
+		{
+			name:    "dns1123 empty label",
+			expr:    "names.dns1123('_.!')",
+			wantErr: "names.dns1123 argument \"_.!\" has no valid label characters",
+		},
+		{
+			name:    "dns1123 empty string",
+			expr:    "names.dns1123('')",
+			wantErr: "has no valid label characters",
+		},
+		{
+			name:    "hash length out of range",
+			expr:    "names.hash('my-app', 0)",
+			wantErr: "names.hash length must be between 1 and 52",
+		},
+		{
+			name:    "truncate length too small",
+			expr:    "names.truncate('my-app', 5)",
+			wantErr: "names.truncate length must be at least 10",
+		},
+	})
+}
@@ -0,0 +1,227 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package library
+
+import (
+	"math/big"
+	"net/netip"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+)
+
+// Network returns a CEL library that provides functions to work with IP
+// addresses and CIDR prefixes.
+//
+// Library functions:
+//
+// ip.isValid(string), ip.isIPv4(string) and ip.isIPv6(string) validate IP
+// addresses.
+//
+// cidr.isValid(string) returns true if the string is a valid CIDR prefix.
+//
+// cidr.contains(string, string) returns true if the prefix contains the IP
+// address.
+//
+// cidr.prefixLength(string) returns the length of the prefix.
+//
+// cidr.subnet(string, int, int) returns the netNum-th subnet of the prefix,
+// extended by newBits bits. It behaves like the Terraform cidrsubnet function.
+//
+// cidr.host(string, int) returns the hostNum-th IP address of the prefix.
+//
+// Example usage:
+//
+//	cidr.subnet(schema.spec.vpcCIDR, 8, 1) // "10.0.1.0/24" for "10.0.0.0/16"
+func Network() cel.EnvOption {
+	return cel.Lib(&networkLibrary{})
+}
+
+type networkLibrary struct{}
+
+func (l *networkLibrary) LibraryName() string {
+	return "network"
+}
+
+func (l *networkLibrary) CompileOptions() []cel.EnvOption {
+	ipPredicate := func(name string, predicate func(netip.Addr) bool) cel.EnvOption {
+		return cel.Function(name,
+			cel.Overload(name+"_string",
+				[]*cel.Type{cel.StringType},
+				cel.BoolType,
+				cel.UnaryBinding(func(val ref.Val) ref.Val {
+					s, ok := val.(types.String)
+					if !ok {
+						return types.NewErr("%s argument must be a string", name)
+					}
+					addr, err := netip.ParseAddr(string(s))
+					return types.Bool(err == nil && predicate(addr))
+				}),
+			),
+		)
+	}
+
+	return []cel.EnvOption{
+		ipPredicate("ip.isValid", netip.Addr.IsValid),
+		ipPredicate("ip.isIPv4", netip.Addr.Is4),
+		ipPredicate("ip.isIPv6", func(addr netip.Addr) bool { return addr.Is6() && !addr.Is4In6() }),
+		cel.Function("cidr.isValid",
+			cel.Overload("cidr.isValid_string",
+				[]*cel.Type{cel.StringType},
+				cel.BoolType,
+				cel.UnaryBinding(cidrIsValid),
+			),
+		),
+		cel.Function("cidr.contains",
+			cel.Overload("cidr.contains_string_string",
+				[]*cel.Type{cel.StringType, cel.StringType},
+				cel.BoolType,
+				cel.BinaryBinding(cidrContains),
+			),
+		),
+		cel.Function("cidr.prefixLength",
+			cel.Overload("cidr.prefixLength_string",
+				[]*cel.Type{cel.StringType},
+				cel.IntType,
+				cel.UnaryBinding(cidrPrefixLength),
+			),
+		),
+		cel.Function("cidr.subnet",
+			cel.Overload("cidr.subnet_string_int_int",
+				[]*cel.Type{cel.StringType, cel.IntType, cel.IntType},
+				cel.StringType,
+				cel.FunctionBinding(cidrSubnet),
+			),
+		),
+		cel.Function("cidr.host",
+			cel.Overload("cidr.host_string_int",
+				[]*cel.Type{cel.StringType, cel.IntType},
+				cel.StringType,
+				cel.BinaryBinding(cidrHost),
+			),
+		),
+	}
+}
+
+func (l *networkLibrary) ProgramOptions() []cel.ProgramOption {
+	return nil
+}
+
+// parsePrefix parses a CIDR prefix, and returns it masked.
+func parsePrefix(function string, val ref.Val) (netip.Prefix, ref.Val) {
+	s, ok := val.(types.String)
+	if !ok {
+		return netip.Prefix{}, types.NewErr("%s prefix must be a string", function)
+	}
+	prefix, err := netip.ParsePrefix(string(s))
+	if err != nil {
+		return netip.Prefix{}, types.NewErr("%s: invalid CIDR %q: %v", function, string(s), err)
+	}
+	return prefix.Masked(), nil
+}
+
+func cidrIsValid(val ref.Val) ref.Val {
+	s, ok := val.(types.String)
+	if !ok {
+		return types.NewErr("cidr.isValid argument must be a string")
+	}
+	_, err := netip.ParsePrefix(string(s))
+	return types.Bool(err == nil)
+}
+
+func cidrContains(prefixVal, ipVal ref.Val) ref.Val {
+	prefix, errVal := parsePrefix("cidr.contains", prefixVal)
+	if errVal != nil {
+		return errVal
+	}
+	s, ok := ipVal.(types.String)
+	if !ok {
+		return types.NewErr("cidr.contains address must be a string")
+	}
+	addr, err := netip.ParseAddr(string(s))
+	if err != nil {
+		return types.NewErr("cidr.contains: invalid IP address %q: %v", string(s), err)
+	}
+	return types.Bool(prefix.Contains(addr))
+}
+
+func cidrPrefixLength(val ref.Val) ref.Val {
+	prefix, errVal := parsePrefix("cidr.prefixLength", val)
+	if errVal != nil {
+		return errVal
+	}
+	return types.Int(prefix.Bits())
+}
+
+func cidrSubnet(args ...ref.Val) ref.Val {
+	if len(args) != 3 {
+		return types.NewErr("cidr.subnet expects 3 arguments")
+	}
+	prefix, errVal := parsePrefix("cidr.subnet", args[0])
+	if errVal != nil {
+		return errVal
+	}
+	newBits, ok := args[1].(types.Int)
+	if !ok {
+		return types.NewErr("cidr.subnet newBits must be an integer")
+	}
+	netNum, ok := args[2].(types.Int)
+	if !ok {
+		return types.NewErr("cidr.subnet netNum must be an integer")
+	}
+
+	bits := prefix.Bits() + int(newBits)
+	if newBits < 0 || bits > prefix.Addr().BitLen() {
+		return types.NewErr("cidr.subnet: can't extend prefix %s by %d bits", prefix, int64(newBits))
+	}
+	if netNum < 0 || big.NewInt(int64(netNum)).BitLen() > int(newBits) {
+		return types.NewErr("cidr.subnet: prefix %s extended by %d bits has no subnet %d",
+			prefix, int64(newBits), int64(netNum))
+	}
+
+	// The subnet number is shifted to the host bits of the new prefix.
+	offset := new(big.Int).Lsh(big.NewInt(int64(netNum)), uint(prefix.Addr().BitLen()-bits))
+	return types.String(netip.PrefixFrom(addToAddr(prefix.Addr(), offset), bits).String())
+}
+
+func cidrHost(prefixVal, hostNumVal ref.Val) ref.Val {
+	prefix, errVal := parsePrefix("cidr.host", prefixVal)
+	if errVal != nil {
+		return errVal
+	}
+	hostNum, ok := hostNumVal.(types.Int)
+	if !ok {
+		return types.NewErr("cidr.host hostNum must be an integer")
+	}
+
+	hostBits := prefix.Addr().BitLen() - prefix.Bits()
+	if hostNum < 0 || big.NewInt(int64(hostNum)).BitLen() > hostBits {
+		return types.NewErr("cidr.host: prefix %s has no host %d", prefix, int64(hostNum))
+	}
+	return types.String(addToAddr(prefix.Addr(), big.NewInt(int64(hostNum))).String())
+}
+
+// addToAddr adds the given offset to an IP address. The offset must fit in
+// the address, which callers ensure by checking it against the prefix.
+func addToAddr(addr netip.Addr, offset *big.Int) netip.Addr {
+	sum := new(big.Int).SetBytes(addr.AsSlice())
+	sum.Add(sum, offset)
+
+	b := make([]byte, addr.BitLen()/8)
+	sum.FillBytes(b)
+	result, _ := netip.AddrFromSlice(b)
+	return result
+}
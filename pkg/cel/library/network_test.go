@@ -0,0 +1,60 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package library
+
+import (
+	"testing"
+)
+
+func TestNetwork(t *testing.T) {
+	runLibraryTests(t, Network(), []libraryTestCase{
+		{name: "valid ip", expr: "ip.isValid('10.0.0.1')", want: true},
+		{name: "invalid ip", expr: "ip.isValid('10.0.0.256')", want: false},
+		{name: "ipv4", expr: "ip.isIPv4('10.0.0.1')", want: true},
+		{name: "ipv6 is not ipv4", expr: "ip.isIPv4('fd00::1')", want: false},
+		{name: "ipv6", expr: "ip.isIPv6('fd00::1')", want: true},
+		{name: "valid cidr", expr: "cidr.isValid('10.0.0.0/16')", want: true},
+		{name: "invalid cidr", expr: "cidr.isValid('10.0.0.0/33')", want: false},
+		{name: "contains", expr: "cidr.contains('10.0.0.0/16', '10.0.200.3')", want: true},
+		{name: "does not contain", expr: "cidr.contains('10.0.0.0/16', '10.1.0.1')", want: false},
+		{name: "prefix length", expr: "cidr.prefixLength('10.0.0.0/16')", want: int64(16)},
+		{name: "first subnet", expr: "cidr.subnet('10.0.0.0/16', 8, 0)", want: "10.0.0.0/24"},
+		{name: "subnet", expr: "cidr.subnet('10.0.0.0/16', 8, 2)", want: "10.0.2.0/24"},
+		{name: "subnet of unmasked prefix", expr: "cidr.subnet('10.0.12.3/16', 4, 15)", want: "10.0.240.0/20"},
+		{name: "ipv6 subnet", expr: "cidr.subnet('fd00::/48', 16, 1)", want: "fd00:0:0:1::/64"},
+		{name: "host", expr: "cidr.host('10.0.1.0/24', 10)", want: "10.0.1.10"},
+		{name: "ipv6 host", expr: "cidr.host('fd00::/64', 255)", want: "fd00::ff"},
+		{
+			name:    "subnet out of range",
+			expr:    "cidr.subnet('10.0.0.0/16', 2, 4)",
+			wantErr: "has no subnet 4",
+		},
+		{
+			name:    "subnet too long",
+			expr:    "cidr.subnet('10.0.0.0/16', 17, 0)",
+			wantErr: "can't extend prefix 10.0.0.0/16 by 17 bits",
+		},
+		{
+			name:    "host out of range",
+			expr:    "cidr.host('10.0.1.0/24', 256)",
+			wantErr: "has no host 256",
+		},
+		{
+			name:    "invalid prefix",
+			expr:    "cidr.subnet('10.0.0.0', 8, 0)",
+			wantErr: `cidr.subnet: invalid CIDR "10.0.0.0"`,
+		},
+	})
+}
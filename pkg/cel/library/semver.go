@@ -0,0 +1,144 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package library
+
+import (
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"k8s.io/apimachinery/pkg/util/version"
+)
+
+// Semver returns a CEL library that provides functions to work with semantic
+// versions. A leading "v" is accepted, e.g. "v1.2.3".
+//
+// Library functions:
+//
+// semver.isValid(string) returns true if the string is a valid semantic version.
+//
+// semver.compare(string, string) returns -1, 0 or 1 if the first version is
+// respectively lower than, equal to or greater than the second one.
+//
+// semver.major(string), semver.minor(string) and semver.patch(string) return
+// the components of a version.
+//
+// Example usage:
+//
+//	semver.compare(schema.spec.version, '1.28.0') >= 0
+func Semver() cel.EnvOption {
+	return cel.Lib(&semverLibrary{})
+}
+
+type semverLibrary struct{}
+
+func (l *semverLibrary) LibraryName() string {
+	return "semver"
+}
+
+func (l *semverLibrary) CompileOptions() []cel.EnvOption {
+	return []cel.EnvOption{
+		cel.Function("semver.isValid",
+			cel.Overload("semver.isValid_string",
+				[]*cel.Type{cel.StringType},
+				cel.BoolType,
+				cel.UnaryBinding(semverIsValid),
+			),
+		),
+		cel.Function("semver.compare",
+			cel.Overload("semver.compare_string_string",
+				[]*cel.Type{cel.StringType, cel.StringType},
+				cel.IntType,
+				cel.BinaryBinding(semverCompare),
+			),
+		),
+		cel.Function("semver.major",
+			cel.Overload("semver.major_string",
+				[]*cel.Type{cel.StringType},
+				cel.IntType,
+				cel.UnaryBinding(semverComponent("semver.major", (*version.Version).Major)),
+			),
+		),
+		cel.Function("semver.minor",
+			cel.Overload("semver.minor_string",
+				[]*cel.Type{cel.StringType},
+				cel.IntType,
+				cel.UnaryBinding(semverComponent("semver.minor", (*version.Version).Minor)),
+			),
+		),
+		cel.Function("semver.patch",
+			cel.Overload("semver.patch_string",
+				[]*cel.Type{cel.StringType},
+				cel.IntType,
+				cel.UnaryBinding(semverComponent("semver.patch", (*version.Version).Patch)),
+			),
+		),
+	}
+}
+
+func (l *semverLibrary) ProgramOptions() []cel.ProgramOption {
+	return nil
+}
+
+func parseSemver(function string, val ref.Val) (*version.Version, ref.Val) {
+	s, ok := val.(types.String)
+	if !ok {
+		return nil, types.NewErr("%s argument must be a string", function)
+	}
+	v, err := version.ParseSemantic(strings.TrimPrefix(string(s), "v"))
+	if err != nil {
+		return nil, types.NewErr("%s: invalid semantic version %q: %v", function, string(s), err)
+	}
+	return v, nil
+}
+
+func semverIsValid(val ref.Val) ref.Val {
+	s, ok := val.(types.String)
+	if !ok {
+		return types.NewErr("semver.isValid argument must be a string")
+	}
+	_, err := version.ParseSemantic(strings.TrimPrefix(string(s), "v"))
+	return types.Bool(err == nil)
+}
+
+func semverCompare(lhs, rhs ref.Val) ref.Val {
+	a, errVal := parseSemver("semver.compare", lhs)
+	if errVal != nil {
+		return errVal
+	}
+	b, errVal := parseSemver("semver.compare", rhs)
+	if errVal != nil {
+		return errVal
+	}
+	switch {
+	case a.LessThan(b):
+		return types.Int(-1)
+	case b.LessThan(a):
+		return types.Int(1)
+	default:
+		return types.Int(0)
+	}
+}
+
+func semverComponent(function string, component func(*version.Version) uint) func(ref.Val) ref.Val {
+	return func(val ref.Val) ref.Val {
+		v, errVal := parseSemver(function, val)
+		if errVal != nil {
+			return errVal
+		}
+		return types.Int(component(v))
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package library
+
+import (
+	"testing"
+)
+
+func TestSemver(t *testing.T) {
+	runLibraryTests(t, Semver(), []libraryTestCase{
+		{name: "valid version", expr: "semver.isValid('1.2.3')", want: true},
+		{name: "valid version with v prefix", expr: "semver.isValid('v1.2.3-rc.1')", want: true},
+		{name: "invalid version", expr: "semver.isValid('1.2')", want: false},
+		{name: "compare lower", expr: "semver.compare('1.2.3', '1.10.0')", want: int64(-1)},
+		{name: "compare equal", expr: "semver.compare('v1.2.3', '1.2.3')", want: int64(0)},
+		{name: "compare greater", expr: "semver.compare('2.0.0', '2.0.0-beta.1')", want: int64(1)},
+		{name: "major", expr: "semver.major('v1.28.3')", want: int64(1)},
+		{name: "minor", expr: "semver.minor('v1.28.3')", want: int64(28)},
+		{name: "patch", expr: "semver.patch('v1.28.3')", want: int64(3)},
+		{
+			name:    "compare invalid version",
+			expr:    "semver.compare('latest', '1.0.0')",
+			wantErr: `semver.compare: invalid semantic version "latest"`,
+		},
+		{
+			name:    "non string argument",
+			expr:    "semver.major(1)",
+			wantErr: "found no matching overload",
+		},
+	})
+}
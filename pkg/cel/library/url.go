@@ -0,0 +1,147 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package library
+
+import (
+	"net/url"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+)
+
+// URL returns a CEL library that provides functions to work with URLs.
+//
+// Library functions:
+//
+// url.isValid(string) returns true if the string is an absolute URL.
+//
+// url.scheme(string), url.host(string), url.hostname(string), url.port(string)
+// and url.path(string) return the components of a URL. url.host includes the
+// port, url.hostname doesn't.
+//
+// url.query(string, string) returns the first value of a query parameter, or
+// an empty string.
+//
+// url.join(string, string) joins a path to a URL.
+//
+// Example usage:
+//
+//	url.hostname(rds.status.endpoint.address)
+func URL() cel.EnvOption {
+	return cel.Lib(&urlLibrary{})
+}
+
+type urlLibrary struct{}
+
+func (l *urlLibrary) LibraryName() string {
+	return "url"
+}
+
+func (l *urlLibrary) CompileOptions() []cel.EnvOption {
+	component := func(name string, get func(*url.URL) string) cel.EnvOption {
+		return cel.Function(name,
+			cel.Overload(name+"_string",
+				[]*cel.Type{cel.StringType},
+				cel.StringType,
+				cel.UnaryBinding(func(val ref.Val) ref.Val {
+					u, errVal := parseURL(name, val)
+					if errVal != nil {
+						return errVal
+					}
+					return types.String(get(u))
+				}),
+			),
+		)
+	}
+
+	return []cel.EnvOption{
+		cel.Function("url.isValid",
+			cel.Overload("url.isValid_string",
+				[]*cel.Type{cel.StringType},
+				cel.BoolType,
+				cel.UnaryBinding(urlIsValid),
+			),
+		),
+		component("url.scheme", func(u *url.URL) string { return u.Scheme }),
+		component("url.host", func(u *url.URL) string { return u.Host }),
+		component("url.hostname", (*url.URL).Hostname),
+		component("url.port", (*url.URL).Port),
+		component("url.path", func(u *url.URL) string { return u.Path }),
+		cel.Function("url.query",
+			cel.Overload("url.query_string_string",
+				[]*cel.Type{cel.StringType, cel.StringType},
+				cel.StringType,
+				cel.BinaryBinding(urlQuery),
+			),
+		),
+		cel.Function("url.join",
+			cel.Overload("url.join_string_string",
+				[]*cel.Type{cel.StringType, cel.StringType},
+				cel.StringType,
+				cel.BinaryBinding(urlJoin),
+			),
+		),
+	}
+}
+
+func (l *urlLibrary) ProgramOptions() []cel.ProgramOption {
+	return nil
+}
+
+func parseURL(function string, val ref.Val) (*url.URL, ref.Val) {
+	s, ok := val.(types.String)
+	if !ok {
+		return nil, types.NewErr("%s argument must be a string", function)
+	}
+	u, err := url.Parse(string(s))
+	if err != nil {
+		return nil, types.NewErr("%s: invalid URL %q: %v", function, string(s), err)
+	}
+	return u, nil
+}
+
+func urlIsValid(val ref.Val) ref.Val {
+	s, ok := val.(types.String)
+	if !ok {
+		return types.NewErr("url.isValid argument must be a string")
+	}
+	u, err := url.Parse(string(s))
+	return types.Bool(err == nil && u.IsAbs() && u.Host != "")
+}
+
+func urlQuery(val, key ref.Val) ref.Val {
+	u, errVal := parseURL("url.query", val)
+	if errVal != nil {
+		return errVal
+	}
+	k, ok := key.(types.String)
+	if !ok {
+		return types.NewErr("url.query key must be a string")
+	}
+	return types.String(u.Query().Get(string(k)))
+}
+
+func urlJoin(val, path ref.Val) ref.Val {
+	u, errVal := parseURL("url.join", val)
+	if errVal != nil {
+		return errVal
+	}
+	p, ok := path.(types.String)
+	if !ok {
+		return types.NewErr("url.join path must be a string")
+	}
+	return types.String(u.JoinPath(string(p)).String())
+}
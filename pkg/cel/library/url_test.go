@@ -0,0 +1,40 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package library
+
+import (
+	"testing"
+)
+
+func TestURL(t *testing.T) {
+	const u = "'https://db.example.com:5432/app/data?sslmode=require&user=admin'"
+	runLibraryTests(t, URL(), []libraryTestCase{
+		{name: "valid url", expr: "url.isValid(" + u + ")", want: true},
+		{name: "relative url", expr: "url.isValid('/app/data')", want: false},
+		{name: "scheme", expr: "url.scheme(" + u + ")", want: "https"},
+		{name: "host", expr: "url.host(" + u + ")", want: "db.example.com:5432"},
+		{name: "hostname", expr: "url.hostname(" + u + ")", want: "db.example.com"},
+		{name: "port", expr: "url.port(" + u + ")", want: "5432"},
+		{name: "path", expr: "url.path(" + u + ")", want: "/app/data"},
+		{name: "query", expr: "url.query(" + u + ", 'sslmode')", want: "require"},
+		{name: "missing query", expr: "url.query(" + u + ", 'password')", want: ""},
+		{name: "join", expr: "url.join('https://example.com/api/', 'v1/users')", want: "https://example.com/api/v1/users"},
+		{
+			name:    "invalid url",
+			expr:    "url.host('http://[::1')",
+			wantErr: "url.host: invalid URL",
+		},
+	})
+}
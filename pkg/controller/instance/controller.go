@@ -26,6 +26,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	ctrl "sigs.k8s.io/controller-runtime"
 
+	"github.com/kubernetes-sigs/kro/pkg/applyset"
 	kroclient "github.com/kubernetes-sigs/kro/pkg/client"
 	"github.com/kubernetes-sigs/kro/pkg/graph"
 	"github.com/kubernetes-sigs/kro/pkg/metadata"
@@ -38,13 +39,16 @@ type ReconcileConfig struct {
 	// a reconciliation if no specific requeue time is set.
 	DefaultRequeueDuration time.Duration
 	// DeletionGraceTimeDuration is the duration to wait after initializing a resource
-	// deletion before considering it failed
-	// Not implemented.
+	// deletion before considering it failed. The resources depending on it keep
+	// waiting for its deletion, which is reported in the instance status.
 	DeletionGraceTimeDuration time.Duration
 	// DeletionPolicy is the deletion policy to use when deleting resources in the graph
 	// TODO(a-hilaly): need to define think the different deletion policies we need to
 	// support.
 	DeletionPolicy string
+	// ResourceConcurrency is the maximum number of resources of an instance
+	// processed, and applied, in parallel. Zero means no limit.
+	ResourceConcurrency int
 }
 
 // Controller manages the reconciliation of a single instance of a ResourceGraphDefinition,
@@ -84,6 +88,10 @@ type Controller struct {
 	// reconcileConfig holds the configuration parameters for the reconciliation
 	// process.
 	reconcileConfig ReconcileConfig
+	// objectReader reads the sub-resources from a cache. When it is set,
+	// reconciles are incremental: the sub-resources whose rendered content
+	// didn't change since they were last applied are not applied again.
+	objectReader applyset.ObjectReader
 }
 
 // NewController creates a new Controller instance.
@@ -95,6 +103,7 @@ func NewController(
 	clientSet kroclient.SetInterface,
 	restMapper meta.RESTMapper,
 	instanceLabeler metadata.Labeler,
+	objectReader applyset.ObjectReader,
 ) *Controller {
 	return &Controller{
 		log:             log,
@@ -103,6 +112,7 @@ func NewController(
 		rgd:             rgd,
 		instanceLabeler: instanceLabeler,
 		reconcileConfig: reconcileConfig,
+		objectReader:    objectReader,
 	}
 }
 
@@ -141,9 +151,11 @@ func (c *Controller) Reconcile(ctx context.Context, req ctrl.Request) error {
 		client:                      c.clientSet.Dynamic(),
 		restMapper:                  c.clientSet.RESTMapper(),
 		runtime:                     rgRuntime,
+		dag:                         c.rgd.DAG,
 		instanceLabeler:             c.instanceLabeler,
 		instanceSubResourcesLabeler: instanceSubResourcesLabeler,
 		reconcileConfig:             c.reconcileConfig,
+		objectReader:                c.objectReader,
 		// Fresh instance state at each reconciliation loop.
 		state: newInstanceState(),
 	}
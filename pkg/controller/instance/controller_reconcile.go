@@ -17,6 +17,8 @@ package instance
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -29,7 +31,9 @@ import (
 
 	"sigs.k8s.io/release-utils/version"
 
+	"github.com/kubernetes-sigs/kro/api/v1alpha1"
 	"github.com/kubernetes-sigs/kro/pkg/applyset"
+	"github.com/kubernetes-sigs/kro/pkg/graph/dag"
 	"github.com/kubernetes-sigs/kro/pkg/metadata"
 	"github.com/kubernetes-sigs/kro/pkg/requeue"
 	"github.com/kubernetes-sigs/kro/pkg/runtime"
@@ -77,6 +81,9 @@ type instanceGraphReconciler struct {
 	// information about the instance and its sub-resources, the CEL expressions
 	// their dependencies, and the resolved values... etc
 	runtime runtime.Interface
+	// dag is the dependency graph of the sub-resources. It is used to process
+	// the independent sub-resources in parallel.
+	dag *dag.DirectedAcyclicGraph[string]
 	// instanceLabeler is responsible for applying labels to the instance object
 	instanceLabeler metadata.Labeler
 	// instanceSubResourcesLabeler is responsible for applying labels to the
@@ -87,6 +94,9 @@ type instanceGraphReconciler struct {
 	reconcileConfig ReconcileConfig
 	// state holds the current state of the instance and its sub-resources.
 	state *InstanceState
+	// objectReader reads the sub-resources from a cache, if reconciles are
+	// incremental.
+	objectReader applyset.ObjectReader
 }
 
 // reconcile performs the reconciliation of the instance and its sub-resources.
@@ -160,6 +170,11 @@ func (igr *instanceGraphReconciler) reconcileInstance(ctx context.Context) error
 		FieldManager: FieldManagerForApplyset,
 		ToolingID:    KROTooling,
 		Log:          igr.log,
+		Concurrency:  igr.reconcileConfig.ResourceConcurrency,
+	}
+	if igr.objectReader != nil {
+		config.Reader = igr.objectReader
+		config.HashAnnotation = metadata.AppliedHashAnnotation
 	}
 
 	aset, err := applyset.New(instance, igr.restMapper, igr.client, config)
@@ -167,57 +182,17 @@ func (igr *instanceGraphReconciler) reconcileInstance(ctx context.Context) error
 		return igr.delayedRequeue(fmt.Errorf("failed creating an applyset: %w", err))
 	}
 
-	unresolvedResourceID := ""
-	prune := true
-	// Reconcile resources in topological order
-	for _, resourceID := range igr.runtime.TopologicalOrder() {
-		log := igr.log.WithValues("resourceID", resourceID)
-
-		// Initialize resource state in instance state
-		resourceState := &ResourceState{State: ResourceStateInProgress}
-		igr.state.ResourceStates[resourceID] = resourceState
-
-		// Check if resource should be processed (create or get)
-		// TODO(barney-s): skipping on error seems un-intuitive, should we skip on CEL evaluation error?
-		if want, err := igr.runtime.ReadyToProcessResource(resourceID); err != nil || !want {
-			log.V(1).Info("Skipping resource processing", "reason", err)
-			resourceState.State = ResourceStateSkipped
-			igr.runtime.IgnoreResource(resourceID)
-			continue
-		}
-
-		// Check if the resource dependencies are resolved and can be reconciled
-		resource, state := igr.runtime.GetResource(resourceID)
-
-		if state != runtime.ResourceStateResolved {
-			unresolvedResourceID = resourceID
-			prune = false
-			break
-		}
-
-		applyable := applyset.ApplyableObject{
-			Unstructured: resource,
-			ID:           resourceID,
-			ExternalRef:  igr.runtime.ResourceDescriptor(resourceID).IsExternalRef(),
-		}
-		clusterObj, err := aset.Add(ctx, applyable)
-		if err != nil {
-			return fmt.Errorf("failed to add resource to applyset: %w", err)
-		}
-
-		if clusterObj != nil {
-			igr.runtime.SetResource(resourceID, clusterObj)
-			igr.updateResourceReadiness(resourceID)
-			// Synchronize runtime state after each resource
-			if _, err := igr.runtime.Synchronize(); err != nil {
-				return fmt.Errorf("failed to synchronize after apply/prune: %w", err)
-			}
-		}
+	unresolvedResourceIDs, err := igr.addResources(ctx, aset)
+	if err != nil {
+		return err
 	}
+	// Pruning is only safe once every resource is part of the applyset.
+	prune := len(unresolvedResourceIDs) == 0
 
 	result, err := aset.Apply(ctx, prune)
 	for _, applied := range result.AppliedObjects {
 		resourceState := igr.state.ResourceStates[applied.ID]
+		resourceState.UpdateMessage = applied.Message
 		if applied.Error != nil {
 			resourceState.State = ResourceStateError
 			resourceState.Err = applied.Error
@@ -239,8 +214,8 @@ func (igr *instanceGraphReconciler) reconcileInstance(ctx context.Context) error
 		return fmt.Errorf("failed to apply/prune resources: %w", err)
 	}
 
-	if unresolvedResourceID != "" {
-		return igr.delayedRequeue(fmt.Errorf("unresolved resource: %s", unresolvedResourceID))
+	if len(unresolvedResourceIDs) > 0 {
+		return igr.delayedRequeue(fmt.Errorf("unresolved resources: %s", strings.Join(unresolvedResourceIDs, ", ")))
 	}
 
 	// If there are any cluster mutations, we need to requeue.
@@ -251,6 +226,124 @@ func (igr *instanceGraphReconciler) reconcileInstance(ctx context.Context) error
 	return nil
 }
 
+// addResult is the result of adding a resource to the applyset.
+type addResult struct {
+	resourceID string
+	observed   *unstructured.Unstructured
+	err        error
+}
+
+// addResources adds the resources to the applyset, reading their state from
+// the cluster. A resource is processed as soon as its dependencies are, so the
+// independent branches of the graph are processed in parallel, up to the
+// configured resource concurrency. An unresolved resource only blocks its
+// dependents, whose processing is deferred to a later reconcile.
+//
+// The runtime is not safe for concurrent use, it is only accessed by the
+// calling goroutine. It returns the ids of the unresolved resources.
+func (igr *instanceGraphReconciler) addResources(ctx context.Context, aset applyset.Set) ([]string, error) {
+	frontier := igr.dag.NewFrontier()
+	results := make(chan addResult)
+	limit := igr.reconcileConfig.ResourceConcurrency
+
+	var queue, unresolved []string
+	var addErr error
+	inFlight := 0
+	for {
+		if addErr == nil {
+			queue = append(queue, frontier.Next()...)
+		}
+		for addErr == nil && len(queue) > 0 && (limit <= 0 || inFlight < limit) {
+			resourceID := queue[0]
+			queue = queue[1:]
+
+			applyable, ok := igr.prepareResource(resourceID)
+			if applyable == nil {
+				if ok {
+					// Skipped resources don't block their dependents, which
+					// are skipped as well.
+					frontier.Done(resourceID)
+					queue = append(queue, frontier.Next()...)
+				} else {
+					unresolved = append(unresolved, resourceID)
+				}
+				continue
+			}
+
+			inFlight++
+			go func() {
+				observed, err := aset.Add(ctx, *applyable)
+				results <- addResult{resourceID: resourceID, observed: observed, err: err}
+			}()
+		}
+		if inFlight == 0 {
+			break
+		}
+
+		result := <-results
+		inFlight--
+		if addErr != nil {
+			continue
+		}
+		if result.err != nil {
+			addErr = fmt.Errorf("failed to add resource %s to applyset: %w", result.resourceID, result.err)
+			continue
+		}
+		if result.observed != nil {
+			igr.runtime.SetResource(result.resourceID, result.observed)
+			igr.updateResourceReadiness(result.resourceID)
+			// Synchronize runtime state after each resource
+			if _, err := igr.runtime.Synchronize(); err != nil {
+				addErr = fmt.Errorf("failed to synchronize after apply/prune: %w", err)
+				continue
+			}
+		}
+		frontier.Done(result.resourceID)
+	}
+	return unresolved, addErr
+}
+
+// prepareResource returns the object of a resource to add to the applyset.
+// It returns nil and true if the resource is skipped, and nil and false if it
+// is not resolved yet.
+func (igr *instanceGraphReconciler) prepareResource(resourceID string) (*applyset.ApplyableObject, bool) {
+	log := igr.log.WithValues("resourceID", resourceID)
+
+	// Initialize resource state in instance state
+	resourceState := &ResourceState{State: ResourceStateInProgress}
+	igr.state.ResourceStates[resourceID] = resourceState
+
+	// Check if resource should be processed (create or get)
+	// TODO(barney-s): skipping on error seems un-intuitive, should we skip on CEL evaluation error?
+	if want, err := igr.runtime.ReadyToProcessResource(resourceID); err != nil || !want {
+		log.V(1).Info("Skipping resource processing", "reason", err)
+		resourceState.State = ResourceStateSkipped
+		igr.runtime.IgnoreResource(resourceID)
+		return nil, true
+	}
+
+	// Check if the resource dependencies are resolved and can be reconciled
+	resource, state := igr.runtime.GetResource(resourceID)
+	if state != runtime.ResourceStateResolved {
+		resourceState.State = ResourceStatePending
+		return nil, false
+	}
+
+	// The runtime keeps resolving the resource while it is added, the
+	// applyset gets its own copy.
+	descriptor := igr.runtime.ResourceDescriptor(resourceID)
+	resourceState.UpdateStrategy = descriptor.GetUpdateStrategy()
+	return &applyset.ApplyableObject{
+		Unstructured:    &unstructured.Unstructured{Object: runtime.DeepCopyObject(resource.Object)},
+		ID:              resourceID,
+		ExternalRef:     descriptor.IsExternalRef(),
+		CreateOnly:      resourceState.UpdateStrategy == v1alpha1.UpdateStrategyCreateOnly,
+		Recreate:        resourceState.UpdateStrategy == v1alpha1.UpdateStrategyRecreate,
+		IgnoreFields:    descriptor.GetIgnoreFields(),
+		RejectConflicts: !descriptor.IsForceApply(),
+	}, true
+}
+
 // setupInstance prepares an instance for reconciliation by setting up necessary
 // labels and managed state.
 func (igr *instanceGraphReconciler) setupInstance(ctx context.Context, instance *unstructured.Unstructured) error {
@@ -320,10 +413,20 @@ func (igr *instanceGraphReconciler) initializeDeletionState() error {
 	return nil
 }
 
-// deleteResourcesInOrder processes resource deletion in reverse topological order
-// to respect dependencies between resources.
+// deleteResourcesInOrder processes resource deletion on the reverse dependency
+// graph. A resource is deleted once all the resources depending on it are gone,
+// so every resource without remaining dependents is deleted in the same
+// reconcile, and its dependencies wait until it is actually removed.
 func (igr *instanceGraphReconciler) deleteResourcesInOrder(ctx context.Context) error {
-	// Process resources in reverse order
+	dependents := make(map[string][]string, len(igr.dag.Vertices))
+	for id, vertex := range igr.dag.Vertices {
+		for dependency := range vertex.DependsOn {
+			dependents[dependency] = append(dependents[dependency], id)
+		}
+	}
+
+	// Dependents come first in the reverse topological order, so a dependency
+	// sees the state they reach in this pass.
 	resources := igr.runtime.TopologicalOrder()
 	for i := len(resources) - 1; i >= 0; i-- {
 		resourceID := resources[i]
@@ -339,6 +442,10 @@ func (igr *instanceGraphReconciler) deleteResourcesInOrder(ctx context.Context)
 			continue
 		}
 
+		if !igr.dependentsDeleted(dependents[resourceID]) {
+			continue
+		}
+
 		if err := igr.deleteResource(ctx, resourceID); err != nil {
 			return err
 		}
@@ -346,15 +453,47 @@ func (igr *instanceGraphReconciler) deleteResourcesInOrder(ctx context.Context)
 	return nil
 }
 
+// dependentsDeleted returns true if none of the given resources exists anymore.
+func (igr *instanceGraphReconciler) dependentsDeleted(dependents []string) bool {
+	for _, dependent := range dependents {
+		resourceState := igr.state.ResourceStates[dependent]
+		if resourceState != nil &&
+			resourceState.State != ResourceStateDeleted && resourceState.State != ResourceStateSkipped {
+			return false
+		}
+	}
+	return true
+}
+
 // deleteResource handles the deletion of a single resource and updates its state.
+// A resource already being deleted isn't deleted again, it waits for its
+// finalizers until the deletion grace time is exceeded and it is reported as
+// failed.
 func (igr *instanceGraphReconciler) deleteResource(ctx context.Context, resourceID string) error {
-	igr.log.V(1).Info("Deleting resource", "resourceID", resourceID)
-
 	resource, _ := igr.runtime.GetResource(resourceID)
+	resourceState := igr.state.ResourceStates[resourceID]
+
+	if deletionTimestamp := resource.GetDeletionTimestamp(); deletionTimestamp != nil {
+		resourceState.State = InstanceStateDeleting
+		grace := igr.reconcileConfig.DeletionGraceTimeDuration
+		if grace > 0 && time.Since(deletionTimestamp.Time) > grace {
+			resourceState.State = ResourceStateError
+			resourceState.Err = fmt.Errorf("deletion of resource %s timed out after %s, waiting on finalizers %v",
+				resourceID, grace, resource.GetFinalizers())
+		}
+		return nil
+	}
+
+	igr.log.V(1).Info("Deleting resource", "resourceID", resourceID)
 	rc := igr.getResourceClient(resourceID)
 
-	// Attempt to delete the resource
-	err := rc.Delete(ctx, resource.GetName(), metav1.DeleteOptions{})
+	// Attempt to delete the resource. With the Foreground policy, the resource
+	// is only gone, and its dependencies deleted, once its own dependents are.
+	options := metav1.DeleteOptions{}
+	if policy := igr.runtime.ResourceDescriptor(resourceID).GetPropagationPolicy(); policy != "" {
+		options.PropagationPolicy = &policy
+	}
+	err := rc.Delete(ctx, resource.GetName(), options)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			igr.state.ResourceStates[resourceID].State = ResourceStateDeleted
@@ -366,7 +505,7 @@ func (igr *instanceGraphReconciler) deleteResource(ctx context.Context, resource
 	}
 
 	igr.state.ResourceStates[resourceID].State = InstanceStateDeleting
-	return igr.delayedRequeue(fmt.Errorf("resource deletion in progress"))
+	return nil
 }
 
 // getResourceClient returns the appropriate dynamic client and namespace for a resource
@@ -387,6 +526,9 @@ func (igr *instanceGraphReconciler) finalizeDeletion(ctx context.Context) error
 	// Check if all resources are deleted
 	for _, resourceState := range igr.state.ResourceStates {
 		if resourceState.State != ResourceStateDeleted && resourceState.State != ResourceStateSkipped {
+			if err := igr.state.ResourceErrors(); err != nil {
+				return igr.delayedRequeue(fmt.Errorf("waiting for resource deletion completion: %w", err))
+			}
 			return igr.delayedRequeue(fmt.Errorf("waiting for resource deletion completion"))
 		}
 	}
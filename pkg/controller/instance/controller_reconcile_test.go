@@ -0,0 +1,301 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+
+	"github.com/kubernetes-sigs/kro/api/v1alpha1"
+	"github.com/kubernetes-sigs/kro/pkg/applyset"
+	"github.com/kubernetes-sigs/kro/pkg/graph/dag"
+	"github.com/kubernetes-sigs/kro/pkg/runtime"
+)
+
+var configMapGVK = schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+
+func configMap(name string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	obj.SetGroupVersionKind(configMapGVK)
+	obj.SetNamespace("default")
+	obj.SetName(name)
+	return obj
+}
+
+// fakeDescriptor describes the resources of fakeRuntime.
+type fakeDescriptor struct {
+	runtime.ResourceDescriptor
+}
+
+func (fakeDescriptor) GetUpdateStrategy() v1alpha1.UpdateStrategy { return "" }
+func (fakeDescriptor) IsExternalRef() bool                        { return false }
+func (fakeDescriptor) GetIgnoreFields() []string                  { return nil }
+func (fakeDescriptor) IsForceApply() bool                         { return false }
+
+// fakeRuntime resolves every resource except the unresolved ones. It has no
+// locking on purpose, so the race detector reports any concurrent access from
+// the scheduler.
+type fakeRuntime struct {
+	runtime.Interface
+	unresolved map[string]bool
+	set        []string
+}
+
+func (r *fakeRuntime) ReadyToProcessResource(string) (bool, error) { return true, nil }
+func (r *fakeRuntime) IgnoreResource(string)                       {}
+func (r *fakeRuntime) IsResourceReady(string) (bool, string, error) {
+	return true, "", nil
+}
+func (r *fakeRuntime) Synchronize() (bool, error) { return false, nil }
+
+func (r *fakeRuntime) GetResource(resourceID string) (*unstructured.Unstructured, runtime.ResourceState) {
+	if r.unresolved[resourceID] {
+		return nil, runtime.ResourceStateWaitingOnDependencies
+	}
+	return configMap(resourceID), runtime.ResourceStateResolved
+}
+
+func (r *fakeRuntime) ResourceDescriptor(string) runtime.ResourceDescriptor {
+	return fakeDescriptor{}
+}
+
+func (r *fakeRuntime) SetResource(resourceID string, _ *unstructured.Unstructured) {
+	r.set = append(r.set, resourceID)
+}
+
+// fakeApplySet records the resources added to it and the maximum number of
+// concurrent Add calls. Adds take delay, and fail with the error of their
+// resource, if any.
+type fakeApplySet struct {
+	applyset.Set
+	delay map[string]time.Duration
+	errs  map[string]error
+
+	mu          sync.Mutex
+	inFlight    int
+	maxInFlight int
+	added       []string
+}
+
+func (s *fakeApplySet) Add(_ context.Context, obj applyset.ApplyableObject) (*unstructured.Unstructured, error) {
+	s.mu.Lock()
+	s.inFlight++
+	s.maxInFlight = max(s.maxInFlight, s.inFlight)
+	s.mu.Unlock()
+
+	time.Sleep(s.delay[obj.ID])
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.inFlight--
+	s.added = append(s.added, obj.ID)
+	if err := s.errs[obj.ID]; err != nil {
+		return nil, err
+	}
+	return obj.Unstructured, nil
+}
+
+// newTestReconciler returns a reconciler of the graph whose resources have the
+// given dependencies.
+func newTestReconciler(
+	t *testing.T,
+	rt runtime.Interface,
+	concurrency int,
+	dependencies map[string][]string,
+) *instanceGraphReconciler {
+	ids := make([]string, 0, len(dependencies))
+	for id := range dependencies {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	d := dag.NewDirectedAcyclicGraph[string]()
+	for i, id := range ids {
+		require.NoError(t, d.AddVertex(id, i))
+	}
+	for _, id := range ids {
+		require.NoError(t, d.AddDependencies(id, dependencies[id]))
+	}
+
+	return &instanceGraphReconciler{
+		log:             logr.Discard(),
+		runtime:         rt,
+		dag:             d,
+		reconcileConfig: ReconcileConfig{ResourceConcurrency: concurrency},
+		state:           newInstanceState(),
+	}
+}
+
+// addResources runs the scheduler, failing the test if it doesn't return.
+func addResources(t *testing.T, igr *instanceGraphReconciler, aset applyset.Set) ([]string, error) {
+	type result struct {
+		unresolved []string
+		err        error
+	}
+	done := make(chan result, 1)
+	go func() {
+		unresolved, err := igr.addResources(context.Background(), aset)
+		done <- result{unresolved: unresolved, err: err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.unresolved, r.err
+	case <-time.After(10 * time.Second):
+		t.Fatal("addResources did not return")
+		return nil, nil
+	}
+}
+
+func TestAddResources_ConcurrencyBound(t *testing.T) {
+	dependencies := map[string][]string{}
+	delay := map[string]time.Duration{}
+	for i := 0; i < 8; i++ {
+		id := fmt.Sprintf("cm%d", i)
+		dependencies[id] = nil
+		delay[id] = 20 * time.Millisecond
+	}
+
+	tests := []struct {
+		name        string
+		concurrency int
+		wantMax     int
+	}{
+		{name: "bounded", concurrency: 3, wantMax: 3},
+		{name: "sequential", concurrency: 1, wantMax: 1},
+		{name: "unbounded", concurrency: 0, wantMax: 8},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rt := &fakeRuntime{}
+			igr := newTestReconciler(t, rt, tt.concurrency, dependencies)
+			aset := &fakeApplySet{delay: delay}
+
+			unresolved, err := addResources(t, igr, aset)
+			require.NoError(t, err)
+			assert.Empty(t, unresolved)
+			assert.Len(t, aset.added, 8)
+			assert.Len(t, rt.set, 8)
+			assert.Equal(t, tt.wantMax, aset.maxInFlight)
+			for id := range dependencies {
+				assert.Equal(t, ResourceStateSynced, igr.state.ResourceStates[id].State)
+			}
+		})
+	}
+}
+
+func TestAddResources_UnresolvedBlocksOnlyDescendants(t *testing.T) {
+	// vpc <- subnet <- cluster, and bucket <- policy on an independent branch.
+	dependencies := map[string][]string{
+		"vpc":     nil,
+		"subnet":  {"vpc"},
+		"cluster": {"subnet"},
+		"bucket":  nil,
+		"policy":  {"bucket"},
+	}
+	rt := &fakeRuntime{unresolved: map[string]bool{"subnet": true}}
+	igr := newTestReconciler(t, rt, 2, dependencies)
+	aset := &fakeApplySet{}
+
+	unresolved, err := addResources(t, igr, aset)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"subnet"}, unresolved)
+	assert.ElementsMatch(t, []string{"vpc", "bucket", "policy"}, aset.added)
+
+	assert.Equal(t, ResourceStatePending, igr.state.ResourceStates["subnet"].State)
+	assert.NotContains(t, igr.state.ResourceStates, "cluster")
+	for _, id := range []string{"vpc", "bucket", "policy"} {
+		assert.Equal(t, ResourceStateSynced, igr.state.ResourceStates[id].State)
+	}
+}
+
+func TestAddResources_FirstErrorDrainsInFlight(t *testing.T) {
+	dependencies := map[string][]string{
+		"a":  nil,
+		"b":  nil,
+		"c":  nil,
+		"b1": {"b"},
+		"c1": {"c"},
+	}
+	rt := &fakeRuntime{}
+	igr := newTestReconciler(t, rt, 3, dependencies)
+	aset := &fakeApplySet{
+		delay: map[string]time.Duration{"b": 50 * time.Millisecond, "c": 50 * time.Millisecond},
+		errs:  map[string]error{"a": errors.New("boom"), "b": errors.New("late")},
+	}
+
+	_, err := addResources(t, igr, aset)
+	require.Error(t, err)
+	// Only the first error is reported.
+	assert.Contains(t, err.Error(), "failed to add resource a to applyset: boom")
+
+	// The in-flight adds finished before returning, and nothing was scheduled
+	// after the error.
+	assert.Equal(t, 0, aset.inFlight)
+	assert.ElementsMatch(t, []string{"a", "b", "c"}, aset.added)
+	// The results drained after the error aren't applied to the runtime.
+	assert.Empty(t, rt.set)
+}
+
+func TestAddResources_SharedApplySet(t *testing.T) {
+	testScheme := k8sruntime.NewScheme()
+	testScheme.AddKnownTypes(configMapGVK.GroupVersion(), &unstructured.Unstructured{})
+	restMapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{configMapGVK.GroupVersion()})
+	restMapper.Add(configMapGVK, meta.RESTScopeNamespace)
+
+	parent := configMap("parent")
+	existing := []k8sruntime.Object{parent}
+	dependencies := map[string][]string{}
+	for i := 0; i < 32; i++ {
+		id := fmt.Sprintf("cm%d", i)
+		dependencies[id] = nil
+		// Half of the resources already exist, so their adds read them back.
+		if i%2 == 0 {
+			existing = append(existing, configMap(id))
+		}
+	}
+	dynamicClient := fake.NewSimpleDynamicClientWithCustomListKinds(testScheme,
+		map[schema.GroupVersionResource]string{configMapGVK.GroupVersion().WithResource("configmaps"): "ConfigMapList"},
+		existing...)
+	aset, err := applyset.New(parent, restMapper, dynamicClient, applyset.Config{
+		ToolingID:    applyset.ToolingID{Name: "test", Version: "v1"},
+		FieldManager: "test-manager",
+		Log:          logr.Discard(),
+	})
+	require.NoError(t, err)
+
+	rt := &fakeRuntime{}
+	igr := newTestReconciler(t, rt, 8, dependencies)
+
+	unresolved, err := addResources(t, igr, aset)
+	require.NoError(t, err)
+	assert.Empty(t, unresolved)
+	// Only the existing resources are observed.
+	assert.Len(t, rt.set, 16)
+}
@@ -17,6 +17,7 @@ package instance
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -93,9 +94,39 @@ func (igr *instanceGraphReconciler) prepareConditions(
 		))
 	}
 
+	// Report the resources updated with another strategy than Apply
+	if message := igr.updateStrategiesMessage(); message != "" {
+		conditions = append(conditions, createCondition(
+			"ResourcesUpdated",
+			corev1.ConditionTrue,
+			"UpdateStrategiesApplied",
+			message,
+			generation,
+		))
+	}
+
 	return conditions
 }
 
+// updateStrategiesMessage describes the update strategies of the resources
+// that aren't simply applied, in topological order.
+func (igr *instanceGraphReconciler) updateStrategiesMessage() string {
+	var entries []string
+	for _, resourceID := range igr.runtime.TopologicalOrder() {
+		resourceState := igr.state.ResourceStates[resourceID]
+		if resourceState == nil || resourceState.UpdateStrategy == "" ||
+			resourceState.UpdateStrategy == v1alpha1.UpdateStrategyApply {
+			continue
+		}
+		entry := fmt.Sprintf("%s: %s", resourceID, resourceState.UpdateStrategy)
+		if resourceState.UpdateMessage != "" {
+			entry = fmt.Sprintf("%s (%s)", entry, resourceState.UpdateMessage)
+		}
+		entries = append(entries, entry)
+	}
+	return strings.Join(entries, ", ")
+}
+
 // patchInstanceStatus updates the status subresource of the instance.
 func (igr *instanceGraphReconciler) patchInstanceStatus(ctx context.Context, status map[string]interface{}) error {
 	instance := igr.runtime.GetInstance().DeepCopy()
@@ -14,20 +14,24 @@
 
 package instance
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/kubernetes-sigs/kro/api/v1alpha1"
+)
 
 const (
-	InstanceStateInProgress = "IN_PROGRESS"
-	InstanceStateFailed     = "FAILED"
-	InstanceStateActive     = "ACTIVE"
-	InstanceStateDeleting   = "DELETING"
-	InstanceStateError      = "ERROR"
+	InstanceStateInProgress = v1alpha1.InstanceStateInProgress
+	InstanceStateFailed     = v1alpha1.InstanceStateFailed
+	InstanceStateActive     = v1alpha1.InstanceStateActive
+	InstanceStateDeleting   = v1alpha1.InstanceStateDeleting
+	InstanceStateError      = v1alpha1.InstanceStateError
 )
 
 // newInstanceState creates a new InstanceState with initialized fields
 func newInstanceState() *InstanceState {
 	return &InstanceState{
-		State:          "IN_PROGRESS",
+		State:          InstanceStateInProgress,
 		ResourceStates: make(map[string]*ResourceState),
 	}
 }
@@ -39,6 +43,11 @@ type ResourceState struct {
 	State string
 	// Err captures any error associated with the current state
 	Err error
+	// UpdateStrategy is the strategy used to update the resource
+	UpdateStrategy v1alpha1.UpdateStrategy
+	// UpdateMessage describes what the update strategy did, when it did more
+	// than applying the resource
+	UpdateMessage string
 }
 
 // InstanceState tracks the overall state of resources being managed
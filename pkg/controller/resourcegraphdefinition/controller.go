@@ -20,6 +20,7 @@ import (
 
 	"github.com/go-logr/logr"
 	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -29,6 +30,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"github.com/kubernetes-sigs/kro/api/v1alpha1"
 	kroclient "github.com/kubernetes-sigs/kro/pkg/client"
@@ -40,6 +42,7 @@ import (
 //+kubebuilder:rbac:groups=kro.run,resources=resourcegraphdefinitions,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=kro.run,resources=resourcegraphdefinitions/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=kro.run,resources=resourcegraphdefinitions/finalizers,verbs=update
+//+kubebuilder:rbac:groups=kro.run,resources=typelibraries,verbs=get;list;watch
 
 // ResourceGraphDefinitionReconciler reconciles a ResourceGraphDefinition object
 type ResourceGraphDefinitionReconciler struct {
@@ -58,6 +61,16 @@ type ResourceGraphDefinitionReconciler struct {
 	rgBuilder               *graph.Builder
 	dynamicController       *dynamiccontroller.DynamicController
 	maxConcurrentReconciles int
+	// resourceConcurrency is the maximum number of sub-resources of an
+	// instance processed in parallel.
+	resourceConcurrency int
+	// objectCache serves the sub-resources of the instances when reconciles
+	// are incremental, it is nil otherwise.
+	objectCache *dynamiccontroller.ObjectCache
+	// rgdEvents receives the resource graph definitions to reconcile again
+	// because of changes outside of them, like the resource graph definitions
+	// they depend on becoming active.
+	rgdEvents chan event.GenericEvent
 }
 
 func NewResourceGraphDefinitionReconciler(
@@ -66,6 +79,8 @@ func NewResourceGraphDefinitionReconciler(
 	dynamicController *dynamiccontroller.DynamicController,
 	builder *graph.Builder,
 	maxConcurrentReconciles int,
+	resourceConcurrency int,
+	objectCache *dynamiccontroller.ObjectCache,
 ) *ResourceGraphDefinitionReconciler {
 	crdWrapper := clientSet.CRD(kroclient.CRDWrapperConfig{})
 
@@ -77,15 +92,56 @@ func NewResourceGraphDefinitionReconciler(
 		metadataLabeler:         metadata.NewKROMetaLabeler(),
 		rgBuilder:               builder,
 		maxConcurrentReconciles: maxConcurrentReconciles,
+		resourceConcurrency:     resourceConcurrency,
+		objectCache:             objectCache,
+		rgdEvents:               make(chan event.GenericEvent, rgdEventsBufferSize),
 	}
 }
 
+// rgdEventsBufferSize is the number of resource graph definitions that can be
+// waiting to be enqueued again.
+const rgdEventsBufferSize = 1024
+
+// EnqueueResourceGraphDefinitions enqueues the given resource graph
+// definitions to be reconciled again.
+func (r *ResourceGraphDefinitionReconciler) EnqueueResourceGraphDefinitions(rgdNames []string) {
+	for _, name := range rgdNames {
+		r.rgdEvents <- event.GenericEvent{
+			Object: &v1alpha1.ResourceGraphDefinition{ObjectMeta: metav1.ObjectMeta{Name: name}},
+		}
+	}
+}
+
+// enqueueGlobalConfigUsers enqueues the resource graph definitions whose
+// expressions use the global configuration to be reconciled again.
+func (r *ResourceGraphDefinitionReconciler) enqueueGlobalConfigUsers() {
+	var rgds v1alpha1.ResourceGraphDefinitionList
+	if err := r.List(context.Background(), &rgds); err != nil {
+		r.instanceLogger.Error(err, "failed to list resourcegraphdefinitions using the global configuration")
+		return
+	}
+	var names []string
+	for i := range rgds.Items {
+		if graph.UsesGlobalConfig(&rgds.Items[i]) {
+			names = append(names, rgds.Items[i].Name)
+		}
+	}
+	r.EnqueueResourceGraphDefinitions(names)
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *ResourceGraphDefinitionReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	r.Client = mgr.GetClient()
 	r.clientSet.SetRESTMapper(mgr.GetRESTMapper())
 	r.instanceLogger = mgr.GetLogger()
 
+	// The resource graph definitions using the instance kind of another one are
+	// built again when it becomes active, changes or goes away.
+	r.rgBuilder.Registry().OnChange(r.EnqueueResourceGraphDefinitions)
+	// The ones using the global configuration are built again when it
+	// changes, since they are validated against it.
+	r.rgBuilder.GlobalConfig().OnChange(r.enqueueGlobalConfigUsers)
+
 	logConstructor := func(req *reconcile.Request) logr.Logger {
 		log := mgr.GetLogger().WithName("rgd-controller").WithValues(
 			"controller", "ResourceGraphDefinition",
@@ -123,6 +179,11 @@ func (r *ResourceGraphDefinitionReconciler) SetupWithManager(mgr ctrl.Manager) e
 				},
 			}),
 		).
+		Watches(
+			&v1alpha1.TypeLibrary{},
+			handler.EnqueueRequestsFromMapFunc(r.findRGDsForTypeLibrary),
+		).
+		WatchesRawSource(source.Channel(r.rgdEvents, &handler.EnqueueRequestForObject{})).
 		Complete(reconcile.AsReconciler[*v1alpha1.ResourceGraphDefinition](mgr.GetClient(), r))
 }
 
@@ -154,6 +215,38 @@ func (r *ResourceGraphDefinitionReconciler) findRGDsForCRD(ctx context.Context,
 	}
 }
 
+// findRGDsForTypeLibrary returns a list of reconcile requests for the
+// ResourceGraphDefinitions importing the given TypeLibrary. It is used to
+// rebuild the RGDs when the types they import change.
+func (r *ResourceGraphDefinitionReconciler) findRGDsForTypeLibrary(ctx context.Context, obj client.Object) []reconcile.Request {
+	library, ok := obj.(*v1alpha1.TypeLibrary)
+	if !ok {
+		return nil
+	}
+
+	var rgds v1alpha1.ResourceGraphDefinitionList
+	if err := r.List(ctx, &rgds); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "failed to list resource graph definitions", "typeLibrary", library.Name)
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, rgd := range rgds.Items {
+		if rgd.Spec.Schema == nil {
+			continue
+		}
+		for _, typeImport := range rgd.Spec.Schema.Imports {
+			if typeImport.Name == library.Name {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: types.NamespacedName{Name: rgd.Name},
+				})
+				break
+			}
+		}
+	}
+	return requests
+}
+
 func (r *ResourceGraphDefinitionReconciler) Reconcile(ctx context.Context, o *v1alpha1.ResourceGraphDefinition) (ctrl.Result, error) {
 	if !o.DeletionTimestamp.IsZero() {
 		if err := r.cleanupResourceGraphDefinition(ctx, o); err != nil {
@@ -171,7 +264,13 @@ func (r *ResourceGraphDefinitionReconciler) Reconcile(ctx context.Context, o *v1
 
 	topologicalOrder, resourcesInformation, reconcileErr := r.reconcileResourceGraphDefinition(ctx, o)
 
-	if err := r.updateStatus(ctx, o, topologicalOrder, resourcesInformation); err != nil {
+	// Resource graph definitions waiting for another one are not in error, the
+	// registry enqueues them again once it is active.
+	if graph.IsWaitingForRGD(reconcileErr) {
+		reconcileErr = nil
+	}
+
+	if err := r.updateStatus(ctx, o, topologicalOrder, resourcesInformation, resourceGraphErrors(reconcileErr)); err != nil {
 		reconcileErr = errors.Join(reconcileErr, err)
 	}
 
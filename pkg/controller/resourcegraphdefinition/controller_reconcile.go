@@ -24,6 +24,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/kubernetes-sigs/kro/api/v1alpha1"
+	"github.com/kubernetes-sigs/kro/pkg/applyset"
 	instancectrl "github.com/kubernetes-sigs/kro/pkg/controller/instance"
 	"github.com/kubernetes-sigs/kro/pkg/dynamiccontroller"
 	"github.com/kubernetes-sigs/kro/pkg/graph"
@@ -41,11 +42,22 @@ func (r *ResourceGraphDefinitionReconciler) reconcileResourceGraphDefinition(
 	log := ctrl.LoggerFrom(ctx)
 	mark := NewConditionsMarkerFor(rgd)
 
+	// Declare the instance kind before building the graph, so that resource graph
+	// definitions using it wait for this one instead of going through discovery.
+	registry := r.rgBuilder.Registry()
+	registry.Declare(rgd.Name, metadata.GetResourceGraphDefinitionInstanceGVK(
+		rgd.Spec.Schema.Group, rgd.Spec.Schema.APIVersion, rgd.Spec.Schema.Kind,
+	))
+
 	// Process resource graph definition graph first to validate structure
 	log.V(1).Info("reconciling resource graph definition graph")
 	processedRGD, resourcesInfo, err := r.reconcileResourceGraphDefinitionGraph(ctx, rgd)
 	if err != nil {
-		mark.ResourceGraphInvalid(err.Error())
+		if graph.IsWaitingForRGD(err) {
+			mark.DependsOnRGD(err.Error())
+		} else {
+			mark.ResourceGraphInvalid(resourceGraphInvalidMessage(err))
+		}
 		return nil, nil, err
 	}
 	mark.ResourceGraphValid()
@@ -86,6 +98,9 @@ func (r *ResourceGraphDefinitionReconciler) reconcileResourceGraphDefinition(
 	}
 	mark.ControllerRunning()
 
+	// The instance kind is now ready to be used by other resource graph definitions.
+	registry.Register(rgd.Name, processedRGD)
+
 	return processedRGD.TopologicalOrder, resourcesInfo, nil
 }
 
@@ -107,25 +122,33 @@ func (r *ResourceGraphDefinitionReconciler) setupMicroController(
 		"controllerKind", processedRGD.Instance.GetCRD().Spec.Names.Kind,
 	)
 
+	// A nil cache must be passed as a nil interface.
+	var objectReader applyset.ObjectReader
+	if r.objectCache != nil {
+		objectReader = r.objectCache
+	}
+
 	return instancectrl.NewController(
 		instanceLogger,
 		instancectrl.ReconcileConfig{
 			DefaultRequeueDuration:    3 * time.Second,
 			DeletionGraceTimeDuration: 30 * time.Second,
 			DeletionPolicy:            "Delete",
+			ResourceConcurrency:       r.resourceConcurrency,
 		},
 		gvr,
 		processedRGD,
 		r.clientSet,
 		r.clientSet.RESTMapper(),
 		labeler,
+		objectReader,
 	)
 }
 
 // reconcileResourceGraphDefinitionGraph processes the resource graph definition to build a dependency graph
 // and extract resource information
-func (r *ResourceGraphDefinitionReconciler) reconcileResourceGraphDefinitionGraph(_ context.Context, rgd *v1alpha1.ResourceGraphDefinition) (*graph.Graph, []v1alpha1.ResourceInformation, error) {
-	processedRGD, err := r.rgBuilder.NewResourceGraphDefinition(rgd)
+func (r *ResourceGraphDefinitionReconciler) reconcileResourceGraphDefinitionGraph(ctx context.Context, rgd *v1alpha1.ResourceGraphDefinition) (*graph.Graph, []v1alpha1.ResourceInformation, error) {
+	processedRGD, err := r.rgBuilder.NewResourceGraphDefinition(ctx, rgd)
 	if err != nil {
 		return nil, nil, newGraphError(err)
 	}
@@ -16,6 +16,7 @@ package resourcegraphdefinition
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"k8s.io/apimachinery/pkg/api/equality"
@@ -26,6 +27,7 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/kubernetes-sigs/kro/api/v1alpha1"
 	"github.com/kubernetes-sigs/kro/pkg/apis"
+	"github.com/kubernetes-sigs/kro/pkg/graph"
 	"github.com/kubernetes-sigs/kro/pkg/metadata"
 )
 
@@ -36,6 +38,7 @@ func (r *ResourceGraphDefinitionReconciler) updateStatus(
 	o *v1alpha1.ResourceGraphDefinition,
 	topologicalOrder []string,
 	resources []v1alpha1.ResourceInformation,
+	resourceGraphErrors []v1alpha1.ResourceGraphError,
 ) error {
 	log, _ := logr.FromContext(ctx)
 	log.V(1).Info("calculating resource graph definition status and conditions")
@@ -60,6 +63,7 @@ func (r *ResourceGraphDefinitionReconciler) updateStatus(
 		dc.Status.State = o.Status.State
 		dc.Status.TopologicalOrder = topologicalOrder
 		dc.Status.Resources = resources
+		dc.Status.Errors = resourceGraphErrors
 
 		log.V(1).Info("updating resource graph definition status",
 			"state", dc.Status.State,
@@ -67,7 +71,7 @@ func (r *ResourceGraphDefinitionReconciler) updateStatus(
 		)
 
 		// If there's nothing to update, just return.
-		if equality.Semantic.DeepEqual(current.Status, o.Status) {
+		if equality.Semantic.DeepEqual(current.Status, dc.Status) {
 			return nil
 		}
 
@@ -75,6 +79,57 @@ func (r *ResourceGraphDefinitionReconciler) updateStatus(
 	})
 }
 
+// buildErrors returns the problems found by the graph builder held by err.
+func buildErrors(err error) graph.BuildErrors {
+	var buildErrs graph.BuildErrors
+	if errors.As(err, &buildErrs) {
+		return buildErrs
+	}
+	var buildErr *graph.BuildError
+	if errors.As(err, &buildErr) {
+		return graph.BuildErrors{buildErr}
+	}
+	return nil
+}
+
+// resourceGraphErrors returns the problems found in the resource graph
+// definition held by err, to be listed in its status.
+func resourceGraphErrors(err error) []v1alpha1.ResourceGraphError {
+	buildErrs := buildErrors(err)
+	if len(buildErrs) == 0 {
+		return nil
+	}
+	resourceGraphErrs := make([]v1alpha1.ResourceGraphError, 0, len(buildErrs))
+	for _, buildErr := range buildErrs {
+		resourceGraphErr := v1alpha1.ResourceGraphError{
+			Code:       string(buildErr.Code),
+			ResourceID: buildErr.ResourceID,
+			Path:       buildErr.Path,
+			Expression: buildErr.Expression,
+			Suggestion: buildErr.Suggestion,
+		}
+		if buildErr.Err != nil {
+			resourceGraphErr.Message = buildErr.Err.Error()
+		}
+		resourceGraphErrs = append(resourceGraphErrs, resourceGraphErr)
+	}
+	return resourceGraphErrs
+}
+
+// resourceGraphInvalidMessage returns the message of the condition of an
+// invalid resource graph. It names the first problem found, the others are
+// listed in the status errors.
+func resourceGraphInvalidMessage(err error) string {
+	buildErrs := buildErrors(err)
+	switch len(buildErrs) {
+	case 0:
+		return err.Error()
+	case 1:
+		return buildErrs[0].Error()
+	}
+	return fmt.Sprintf("%s (and %d more errors listed in status.errors)", buildErrs[0].Error(), len(buildErrs)-1)
+}
+
 // setManaged sets the resourcegraphdefinition as managed, by adding the
 // default finalizer if it doesn't exist.
 func (r *ResourceGraphDefinitionReconciler) setManaged(ctx context.Context, rgd *v1alpha1.ResourceGraphDefinition) error {
@@ -142,6 +197,12 @@ func (m *ConditionsMarker) ResourceGraphInvalid(msg string) {
 	m.cs.SetFalse(ResourceGraphAccepted, "InvalidResourceGraph", msg)
 }
 
+// DependsOnRGD signals the rgd.spec.resources use the instance kind of another ResourceGraphDefinition
+// that is not active yet.
+func (m *ConditionsMarker) DependsOnRGD(msg string) {
+	m.cs.SetFalse(ResourceGraphAccepted, "DependsOnRGD", msg)
+}
+
 // FailedLabelerSetup signals that the controller was unable to start the resource labeler and failed to continue.
 func (m *ConditionsMarker) FailedLabelerSetup(msg string) {
 	m.cs.SetFalse(ControllerReady, "FailedLabelerSetup", msg)
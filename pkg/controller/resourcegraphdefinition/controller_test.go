@@ -0,0 +1,151 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcegraphdefinition
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kubernetes-sigs/kro/api/v1alpha1"
+	"github.com/kubernetes-sigs/kro/pkg/graph"
+	"github.com/kubernetes-sigs/kro/pkg/metadata"
+	"github.com/kubernetes-sigs/kro/pkg/testutil/generator"
+	"github.com/kubernetes-sigs/kro/pkg/testutil/k8s"
+)
+
+func newPod(name, image string) map[string]interface{} {
+	return map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata": map[string]interface{}{
+			"name": name,
+		},
+		"spec": map[string]interface{}{
+			"containers": []interface{}{
+				map[string]interface{}{"name": "app", "image": image},
+			},
+		},
+	}
+}
+
+// newTestReconciler returns a reconciler building the graphs with the fake
+// resolver, and reading the given resource graph definition from a fake client.
+func newTestReconciler(t *testing.T, rgd *v1alpha1.ResourceGraphDefinition) *ResourceGraphDefinitionReconciler {
+	scheme := k8sruntime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	fakeResolver, fakeDiscovery := k8s.NewFakeResolver()
+	builder, err := graph.NewBuilder(nil, graph.WithSchemaResolver(fakeResolver, fakeDiscovery))
+	require.NoError(t, err)
+
+	return &ResourceGraphDefinitionReconciler{
+		Client: fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(rgd).
+			WithStatusSubresource(rgd).
+			Build(),
+		metadataLabeler: metadata.NewKROMetaLabeler(),
+		rgBuilder:       builder,
+	}
+}
+
+func TestReconcile_InvalidResourceGraphErrors(t *testing.T) {
+	rgd := generator.NewResourceGraphDefinition("test-rgd",
+		generator.WithSchema("Test", "v1alpha1", map[string]interface{}{"image": "string"}, nil),
+		generator.WithResource("first", newPod("first", "${schema.spec.imag}"), nil, nil),
+		generator.WithResource("second", newPod("${frist.metadata.name}", "${schema.spec.image}"), nil, nil),
+	)
+	r := newTestReconciler(t, rgd)
+
+	_, err := r.Reconcile(context.Background(), rgd.DeepCopy())
+	require.Error(t, err)
+
+	var got v1alpha1.ResourceGraphDefinition
+	require.NoError(t, r.Get(context.Background(), client.ObjectKeyFromObject(rgd), &got))
+	assert.Equal(t, v1alpha1.ResourceGraphDefinitionStateInactive, got.Status.State)
+
+	// Each problem is listed on its own, pointing at the field holding it.
+	require.Len(t, got.Status.Errors, 2)
+	assert.Equal(t, v1alpha1.ResourceGraphError{
+		Code:       string(graph.ErrorCodeInvalidExpression),
+		ResourceID: "first",
+		Path:       "spec.resources[0].template.spec.containers[0].image",
+		Expression: "schema.spec.imag",
+		Message:    got.Status.Errors[0].Message,
+	}, got.Status.Errors[0])
+	assert.NotEmpty(t, got.Status.Errors[0].Message)
+	assert.NotContains(t, got.Status.Errors[0].Message, "\n")
+	assert.Equal(t, "second", got.Status.Errors[1].ResourceID)
+	assert.Equal(t, "spec.resources[1].template.metadata.name", got.Status.Errors[1].Path)
+	assert.Equal(t, `did you mean "first"?`, got.Status.Errors[1].Suggestion)
+
+	// The condition names the first problem, instead of all of them.
+	var accepted *v1alpha1.Condition
+	for i := range got.Status.Conditions {
+		if got.Status.Conditions[i].Type == ResourceGraphAccepted {
+			accepted = &got.Status.Conditions[i]
+		}
+	}
+	require.NotNil(t, accepted)
+	require.NotNil(t, accepted.Message)
+	assert.Equal(t, "InvalidResourceGraph", *accepted.Reason)
+	assert.NotContains(t, *accepted.Message, "\n")
+	assert.Contains(t, *accepted.Message, `resource "first" at spec.resources[0].template.spec.containers[0].image`)
+	assert.Contains(t, *accepted.Message, "and 1 more errors listed in status.errors")
+}
+
+func TestResourceGraphInvalidMessage(t *testing.T) {
+	buildErr := &graph.BuildError{
+		Code:       graph.ErrorCodeUnknownKind,
+		ResourceID: "first",
+		Path:       "spec.resources[0].template.kind",
+		Err:        errors.New("schema not found"),
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "other error",
+			err:  errors.New("failed to build"),
+			want: "failed to build",
+		},
+		{
+			name: "single build error",
+			err:  newGraphError(buildErr),
+			want: `resource "first" at spec.resources[0].template.kind: schema not found`,
+		},
+		{
+			name: "several build errors",
+			err:  newGraphError(graph.BuildErrors{buildErr, buildErr, buildErr}),
+			want: `resource "first" at spec.resources[0].template.kind: schema not found (and 2 more errors listed in status.errors)`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, resourceGraphInvalidMessage(tt.err))
+		})
+	}
+	assert.Nil(t, resourceGraphErrors(errors.New("failed to build")))
+}
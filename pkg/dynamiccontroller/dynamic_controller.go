@@ -449,6 +449,26 @@ func (dc *DynamicController) enqueueObject(obj interface{}, eventType string) {
 	dc.queue.Add(objectIdentifiers)
 }
 
+// EnqueueAll enqueues every object of every registered GVR, e.g. when a
+// change outside of the objects requires them to be reconciled again.
+func (dc *DynamicController) EnqueueAll() {
+	dc.informers.Range(func(key, value interface{}) bool {
+		gvr, ok := key.(schema.GroupVersionResource)
+		if !ok {
+			return true
+		}
+		wrapper, ok := value.(*informerWrapper)
+		if !ok {
+			dc.log.Error(nil, "Failed to cast informer", "key", key)
+			return true
+		}
+		for _, obj := range wrapper.informer.ForResource(gvr).Informer().GetStore().List() {
+			dc.enqueueObject(obj, "update")
+		}
+		return true
+	})
+}
+
 // Register registers a new GVK to the informers map safely.
 func (dc *DynamicController) Register(ctx context.Context, gvr schema.GroupVersionResource, handler Handler) error {
 	dc.log.V(1).Info("Registering new GVK", "gvr", gvr)
@@ -211,3 +211,44 @@ func TestInstanceUpdatePolicy(t *testing.T) {
 		assert.True(t, ok)
 	}
 }
+
+func TestEnqueueAll(t *testing.T) {
+	logger := noopLogger()
+
+	scheme := runtime.NewScheme()
+	gvr := schema.GroupVersionResource{Group: "test", Version: "v1", Resource: "tests"}
+	gvk := schema.GroupVersionKind{Group: "test", Version: "v1", Kind: "Test"}
+
+	obj1 := &unstructured.Unstructured{}
+	obj1.SetGroupVersionKind(gvk)
+	obj1.SetNamespace("default")
+	obj1.SetName("test-object-1")
+
+	obj2 := &unstructured.Unstructured{}
+	obj2.SetGroupVersionKind(gvk)
+	obj2.SetNamespace("test-namespace")
+	obj2.SetName("test-object-2")
+
+	client := fake.NewSimpleDynamicClientWithCustomListKinds(scheme, map[schema.GroupVersionResource]string{
+		gvr: "TestList",
+	}, obj1, obj2)
+
+	dc := NewDynamicController(logger, Config{}, client)
+
+	handlerFunc := Handler(func(ctx context.Context, req controllerruntime.Request) error {
+		return nil
+	})
+	err := dc.Register(context.Background(), gvr, handlerFunc)
+	require.NoError(t, err)
+
+	// simulate reconciling the instances
+	for dc.queue.Len() > 0 {
+		item, _ := dc.queue.Get()
+		dc.queue.Done(item)
+		dc.queue.Forget(item)
+	}
+
+	dc.EnqueueAll()
+
+	assert.Equal(t, 2, dc.queue.Len())
+}
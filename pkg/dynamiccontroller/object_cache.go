@@ -0,0 +1,143 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynamiccontroller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/kubernetes-sigs/kro/pkg/metadata"
+)
+
+// objectCacheSyncTimeout is the maximum duration a read waits for the
+// informer of a new GVR to sync.
+const objectCacheSyncTimeout = 30 * time.Second
+
+// ObjectCache serves the objects managed by kro from informer caches, so
+// that reconciling an instance doesn't need to read every one of its
+// resources from the API server.
+//
+// Informers are started lazily, the first time an object of a GVR is read,
+// and only watch the objects labeled as owned by kro. Objects that aren't
+// labeled yet, e.g. before they are first applied, are reported as not found.
+type ObjectCache struct {
+	log          logr.Logger
+	kubeClient   dynamic.Interface
+	resyncPeriod time.Duration
+
+	mu        sync.Mutex
+	informers map[schema.GroupVersionResource]cache.SharedIndexInformer
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewObjectCache creates a new ObjectCache.
+func NewObjectCache(log logr.Logger, kubeClient dynamic.Interface, resyncPeriod time.Duration) *ObjectCache {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &ObjectCache{
+		log:          log.WithName("object-cache"),
+		kubeClient:   kubeClient,
+		resyncPeriod: resyncPeriod,
+		informers:    make(map[schema.GroupVersionResource]cache.SharedIndexInformer),
+		ctx:          ctx,
+		cancel:       cancel,
+	}
+}
+
+// Start blocks until the context is done, then stops the informers. It
+// allows the cache to be added to a controller manager.
+func (oc *ObjectCache) Start(ctx context.Context) error {
+	<-ctx.Done()
+	oc.log.Info("Stopping object cache informers")
+	oc.cancel()
+	return nil
+}
+
+// Get returns a copy of the object with the given name, read from the cache
+// of its GVR. It returns a NotFound error if the object isn't in the cache.
+func (oc *ObjectCache) Get(
+	ctx context.Context,
+	gvr schema.GroupVersionResource,
+	namespace, name string,
+) (*unstructured.Unstructured, error) {
+	informer := oc.informer(gvr)
+
+	if !informer.HasSynced() {
+		syncCtx, cancel := context.WithTimeout(ctx, objectCacheSyncTimeout)
+		defer cancel()
+		if !cache.WaitForCacheSync(syncCtx.Done(), informer.HasSynced) {
+			return nil, fmt.Errorf("failed to sync object cache for GVR %s", gvr)
+		}
+	}
+
+	key := name
+	if namespace != "" {
+		key = namespace + "/" + name
+	}
+	obj, exists, err := informer.GetIndexer().GetByKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s from object cache: %w", key, err)
+	}
+	if !exists {
+		return nil, apierrors.NewNotFound(gvr.GroupResource(), name)
+	}
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return nil, fmt.Errorf("object cache holds a %T for %s", obj, key)
+	}
+	return u.DeepCopy(), nil
+}
+
+// informer returns the informer of a GVR, starting it if needed.
+func (oc *ObjectCache) informer(gvr schema.GroupVersionResource) cache.SharedIndexInformer {
+	oc.mu.Lock()
+	defer oc.mu.Unlock()
+
+	if informer, ok := oc.informers[gvr]; ok {
+		return informer
+	}
+
+	oc.log.V(1).Info("Starting object cache informer", "gvr", gvr)
+	factory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(
+		oc.kubeClient,
+		oc.resyncPeriod,
+		metav1.NamespaceAll,
+		func(options *metav1.ListOptions) {
+			options.LabelSelector = metadata.OwnedLabel + "=true"
+		},
+	)
+	informer := factory.ForResource(gvr).Informer()
+	if err := informer.SetWatchErrorHandler(func(_ *cache.Reflector, err error) {
+		oc.log.Error(err, "Watch error", "gvr", gvr)
+	}); err != nil {
+		oc.log.Error(err, "Failed to set watch error handler", "gvr", gvr)
+	}
+	go informer.Run(oc.ctx.Done())
+
+	oc.informers[gvr] = informer
+	return informer
+}
@@ -0,0 +1,71 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynamiccontroller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+
+	"github.com/kubernetes-sigs/kro/pkg/metadata"
+)
+
+func TestObjectCache_Get(t *testing.T) {
+	gvr := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	newConfigMap := func(name string, labels map[string]string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion("v1")
+		obj.SetKind("ConfigMap")
+		obj.SetNamespace("default")
+		obj.SetName(name)
+		obj.SetLabels(labels)
+		return obj
+	}
+
+	client := fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{gvr: "ConfigMapList"},
+		newConfigMap("owned", map[string]string{metadata.OwnedLabel: "true"}),
+		newConfigMap("unowned", nil),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	oc := NewObjectCache(noopLogger(), client, 10*time.Hour)
+	go func() { _ = oc.Start(ctx) }()
+
+	obj, err := oc.Get(ctx, gvr, "default", "owned")
+	require.NoError(t, err)
+	assert.Equal(t, "owned", obj.GetName())
+
+	// The returned object is a copy.
+	obj.SetName("modified")
+	obj, err = oc.Get(ctx, gvr, "default", "owned")
+	require.NoError(t, err)
+	assert.Equal(t, "owned", obj.GetName())
+
+	_, err = oc.Get(ctx, gvr, "default", "unowned")
+	assert.True(t, apierrors.IsNotFound(err))
+
+	_, err = oc.Get(ctx, gvr, "default", "missing")
+	assert.True(t, apierrors.IsNotFound(err))
+}
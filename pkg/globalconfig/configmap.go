@@ -0,0 +1,108 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package globalconfig
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+
+// ConfigMapWatcher keeps the ConfigMap values of a Store in sync with the data
+// of a ConfigMap. A missing ConfigMap is equivalent to an empty one.
+type ConfigMapWatcher struct {
+	client       kubernetes.Interface
+	configMap    types.NamespacedName
+	store        *Store
+	resyncPeriod time.Duration
+	log          logr.Logger
+}
+
+// NewConfigMapWatcher creates a new ConfigMapWatcher updating the given store
+// with the data of the given ConfigMap.
+func NewConfigMapWatcher(
+	log logr.Logger,
+	client kubernetes.Interface,
+	configMap types.NamespacedName,
+	store *Store,
+	resyncPeriod time.Duration,
+) *ConfigMapWatcher {
+	return &ConfigMapWatcher{
+		client:       client,
+		configMap:    configMap,
+		store:        store,
+		resyncPeriod: resyncPeriod,
+		log:          log.WithName("global-config").WithValues("configmap", configMap.String()),
+	}
+}
+
+// Start watches the ConfigMap until the context is cancelled. It implements
+// the controller-runtime manager.Runnable interface.
+func (w *ConfigMapWatcher) Start(ctx context.Context) error {
+	listWatch := cache.NewListWatchFromClient(
+		w.client.CoreV1().RESTClient(),
+		"configmaps",
+		w.configMap.Namespace,
+		fields.OneTermEqualSelector("metadata.name", w.configMap.Name),
+	)
+	informer := cache.NewSharedInformer(listWatch, &corev1.ConfigMap{}, w.resyncPeriod)
+
+	_, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    w.update,
+		UpdateFunc: func(_, newObj interface{}) { w.update(newObj) },
+		DeleteFunc: func(interface{}) { w.set(nil) },
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add event handler: %w", err)
+	}
+
+	w.log.Info("Starting global configuration watcher")
+	go informer.Run(ctx.Done())
+	if !cache.WaitForCacheSync(ctx.Done(), informer.HasSynced) {
+		return fmt.Errorf("failed to sync configmap %s", w.configMap)
+	}
+	<-ctx.Done()
+	return nil
+}
+
+// NeedLeaderElection implements the controller-runtime
+// manager.LeaderElectionRunnable interface. The configuration must be loaded
+// by every replica, as it is used when building resource graph definitions.
+func (w *ConfigMapWatcher) NeedLeaderElection() bool {
+	return false
+}
+
+func (w *ConfigMapWatcher) update(obj interface{}) {
+	configMap, ok := obj.(*corev1.ConfigMap)
+	if !ok {
+		return
+	}
+	w.set(configMap.Data)
+}
+
+func (w *ConfigMapWatcher) set(values map[string]string) {
+	if w.store.SetConfigMapValues(values) {
+		w.log.V(1).Info("Global configuration changed", "keys", len(values))
+	}
+}
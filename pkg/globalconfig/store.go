@@ -0,0 +1,99 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package globalconfig holds the cluster-wide configuration values exposed to
+// the CEL expressions of every resource graph definition through the
+// `kro.config` variable.
+package globalconfig
+
+import (
+	"maps"
+	"sync"
+)
+
+// Store holds the global configuration values. Values come from two sources:
+// static values, set with controller flags, and values read from a ConfigMap,
+// which take precedence over the static ones.
+//
+// Store is safe for concurrent use.
+type Store struct {
+	mu sync.RWMutex
+	// static are the values set when the store is created.
+	static map[string]string
+	// configMapValues are the values read from the ConfigMap.
+	configMapValues map[string]string
+	// values is the merged view of static and configMapValues.
+	values map[string]string
+	// onChange are called, in order, every time the values change.
+	onChange []func()
+}
+
+// NewStore creates a new Store with the given static values.
+func NewStore(static map[string]string) *Store {
+	s := &Store{
+		static: maps.Clone(static),
+	}
+	s.values = s.merge()
+	return s
+}
+
+// Values returns a copy of the current configuration values. A nil Store has
+// no values.
+func (s *Store) Values() map[string]string {
+	if s == nil {
+		return map[string]string{}
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return maps.Clone(s.values)
+}
+
+// OnChange registers a function called every time the configuration values
+// change. The values of a nil Store never change.
+func (s *Store) OnChange(fn func()) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onChange = append(s.onChange, fn)
+}
+
+// SetConfigMapValues replaces the values read from the ConfigMap, and notifies
+// the registered functions if the resulting configuration changed. It returns
+// true if the configuration changed.
+func (s *Store) SetConfigMapValues(values map[string]string) bool {
+	s.mu.Lock()
+	s.configMapValues = maps.Clone(values)
+	merged := s.merge()
+	changed := !maps.Equal(merged, s.values)
+	s.values = merged
+	onChange := s.onChange
+	s.mu.Unlock()
+
+	if changed {
+		for _, fn := range onChange {
+			fn()
+		}
+	}
+	return changed
+}
+
+// merge returns the static values overridden by the ConfigMap values.
+func (s *Store) merge() map[string]string {
+	merged := make(map[string]string, len(s.static)+len(s.configMapValues))
+	maps.Copy(merged, s.static)
+	maps.Copy(merged, s.configMapValues)
+	return merged
+}
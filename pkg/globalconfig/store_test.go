@@ -0,0 +1,72 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package globalconfig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore(t *testing.T) {
+	store := NewStore(map[string]string{
+		"registry":    "docker.io",
+		"clusterName": "dev",
+	})
+
+	changes := 0
+	store.OnChange(func() { changes++ })
+
+	assert.Equal(t, map[string]string{
+		"registry":    "docker.io",
+		"clusterName": "dev",
+	}, store.Values())
+
+	// ConfigMap values override the static ones.
+	assert.True(t, store.SetConfigMapValues(map[string]string{
+		"registry":  "ghcr.io",
+		"accountID": "123456789012",
+	}))
+	assert.Equal(t, map[string]string{
+		"registry":    "ghcr.io",
+		"clusterName": "dev",
+		"accountID":   "123456789012",
+	}, store.Values())
+	assert.Equal(t, 1, changes)
+
+	// Setting the same values is not a change.
+	assert.False(t, store.SetConfigMapValues(map[string]string{
+		"registry":  "ghcr.io",
+		"accountID": "123456789012",
+	}))
+	assert.Equal(t, 1, changes)
+
+	// Removing the ConfigMap restores the static values.
+	assert.True(t, store.SetConfigMapValues(nil))
+	assert.Equal(t, map[string]string{
+		"registry":    "docker.io",
+		"clusterName": "dev",
+	}, store.Values())
+	assert.Equal(t, 2, changes)
+
+	// Values returns a copy.
+	store.Values()["registry"] = "quay.io"
+	assert.Equal(t, "docker.io", store.Values()["registry"])
+}
+
+func TestStore_Nil(t *testing.T) {
+	var store *Store
+	assert.Empty(t, store.Values())
+}
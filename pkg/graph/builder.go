@@ -15,53 +15,139 @@
 package graph
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"slices"
+	"strings"
 
 	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
 	"github.com/google/cel-go/common/types/ref"
 	"golang.org/x/exp/maps"
 	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	k8sschema "k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/yaml"
 	"k8s.io/apiserver/pkg/cel/openapi/resolver"
-	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/rest"
+	"k8s.io/kube-openapi/pkg/validation/spec"
 
 	"github.com/kubernetes-sigs/kro/api/v1alpha1"
 	krocel "github.com/kubernetes-sigs/kro/pkg/cel"
 	"github.com/kubernetes-sigs/kro/pkg/cel/ast"
+	"github.com/kubernetes-sigs/kro/pkg/globalconfig"
 	"github.com/kubernetes-sigs/kro/pkg/graph/crd"
 	"github.com/kubernetes-sigs/kro/pkg/graph/dag"
 	"github.com/kubernetes-sigs/kro/pkg/graph/emulator"
+	"github.com/kubernetes-sigs/kro/pkg/graph/fieldpath"
 	"github.com/kubernetes-sigs/kro/pkg/graph/parser"
 	"github.com/kubernetes-sigs/kro/pkg/graph/schema"
 	schemaresolver "github.com/kubernetes-sigs/kro/pkg/graph/schema/resolver"
 	"github.com/kubernetes-sigs/kro/pkg/graph/variable"
 	"github.com/kubernetes-sigs/kro/pkg/metadata"
+	"github.com/kubernetes-sigs/kro/pkg/runtime"
 	"github.com/kubernetes-sigs/kro/pkg/simpleschema"
 )
 
-// NewBuilder creates a new GraphBuilder instance.
+// BuilderOption configures a Builder.
+type BuilderOption func(*Builder)
+
+// WithGlobalConfig sets the store of the global configuration values exposed
+// to the CEL expressions through the kro.config variable. Without it,
+// kro.config is an empty map.
+func WithGlobalConfig(store *globalconfig.Store) BuilderOption {
+	return func(b *Builder) {
+		b.globalConfig = store
+	}
+}
+
+// WithCostLimits sets the cost limits of the CEL expressions. The expression
+// limit is enforced on the estimated cost of expressions when they are
+// compiled, and both limits are enforced by the runtimes of the built graphs.
+func WithCostLimits(limits krocel.CostLimits) BuilderOption {
+	return func(b *Builder) {
+		b.costLimits = limits
+	}
+}
+
+// WithSchemaResolver sets the resolver of the schemas of the resources, and
+// the lister of the namespaced resources, used instead of the cluster ones,
+// e.g. to build against an offline.BundleResolver snapshotted from
+// another cluster.
+func WithSchemaResolver(
+	schemaResolver resolver.SchemaResolver,
+	namespacedResources NamespacedResourceLister,
+) BuilderOption {
+	return func(b *Builder) {
+		b.schemaResolver = schemaResolver
+		b.discoveryClient = namespacedResources
+	}
+}
+
+// NewBuilder creates a new GraphBuilder instance. The client config can be nil
+// when the schemas are resolved with WithSchemaResolver, in which case
+// TypeLibrary imports aren't supported.
 func NewBuilder(
 	clientConfig *rest.Config,
+	options ...BuilderOption,
 ) (*Builder, error) {
-	schemaResolver, dc, err := schemaresolver.NewCombinedResolver(clientConfig)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create schema resolver: %w", err)
+	rgBuilder := &Builder{
+		resourceEmulator: emulator.NewEmulator(),
+		registry:         NewRegistry(),
+		costLimits:       krocel.DefaultCostLimits(),
+	}
+	for _, option := range options {
+		option(rgBuilder)
 	}
 
-	resourceEmulator := emulator.NewEmulator()
+	if rgBuilder.schemaResolver == nil {
+		if clientConfig == nil {
+			return nil, fmt.Errorf("a client config or a schema resolver is required")
+		}
+		schemaResolver, dc, err := schemaresolver.NewCombinedResolver(clientConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create schema resolver: %w", err)
+		}
+		rgBuilder.schemaResolver = schemaResolver
+		rgBuilder.discoveryClient = dc
+	}
 
-	rgBuilder := &Builder{
-		resourceEmulator: resourceEmulator,
-		schemaResolver:   schemaResolver,
-		discoveryClient:  dc,
+	if clientConfig != nil {
+		dynamicClient, err := dynamic.NewForConfig(clientConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create dynamic client: %w", err)
+		}
+		rgBuilder.typeLibraryResolver = NewTypeLibraryResolver(dynamicClient)
 	}
 	return rgBuilder, nil
 }
 
+// NewOfflineBuilder creates a new GraphBuilder instance that doesn't need a
+// cluster, e.g. to validate resource graph definitions in editors or in CI.
+// The schemas of the resources are resolved by the given schema resolver, and
+// their scopes are listed by the given lister, both of which are usually an
+// offline.LocalResolver or an offline.BundleResolver. TypeLibrary
+// imports aren't supported.
+func NewOfflineBuilder(
+	schemaResolver resolver.SchemaResolver,
+	namespacedResources NamespacedResourceLister,
+	options ...BuilderOption,
+) *Builder {
+	options = append([]BuilderOption{WithSchemaResolver(schemaResolver, namespacedResources)}, options...)
+	// NewBuilder only fails to create the clients of the cluster.
+	rgBuilder, _ := NewBuilder(nil, options...)
+	return rgBuilder
+}
+
+// NamespacedResourceLister lists the namespaced resources of a cluster. It is
+// implemented by the discovery clients.
+type NamespacedResourceLister interface {
+	ServerPreferredNamespacedResources() ([]*metav1.APIResourceList, error)
+}
+
 // Builder is an object that is responsible for constructing and managing
 // resourceGraphDefinitions. It is responsible for transforming the resourceGraphDefinition CRD
 // into a runtime representation that can be used to create the resources in
@@ -95,14 +181,48 @@ type Builder struct {
 	// Maybe there is a better way, if anything probably there is a better way to
 	// validate the CEL expressions. To revisit.
 	resourceEmulator *emulator.Emulator
-	discoveryClient  discovery.DiscoveryInterface
+	// discoveryClient is used to find out which resources are namespaced.
+	discoveryClient NamespacedResourceLister
+	// typeLibraryResolver is used to resolve the TypeLibraries imported by the
+	// instance schema.
+	typeLibraryResolver TypeLibraryResolver
+	// registry keeps track of the instance kinds provided by the resource graph
+	// definitions. It is used to resolve the schema of resources that are
+	// instances of other resource graph definitions.
+	registry *Registry
+	// globalConfig holds the global configuration values, exposed to the CEL
+	// expressions through the kro.config variable.
+	globalConfig *globalconfig.Store
+	// costLimits bounds the cost of the CEL expressions. A zero limit
+	// disables it.
+	costLimits krocel.CostLimits
+}
+
+// Registry returns the registry of instance kinds used by the builder.
+func (b *Builder) Registry() *Registry {
+	return b.registry
+}
+
+// GlobalConfig returns the store of the global configuration values used by
+// the builder, nil if it has none.
+func (b *Builder) GlobalConfig() *globalconfig.Store {
+	return b.globalConfig
+}
+
+// SchemaResolver returns the resolver used by the builder to resolve the
+// OpenAPI schemas of the resources.
+func (b *Builder) SchemaResolver() resolver.SchemaResolver {
+	return b.schemaResolver
 }
 
 // NewResourceGraphDefinition creates a new ResourceGraphDefinition object from the given ResourceGraphDefinition
 // CRD. The ResourceGraphDefinition object is a fully processed and validated representation
 // of the resource graph definition CRD, it's underlying resources, and the relationships between
 // the resources.
-func (b *Builder) NewResourceGraphDefinition(originalCR *v1alpha1.ResourceGraphDefinition) (*Graph, error) {
+func (b *Builder) NewResourceGraphDefinition(
+	ctx context.Context,
+	originalCR *v1alpha1.ResourceGraphDefinition,
+) (*Graph, error) {
 	// Before anything else, let's copy the resource graph definition to avoid modifying the
 	// original object.
 	rgd := originalCR.DeepCopy()
@@ -114,11 +234,21 @@ func (b *Builder) NewResourceGraphDefinition(originalCR *v1alpha1.ResourceGraphD
 	//    that the names of the resources are valid to be used in CEL expressions.
 	//    for example name-something-something is not a valid name for a resource,
 	//    because in CEL - is a subtraction operator.
+	//
+	// The problems found in the resource graph definition are reported as
+	// BuildErrors, pointing at the fields holding them. Each step reports all
+	// the problems it finds, but the steps relying on the previous ones only
+	// run when those succeeded.
 	err := validateResourceGraphDefinitionNamingConventions(rgd)
 	if err != nil {
-		return nil, fmt.Errorf("failed to validate resourcegraphdefinition: %w", err)
+		return nil, err
 	}
 
+	// The expressions are validated against the current global configuration
+	// values. Missing keys make the resource graph definition invalid until
+	// they are configured.
+	config := b.globalConfig.Values()
+
 	// Now that we did a basic validation of the resource graph definition, we can start understanding
 	// the resources that are part of the resource graph definition.
 
@@ -144,18 +274,48 @@ func (b *Builder) NewResourceGraphDefinition(originalCR *v1alpha1.ResourceGraphD
 
 	// we'll also store the resources in a map for easy access later.
 	resources := make(map[string]*Resource)
+	// providers are the names of the resource graph definitions whose instance
+	// kinds are used by the resources, available or not.
+	var providers []string
+	var errs BuildErrors
 	for i, rgResource := range rgd.Spec.Resources {
 		id := rgResource.ID
 		order := i
-		r, err := b.buildRGResource(rgResource, namespacedResources, order)
+		r, err := b.buildRGResource(rgd.Name, rgResource, namespacedResources, order)
+		var dependencyErr *RGDDependencyError
+		var cycleErr *RGDCycleError
+		switch {
+		case err == nil && r.dependsOnRGD != "":
+			providers = append(providers, r.dependsOnRGD)
+		case errors.As(err, &dependencyErr):
+			providers = append(providers, dependencyErr.RGDName)
+		case errors.As(err, &cycleErr):
+			providers = append(providers, cycleErr.Cycle[1])
+		}
 		if err != nil {
-			return nil, fmt.Errorf("failed to build resource %q: %w", id, err)
+			errs = append(errs, asBuildErrors(err, ErrorCodeInvalidResource, id, resourcePath(order, ""))...)
+			continue
 		}
 		if resources[id] != nil {
-			return nil, fmt.Errorf("found resources with duplicate id %q", id)
+			errs = append(errs, &BuildError{
+				Code:       ErrorCodeDuplicateID,
+				ResourceID: id,
+				Path:       resourcePath(order, "id"),
+				Err:        fmt.Errorf("found resources with duplicate id %q", id),
+			})
+			continue
+		}
+		if r.propagationPolicy == "" {
+			r.propagationPolicy = rgd.Spec.PropagationPolicy
 		}
 		resources[id] = r
 	}
+	if b.registry != nil {
+		b.registry.setDependencies(rgd.Name, providers)
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
 
 	// At this stage we have a superficial understanding of the resources that are
 	// part of the resource graph definition. We have the OpenAPI schema for each resource, and
@@ -197,6 +357,7 @@ func (b *Builder) NewResourceGraphDefinition(originalCR *v1alpha1.ResourceGraphD
 	// 4. Infer the status schema based on the CEL expressions.
 
 	instance, err := b.buildInstanceResource(
+		ctx,
 		rgd.Spec.Schema.Group,
 		rgd.Spec.Schema.APIVersion,
 		rgd.Spec.Schema.Kind,
@@ -204,9 +365,10 @@ func (b *Builder) NewResourceGraphDefinition(originalCR *v1alpha1.ResourceGraphD
 		// We need to pass the resources to the instance resource, so we can validate
 		// the CEL expressions in the context of the resources.
 		resources,
+		config,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to build resourcegraphdefinition '%v': %w", rgd.Name, err)
+		return nil, asBuildErrors(err, ErrorCodeInvalidSchema, "", "spec.schema")
 	}
 
 	// Before getting into the dependency graph, we need to validate the CEL expressions
@@ -215,9 +377,9 @@ func (b *Builder) NewResourceGraphDefinition(originalCR *v1alpha1.ResourceGraphD
 	// and evaluate the CEL expressions in the context of the resource graph definition.
 	//This is done
 	// by dry-running the CEL expressions against the emulated resources.
-	err = validateResourceCELExpressions(resources, instance)
+	err = validateResourceCELExpressions(resources, instance, config, b.costLimits)
 	if err != nil {
-		return nil, fmt.Errorf("failed to validate resource CEL expressions: %w", err)
+		return nil, err
 	}
 
 	// Now that we have the instance resource, we can move into the next stage of
@@ -234,7 +396,7 @@ func (b *Builder) NewResourceGraphDefinition(originalCR *v1alpha1.ResourceGraphD
 	// inspector.
 	dag, err := b.buildDependencyGraph(resources)
 	if err != nil {
-		return nil, fmt.Errorf("failed to build dependency graph: %w", err)
+		return nil, err
 	}
 
 	topologicalOrder, err := dag.TopologicalSort()
@@ -242,15 +404,42 @@ func (b *Builder) NewResourceGraphDefinition(originalCR *v1alpha1.ResourceGraphD
 		return nil, fmt.Errorf("failed to get topological order: %w", err)
 	}
 
+	// The expressions are compiled once, and their programs are shared by the
+	// runtimes of all the instances.
+	programs, err := compilePrograms(resources, instance, b.costLimits.Expression)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile CEL expressions: %w", err)
+	}
+
 	resourceGraphDefinition := &Graph{
 		DAG:              dag,
 		Instance:         instance,
 		Resources:        resources,
 		TopologicalOrder: topologicalOrder,
+		globalConfig:     b.globalConfig,
+		costLimits:       b.costLimits,
+		programs:         programs,
 	}
 	return resourceGraphDefinition, nil
 }
 
+// compilePrograms compiles the template, readyWhen and includeWhen expressions
+// of the resources, and the status expressions of the instance.
+func compilePrograms(resources map[string]*Resource, instance *Resource, costLimit uint64) (runtime.Programs, error) {
+	expressions := []string{}
+	for _, resource := range resources {
+		for _, resourceVariable := range resource.variables {
+			expressions = append(expressions, resourceVariable.Expressions...)
+		}
+		expressions = append(expressions, resource.readyWhenExpressions...)
+		expressions = append(expressions, resource.includeWhenExpressions...)
+	}
+	for _, instanceVariable := range instance.variables {
+		expressions = append(expressions, instanceVariable.Expressions...)
+	}
+	return runtime.CompilePrograms(maps.Keys(resources), expressions, costLimit)
+}
+
 // buildExternalRefResource builds an empty resource with metadata from the given externalRef definition.
 func (b *Builder) buildExternalRefResource(
 	externalRef *v1alpha1.ExternalRef) map[string]interface{} {
@@ -272,40 +461,60 @@ func (b *Builder) buildExternalRefResource(
 // OpenAPI schema, emulating the resource and extracting the cel expressions
 // from the schema.
 func (b *Builder) buildRGResource(
+	rgdName string,
 	rgResource *v1alpha1.Resource,
 	namespacedResources map[k8sschema.GroupKind]bool,
 	order int,
 ) (*Resource, error) {
+	// The errors point at the field of the resource holding the problem.
+	newError := func(code ErrorCode, field string, err error) *BuildError {
+		return &BuildError{Code: code, ResourceID: rgResource.ID, Path: resourcePath(order, field), Err: err}
+	}
+	objectField := "template"
+	if rgResource.ExternalRef != nil {
+		objectField = "externalRef"
+	}
+
 	// 1. We need to unmarshal the resource into a map[string]interface{} to
 	//    make it easier to work with.
 	resourceObject := map[string]interface{}{}
 	if len(rgResource.Template.Raw) > 0 {
 		err := yaml.UnmarshalStrict(rgResource.Template.Raw, &resourceObject)
 		if err != nil {
-			return nil, fmt.Errorf("failed to unmarshal resource %s: %w", rgResource.ID, err)
+			return nil, newError(ErrorCodeInvalidResource, objectField,
+				fmt.Errorf("failed to unmarshal resource %s: %w", rgResource.ID, err))
 		}
 	} else if rgResource.ExternalRef != nil {
 		resourceObject = b.buildExternalRefResource(rgResource.ExternalRef)
 	} else {
-		return nil, fmt.Errorf("exactly one of template or externalRef must be provided")
+		return nil, newError(ErrorCodeInvalidResource, "",
+			fmt.Errorf("exactly one of template or externalRef must be provided"))
 	}
 
 	// 1. Check if it looks like a valid Kubernetes resource.
 	err := validateKubernetesObjectStructure(resourceObject)
 	if err != nil {
-		return nil, fmt.Errorf("resource %s is not a valid Kubernetes object: %v", rgResource.ID, err)
+		return nil, newError(ErrorCodeInvalidResource, objectField,
+			fmt.Errorf("resource %s is not a valid Kubernetes object: %v", rgResource.ID, err))
 	}
 
 	// 2. Based the GVK, we need to load the OpenAPI schema for the resource.
 	gvk, err := metadata.ExtractGVKFromUnstructured(resourceObject)
 	if err != nil {
-		return nil, fmt.Errorf("failed to extract GVK from resource %s: %w", rgResource.ID, err)
+		return nil, newError(ErrorCodeInvalidResource, objectField+".apiVersion",
+			fmt.Errorf("failed to extract GVK from resource %s: %w", rgResource.ID, err))
 	}
 
 	// 3. Load the OpenAPI schema for the resource.
-	resourceSchema, err := b.schemaResolver.ResolveSchema(gvk)
+	resourceSchema, dependsOnRGD, providerGraph, err := b.resolveResourceSchema(rgdName, gvk)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get schema for resource %s: %w", rgResource.ID, err)
+		code := ErrorCodeUnknownKind
+		var cycleErr *RGDCycleError
+		if errors.As(err, &cycleErr) {
+			code = ErrorCodeDependencyCycle
+		}
+		return nil, newError(code, objectField+".kind",
+			fmt.Errorf("failed to get schema for resource %s: %w", rgResource.ID, err))
 	}
 
 	var emulatedResource *unstructured.Unstructured
@@ -316,10 +525,12 @@ func (b *Builder) buildRGResource(
 	if gvk.Group == "apiextensions.k8s.io" && gvk.Version == "v1" && gvk.Kind == "CustomResourceDefinition" {
 		celExpressions, err := parser.ParseSchemalessResource(resourceObject)
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse schemaless resource %s: %w", rgResource.ID, err)
+			return nil, newError(ErrorCodeInvalidExpression, objectField,
+				fmt.Errorf("failed to parse schemaless resource %s: %w", rgResource.ID, err))
 		}
 		if len(celExpressions) > 0 {
-			return nil, fmt.Errorf("failed, CEL expressions are not supported for CRDs, resource %s", rgResource.ID)
+			return nil, newError(ErrorCodeInvalidExpression, objectField+"."+celExpressions[0].Path,
+				fmt.Errorf("failed, CEL expressions are not supported for CRDs, resource %s", rgResource.ID))
 		}
 	} else {
 
@@ -327,13 +538,15 @@ func (b *Builder) buildRGResource(
 		//    CEL expressions.
 		emulatedResource, err = b.resourceEmulator.GenerateDummyCR(gvk, resourceSchema)
 		if err != nil {
-			return nil, fmt.Errorf("failed to generate dummy CR for resource %s: %w", rgResource.ID, err)
+			return nil, newError(ErrorCodeInvalidResource, objectField,
+				fmt.Errorf("failed to generate dummy CR for resource %s: %w", rgResource.ID, err))
 		}
 
 		// 5. Extract CEL fieldDescriptors from the schema.
 		fieldDescriptors, err := parser.ParseResource(resourceObject, resourceSchema)
 		if err != nil {
-			return nil, fmt.Errorf("failed to extract CEL expressions from schema for resource %s: %w", rgResource.ID, err)
+			return nil, newError(ErrorCodeInvalidExpression, objectField,
+				fmt.Errorf("failed to extract CEL expressions from schema for resource %s: %w", rgResource.ID, err))
 		}
 		for _, fieldDescriptor := range fieldDescriptors {
 			resourceVariables = append(resourceVariables, &variable.ResourceField{
@@ -345,18 +558,40 @@ func (b *Builder) buildRGResource(
 	}
 
 	// 6. Parse ReadyWhen expressions
-	readyWhen, err := parser.ParseConditionExpressions(rgResource.ReadyWhen)
+	readyWhen, err := parseConditions(rgResource.ID, order, "readyWhen", rgResource.ReadyWhen)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse readyWhen expressions: %v", err)
+		return nil, err
+	}
+	// Instances of other resource graph definitions are only ready once they
+	// are active, unless the user says otherwise.
+	if dependsOnRGD != "" && len(readyWhen) == 0 {
+		readyWhen = []string{fmt.Sprintf("%s.status.state == %q", rgResource.ID, v1alpha1.InstanceStateActive)}
 	}
 
 	// 7. Parse condition expressions
-	includeWhen, err := parser.ParseConditionExpressions(rgResource.IncludeWhen)
+	includeWhen, err := parseConditions(rgResource.ID, order, "includeWhen", rgResource.IncludeWhen)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse includeWhen expressions: %v", err)
+		return nil, err
 	}
 
 	_, isNamespaced := namespacedResources[gvk.GroupKind()]
+	if providerGraph != nil {
+		// The CRD of the instances might not be discoverable yet.
+		isNamespaced = providerGraph.Instance.GetCRD().Spec.Scope == extv1.NamespaceScoped
+	}
+
+	updateStrategy := rgResource.UpdateStrategy
+	if updateStrategy == "" {
+		updateStrategy = v1alpha1.UpdateStrategyApply
+	}
+
+	// 8. Validate the ignored fields
+	for i, path := range rgResource.IgnoreFields {
+		if err := validateIgnoreField(path); err != nil {
+			return nil, newError(ErrorCodeInvalidField, fmt.Sprintf("ignoreFields[%d]", i),
+				fmt.Errorf("invalid ignoreFields of resource %s: %w", rgResource.ID, err))
+		}
+	}
 
 	// Note that at this point we don't inject the dependencies into the resource.
 	return &Resource{
@@ -371,9 +606,54 @@ func (b *Builder) buildRGResource(
 		namespaced:             isNamespaced,
 		order:                  order,
 		isExternalRef:          rgResource.ExternalRef != nil,
+		dependsOnRGD:           dependsOnRGD,
+		explicitDependencies:   slices.Clone(rgResource.DependsOn),
+		propagationPolicy:      rgResource.PropagationPolicy,
+		updateStrategy:         updateStrategy,
+		ignoreFields:           slices.Clone(rgResource.IgnoreFields),
+		forceApply:             rgResource.ForceApply == nil || *rgResource.ForceApply,
 	}, nil
 }
 
+// resolveResourceSchema resolves the OpenAPI schema for the given GVK. If the
+// GVK is the instance kind of another resource graph definition, the schema is
+// taken from its compiled graph, which is returned as well along with the name
+// of that resource graph definition.
+//
+// If that resource graph definition isn't compiled yet, a RGDDependencyError
+// is returned, and if it uses the kind of this one, directly or not, a
+// RGDCycleError is returned.
+func (b *Builder) resolveResourceSchema(
+	rgdName string,
+	gvk k8sschema.GroupVersionKind,
+) (*spec.Schema, string, *Graph, error) {
+	if b.registry != nil {
+		if providerName, providerGraph, ok := b.registry.Lookup(gvk); ok {
+			if providerName == rgdName {
+				return nil, "", nil, fmt.Errorf("resourcegraphdefinition can't use its own instance kind %s", gvk.Kind)
+			}
+			if err := b.registry.addDependency(rgdName, providerName); err != nil {
+				return nil, "", nil, err
+			}
+			if providerGraph == nil {
+				return nil, "", nil, &RGDDependencyError{RGDName: providerName, GVK: gvk}
+			}
+			// The instance schema only declares metadata as an object, complete it
+			// the same way discovery does for the CRD schemas it serves.
+			instanceSchema := *providerGraph.Instance.GetSchema()
+			instanceSchema.Properties = maps.Clone(instanceSchema.Properties)
+			instanceSchema.Properties["metadata"] = *schemaresolver.ObjectMetaSchema()
+			return &instanceSchema, providerName, providerGraph, nil
+		}
+	}
+
+	resourceSchema, err := b.schemaResolver.ResolveSchema(gvk)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	return resourceSchema, "", nil, nil
+}
+
 // buildDependencyGraph builds the dependency graph between the resources in the
 // resource graph definition.
 // The dependency graph is a directed acyclic graph that represents
@@ -398,10 +678,12 @@ func (b *Builder) buildDependencyGraph(
 	// We also want to allow users to refer to the instance spec in their expressions.
 	resourceNames = append(resourceNames, "schema")
 
-	env, err := krocel.DefaultEnvironment(krocel.WithResourceIDs(resourceNames))
+	env, err := krocel.DefaultEnvironment(krocel.WithResourceIDs(resourceNames), krocel.WithConfig())
 	if err != nil {
 		return nil, fmt.Errorf("failed to create CEL environment: %w", err)
 	}
+	// The global configuration can be referred to, but is never a dependency.
+	resourceNames = append(resourceNames, krocel.KroVariable)
 
 	directedAcyclicGraph := dag.NewDirectedAcyclicGraph[string]()
 	// Set the vertices of the graph to be the resources defined in the resource graph definition.
@@ -411,20 +693,28 @@ func (b *Builder) buildDependencyGraph(
 		}
 	}
 
-	for _, resource := range resources {
+	var errs BuildErrors
+	for _, resource := range resourcesInOrder(resources) {
+		// A cycle is only reported once per resource.
+		cyclic := false
 		for _, resourceVariable := range resource.variables {
+			path := resource.templatePath(resourceVariable.Path)
 			for _, expression := range resourceVariable.Expressions {
 				// We need to inspect the expression to understand how it relates to the
 				// resources defined in the resource graph definition.
 				err := validateCELExpressionContext(env, expression, resourceNames)
 				if err != nil {
-					return nil, fmt.Errorf("failed to validate expression context: %w", err)
+					errs = append(errs, newExpressionError(resource.id, path, expression,
+						fmt.Errorf("failed to validate expression context: %w", err), resourceNames))
+					continue
 				}
 
 				// We need to extract the dependencies from the expression.
 				resourceDependencies, isStatic, err := extractDependencies(env, expression, resourceNames)
 				if err != nil {
-					return nil, fmt.Errorf("failed to extract dependencies: %w", err)
+					errs = append(errs, newExpressionError(resource.id, path, expression,
+						fmt.Errorf("failed to extract dependencies: %w", err), resourceNames))
+					continue
 				}
 
 				// Static until proven dynamic.
@@ -438,16 +728,101 @@ func (b *Builder) buildDependencyGraph(
 				resource.addDependencies(resourceDependencies...)
 				resourceVariable.AddDependencies(resourceDependencies...)
 				// We need to add the dependencies to the graph.
-				if err := directedAcyclicGraph.AddDependencies(resource.id, resourceDependencies); err != nil {
-					return nil, err
+				err = directedAcyclicGraph.AddDependencies(resource.id, resourceDependencies)
+				if err != nil && !cyclic {
+					cyclic = true
+					errs = append(errs, &BuildError{
+						Code:       ErrorCodeDependencyCycle,
+						ResourceID: resource.id,
+						Path:       path,
+						Expression: expression,
+						Err:        err,
+					})
 				}
 			}
 		}
+
+		// Explicit dependencies order resources that don't refer to each other.
+		var explicitDependencies []string
+		for i, dependency := range resource.explicitDependencies {
+			if _, ok := resources[dependency]; !ok {
+				errs = append(errs, &BuildError{
+					Code:       ErrorCodeUnknownDependency,
+					ResourceID: resource.id,
+					Path:       resourcePath(resource.order, fmt.Sprintf("dependsOn[%d]", i)),
+					Suggestion: suggest(dependency, maps.Keys(resources)),
+					Err:        fmt.Errorf("resource %s depends on unknown resource %s", resource.id, dependency),
+				})
+				continue
+			}
+			explicitDependencies = append(explicitDependencies, dependency)
+		}
+		resource.addDependencies(explicitDependencies...)
+		err := directedAcyclicGraph.AddDependencies(resource.id, explicitDependencies)
+		if err != nil && !cyclic {
+			errs = append(errs, &BuildError{
+				Code:       ErrorCodeDependencyCycle,
+				ResourceID: resource.id,
+				Path:       resourcePath(resource.order, "dependsOn"),
+				Err:        err,
+			})
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs
 	}
 
 	return directedAcyclicGraph, nil
 }
 
+// resourcesInOrder returns the resources in the order they are defined in the
+// resource graph definition, so that their errors are reported in that order.
+func resourcesInOrder(resources map[string]*Resource) []*Resource {
+	ordered := maps.Values(resources)
+	slices.SortFunc(ordered, func(a, b *Resource) int {
+		return a.order - b.order
+	})
+	return ordered
+}
+
+// validateIgnoreField validates the path of a field only set when a resource
+// is created. The fields identifying the resource can't be ignored, and the
+// path must end with a field name.
+func validateIgnoreField(path string) error {
+	segments, err := fieldpath.Parse(path)
+	if err != nil {
+		return fmt.Errorf("failed to parse path %q: %w", path, err)
+	}
+	if len(segments) == 0 || segments[len(segments)-1].Index != -1 {
+		return fmt.Errorf("path %q must end with a field name", path)
+	}
+	switch fieldpath.Build(segments[:min(len(segments), 2)]) {
+	case "apiVersion", "kind", "metadata", "metadata.name", "metadata.namespace":
+		return fmt.Errorf("path %q refers to a field identifying the resource", path)
+	}
+	return nil
+}
+
+// parseConditions parses the readyWhen or includeWhen conditions of the
+// resource with the given id and order, field being the name of the list.
+func parseConditions(id string, order int, field string, conditions []string) ([]string, error) {
+	expressions := make([]string, 0, len(conditions))
+	for i, condition := range conditions {
+		parsed, err := parser.ParseConditionExpressions([]string{condition})
+		if err != nil {
+			return nil, &BuildError{
+				Code:       ErrorCodeInvalidExpression,
+				ResourceID: id,
+				Path:       resourcePath(order, fmt.Sprintf("%s[%d]", field, i)),
+				Expression: condition,
+				Err:        fmt.Errorf("failed to parse %s expressions: %v", field, err),
+			}
+		}
+		expressions = append(expressions, parsed...)
+	}
+	return expressions, nil
+}
+
 // buildInstanceResource builds the instance resource. The instance resource is
 // the representation of the CR that users will create in their cluster to request
 // the creation of the resources defined in the resource graph definition.
@@ -455,9 +830,11 @@ func (b *Builder) buildDependencyGraph(
 // Since instances are defined using the "SimpleSchema" format, we use a different
 // approach to build the instance resource. We need to:
 func (b *Builder) buildInstanceResource(
+	ctx context.Context,
 	group, apiVersion, kind string,
 	rgDefinition *v1alpha1.Schema,
 	resources map[string]*Resource,
+	config map[string]string,
 ) (*Resource, error) {
 	// The instance resource is the resource users will create in their cluster,
 	// to request the creation of the resources defined in the resource graph definition.
@@ -470,15 +847,29 @@ func (b *Builder) buildInstanceResource(
 	// The instance resource is a Kubernetes resource, so it has a GroupVersionKind.
 	gvk := metadata.GetResourceGraphDefinitionInstanceGVK(group, apiVersion, kind)
 
+	// The instance schema can import custom types from TypeLibraries, which
+	// need to be resolved before the SimpleSchema can be transformed.
+	importedTypes, err := resolveImportedTypes(ctx, b.typeLibraryResolver, rgDefinition.Imports)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve imported types: %w", err)
+	}
+
 	// The instance resource has a schema defined using the "SimpleSchema" format.
-	instanceSpecSchema, err := buildInstanceSpecSchema(rgDefinition)
+	instanceSpecSchema, err := buildInstanceSpecSchema(rgDefinition, importedTypes)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build OpenAPI schema for instance: %w", err)
 	}
 
-	instanceStatusSchema, statusVariables, err := buildStatusSchema(rgDefinition, resources)
+	instanceStatusSchema, statusVariables, err := buildStatusSchema(rgDefinition, resources, config, b.costLimits)
 	if err != nil {
-		return nil, fmt.Errorf("failed to build OpenAPI schema for instance status: %w", err)
+		if buildErrs, ok := err.(BuildErrors); ok {
+			return nil, buildErrs
+		}
+		return nil, &BuildError{
+			Code: ErrorCodeInvalidSchema,
+			Path: "spec.schema.status",
+			Err:  fmt.Errorf("failed to build OpenAPI schema for instance status: %w", err),
+		}
 	}
 
 	// Synthesize the CRD for the instance resource.
@@ -497,10 +888,11 @@ func (b *Builder) buildInstanceResource(
 	}
 
 	resourceNames := maps.Keys(resources)
-	env, err := krocel.DefaultEnvironment(krocel.WithResourceIDs(resourceNames))
+	env, err := krocel.DefaultEnvironment(krocel.WithResourceIDs(resourceNames), krocel.WithConfig())
 	if err != nil {
 		return nil, fmt.Errorf("failed to create CEL environment: %w", err)
 	}
+	resourceNames = append(resourceNames, krocel.KroVariable)
 
 	// The instance resource has a set of variables that need to be resolved.
 	instance := &Resource{
@@ -522,7 +914,12 @@ func (b *Builder) buildInstanceResource(
 			return nil, fmt.Errorf("failed to extract dependencies: %w", err)
 		}
 		if isStatic {
-			return nil, fmt.Errorf("instance status field must refer to a resource: %s", statusVariable.Path)
+			return nil, &BuildError{
+				Code:       ErrorCodeInvalidExpression,
+				Path:       "spec.schema." + statusVariable.Path,
+				Expression: statusVariable.Expressions[0],
+				Err:        fmt.Errorf("instance status field must refer to a resource: %s", statusVariable.Path),
+			}
 		}
 		instance.addDependencies(instanceDependencies...)
 
@@ -537,10 +934,34 @@ func (b *Builder) buildInstanceResource(
 	return instance, nil
 }
 
+// SynthesizeInstanceCRD synthesizes the CRD of the instances of a resource
+// graph definition from its schema alone, without a cluster. The resources
+// aren't resolved, so the status of the instances only has the fields common
+// to all the instances. The schema can't import types from TypeLibraries,
+// which are read from the cluster.
+func SynthesizeInstanceCRD(rgd *v1alpha1.ResourceGraphDefinition) (*extv1.CustomResourceDefinition, error) {
+	importedTypes, err := resolveImportedTypes(context.Background(), nil, rgd.Spec.Schema.Imports)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve imported types: %w", err)
+	}
+	instanceSpecSchema, err := buildInstanceSpecSchema(rgd.Spec.Schema, importedTypes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build OpenAPI schema for instance: %w", err)
+	}
+	instanceStatusSchema := extv1.JSONSchemaProps{Type: "object"}
+	return crd.SynthesizeCRD(
+		rgd.Spec.Schema.Group, rgd.Spec.Schema.APIVersion, rgd.Spec.Schema.Kind,
+		*instanceSpecSchema, instanceStatusSchema, true, rgd.Spec.Schema.AdditionalPrinterColumns,
+	), nil
+}
+
 // buildInstanceSpecSchema builds the instance spec schema that will be
 // used to generate the CRD for the instance resource. The instance spec
 // schema is expected to be defined using the "SimpleSchema" format.
-func buildInstanceSpecSchema(rgSchema *v1alpha1.Schema) (*extv1.JSONSchemaProps, error) {
+func buildInstanceSpecSchema(
+	rgSchema *v1alpha1.Schema,
+	importedTypes []map[string]interface{},
+) (*extv1.JSONSchemaProps, error) {
 	// We need to unmarshal the instance schema to a map[string]interface{} to
 	// make it easier to work with.
 	instanceSpec := map[string]interface{}{}
@@ -558,7 +979,7 @@ func buildInstanceSpecSchema(rgSchema *v1alpha1.Schema) (*extv1.JSONSchemaProps,
 	}
 
 	// The instance resource has a schema defined using the "SimpleSchema" format.
-	instanceSchema, err := simpleschema.ToOpenAPISpec(instanceSpec, customTypes)
+	instanceSchema, err := simpleschema.ToOpenAPISpec(instanceSpec, customTypes, importedTypes...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build OpenAPI schema for instance: %v", err)
 	}
@@ -580,6 +1001,8 @@ func buildInstanceSpecSchema(rgSchema *v1alpha1.Schema) (*extv1.JSONSchemaProps,
 func buildStatusSchema(
 	rgSchema *v1alpha1.Schema,
 	resources map[string]*Resource,
+	config map[string]string,
+	costLimits krocel.CostLimits,
 ) (
 	*extv1.JSONSchemaProps,
 	[]variable.FieldDescriptor,
@@ -598,39 +1021,64 @@ func buildStatusSchema(
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to extract CEL expressions from status: %w", err)
 	}
+	// Status fields are computed by expressions, the literal ones (escaped
+	// expressions only) are ignored like any other constant.
+	fieldDescriptors = slices.DeleteFunc(fieldDescriptors, func(fd variable.FieldDescriptor) bool {
+		return len(fd.Expressions) == 0
+	})
+	for _, fd := range fieldDescriptors {
+		if fd.Operation != variable.FieldOperationSet {
+			return nil, nil, fmt.Errorf("status fields only support value expressions, found a %s operation at path %s",
+				fd.Operation, fd.Path)
+		}
+	}
 
 	// Inspection of the CEL expressions to infer the types of the status fields.
 	resourceNames := maps.Keys(resources)
 
-	env, err := krocel.DefaultEnvironment(krocel.WithResourceIDs(resourceNames))
+	env, err := krocel.DefaultEnvironment(krocel.WithResourceIDs(resourceNames), krocel.WithConfig())
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create CEL environment: %w", err)
 	}
+	resourceNames = append(resourceNames, krocel.KroVariable)
+
+	// The status expressions are dry-run against the resources and the global
+	// configuration.
+	statusContext := maps.Clone(resources)
+	statusContext[krocel.KroVariable] = newConfigResource(config)
 
 	// statusStructureParts := make([]schema.FieldDescriptor, 0, len(extracted))
 	statusDryRunResults := make(map[string][]ref.Val, len(fieldDescriptors))
+	var errs BuildErrors
 	for _, found := range fieldDescriptors {
 		// For each expression in the extracted `ExpressionField` we need to dry-run
 		// the expression to infer the type of the status field.
 		evals := []ref.Val{}
+		path := "spec.schema.status." + found.Path
 		for _, expr := range found.Expressions {
 			// we need to inspect the expression to understand how it relates to the
 			// resources defined in the resource graph definition.
 			err := validateCELExpressionContext(env, expr, resourceNames)
 			if err != nil {
-				return nil, nil, fmt.Errorf("failed to validate expression context: %w", err)
+				errs = append(errs, newExpressionError("", path, expr,
+					fmt.Errorf("failed to validate expression context: %w", err), resourceNames))
+				continue
 			}
 
-			// resources is the context here.
-			value, err := dryRunExpression(env, expr, resources)
+			value, err := dryRunExpression(env, expr, statusContext, costLimits)
 			if err != nil {
-				return nil, nil, fmt.Errorf("failed to dry-run expression: %w", err)
+				errs = append(errs, newExpressionError("", path, expr,
+					fmt.Errorf("failed to dry-run expression: %w", err), resourceNames))
+				continue
 			}
 
 			evals = append(evals, value)
 		}
 		statusDryRunResults[found.Path] = evals
 	}
+	if len(errs) > 0 {
+		return nil, nil, errs
+	}
 
 	statusSchema, err := schema.GenerateSchemaFromEvals(statusDryRunResults)
 	if err != nil {
@@ -662,15 +1110,34 @@ func validateCELExpressionContext(env *cel.Env, expression string, resources []s
 // dryRunExpression executes the given CEL expression in the context of a set
 // of emulated resources. We could've called this function evaluateExpression,
 // but we chose to call it dryRunExpression to indicate that we are not
-// used for anything other than validating the expression and inspecting it
-func dryRunExpression(env *cel.Env, expression string, resources map[string]*Resource) (ref.Val, error) {
+// used for anything other than validating the expression and inspecting it.
+//
+// Expressions whose estimated cost exceeds the estimated cost limit, or whose
+// actual cost exceeds the expression cost limit, are rejected. A zero limit
+// disables its check.
+func dryRunExpression(
+	env *cel.Env,
+	expression string,
+	resources map[string]*Resource,
+	costLimits krocel.CostLimits,
+) (ref.Val, error) {
 	ast, issues := env.Compile(expression)
 	if issues != nil && issues.Err() != nil {
 		return nil, fmt.Errorf("failed to compile expression: %w", issues.Err())
 	}
 
+	schemas := map[string]*spec.Schema{}
+	for resourceName, resource := range resources {
+		if resource.schema != nil {
+			schemas[resourceName] = resource.schema
+		}
+	}
+	if err := krocel.CheckEstimatedCost(env, ast, costLimits.Estimated, schemas); err != nil {
+		return nil, fmt.Errorf("expression %s is too expensive: %w", expression, err)
+	}
+
 	// TODO(a-hilaly): thinking about a creating a library to hide this...
-	program, err := env.Program(ast)
+	program, err := env.Program(ast, krocel.CostLimitProgramOptions(costLimits.Expression)...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create program: %w", err)
 	}
@@ -683,6 +1150,9 @@ func dryRunExpression(env *cel.Env, expression string, resources map[string]*Res
 	}
 
 	output, _, err := program.Eval(context)
+	if krocel.IsCostLimitExceeded(err) {
+		return nil, fmt.Errorf("expression %s exceeded the cost limit of %d: %w", expression, costLimits.Expression, err)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to evaluate expression: %w", err)
 	}
@@ -706,7 +1176,10 @@ func extractDependencies(env *cel.Env, expression string, resourceNames []string
 	isStatic := true
 	dependencies := make([]string, 0)
 	for _, resource := range inspectionResult.ResourceDependencies {
-		if resource.ID != "schema" && !slices.Contains(dependencies, resource.ID) {
+		if resource.ID == "schema" || resource.ID == krocel.KroVariable {
+			continue
+		}
+		if !slices.Contains(dependencies, resource.ID) {
 			isStatic = false
 			dependencies = append(dependencies, resource.ID)
 		}
@@ -728,12 +1201,17 @@ func extractDependencies(env *cel.Env, expression string, resourceNames []string
 // we evaluate A's CEL expressions against 2 emulated resources B and C. Then
 // we evaluate B's CEL expressions against 2 emulated resources A and C, and so
 // on.
-func validateResourceCELExpressions(resources map[string]*Resource, instance *Resource) error {
+func validateResourceCELExpressions(
+	resources map[string]*Resource,
+	instance *Resource,
+	config map[string]string,
+	costLimits krocel.CostLimits,
+) error {
 	resourceIDs := maps.Keys(resources)
 	// We also want to allow users to refer to the instance spec in their expressions.
 	resourceIDs = append(resourceIDs, "schema")
 
-	env, err := krocel.DefaultEnvironment(krocel.WithResourceIDs(resourceIDs))
+	env, err := krocel.DefaultEnvironment(krocel.WithResourceIDs(resourceIDs), krocel.WithConfig())
 	if err != nil {
 		return fmt.Errorf("failed to create CEL environment: %w", err)
 	}
@@ -754,6 +1232,7 @@ func validateResourceCELExpressions(resources map[string]*Resource, instance *Re
 			Object: instanceEmulatedCopy.Object,
 		},
 	}
+	includeWhenContext[krocel.KroVariable] = newConfigResource(config)
 
 	// create expressionsContext
 	expressionContext := map[string]*Resource{}
@@ -763,6 +1242,7 @@ func validateResourceCELExpressions(resources map[string]*Resource, instance *Re
 			Object: instanceEmulatedCopy.Object,
 		},
 	}
+	expressionContext[krocel.KroVariable] = newConfigResource(config)
 	// include all resources, and remove individual ones
 	// during the validation
 	// this is done to avoid having to create a new context for each resource
@@ -770,41 +1250,119 @@ func validateResourceCELExpressions(resources map[string]*Resource, instance *Re
 		expressionContext[resourceName] = contextResource
 	}
 
-	for _, resource := range resources {
+	// All the expressions are validated, to report all the invalid ones at once.
+	var errs BuildErrors
+	for _, resource := range resourcesInOrder(resources) {
 		// exclude resource from the context
 		delete(expressionContext, resource.id)
 
-		err := ensureResourceExpressions(env, expressionContext, resource)
-		if err != nil {
-			return fmt.Errorf("failed to ensure resource %s expressions: %w", resource.id, err)
-		}
-
-		err = ensureReadyWhenExpressions(resource)
-		if err != nil {
-			return fmt.Errorf("failed to ensure resource %s readyWhen expressions: %w", resource.id, err)
-		}
-
-		err = ensureIncludeWhenExpressions(env, includeWhenContext, resource)
-		if err != nil {
-			return fmt.Errorf("failed to ensure resource %s includeWhen expressions: %w", resource.id, err)
-		}
+		errs = append(errs, ensureResourceExpressions(env, expressionContext, resource, costLimits)...)
+		errs = append(errs, ensureReadyWhenExpressions(resource, config, costLimits)...)
+		errs = append(errs, ensureIncludeWhenExpressions(env, includeWhenContext, resource, costLimits)...)
 
 		// include the resource back to the context
 		expressionContext[resource.id] = resource
 	}
 
-	return nil
+	return errs.errorOrNil()
 }
 
 // ensureResourceExpressions validates the CEL expressions in the resource
 // against the resources defined in the resource graph definition.
-func ensureResourceExpressions(env *cel.Env, context map[string]*Resource, resource *Resource) error {
+func ensureResourceExpressions(
+	env *cel.Env,
+	context map[string]*Resource,
+	resource *Resource,
+	costLimits krocel.CostLimits,
+) BuildErrors {
+	var errs BuildErrors
 	// We need to validate the CEL expressions in the resource.
 	for _, resourceVariable := range resource.variables {
+		path := resource.templatePath(resourceVariable.Path)
 		for _, expression := range resourceVariable.Expressions {
-			_, err := ensureExpression(env, expression, []string{resource.id}, context)
+			output, err := ensureExpression(env, expression, []string{resource.id}, context, costLimits)
+			if err != nil {
+				err = fmt.Errorf("failed to dry-run expression %s: %w", expression, err)
+			} else {
+				err = validateOperationOutput(resourceVariable.FieldDescriptor, expression, output)
+			}
+			if err != nil {
+				errs = append(errs, newExpressionError(resource.id, path, expression, err, maps.Keys(context)))
+			}
+		}
+	}
+	return errs
+}
+
+// validateOperationOutput validates the output type of the expressions of
+// structural operations: key expressions must evaluate to strings, merged
+// values to maps and spliced values to lists. The merged values and the
+// spliced items must match the schema of the values of the map and of the
+// items of the list.
+func validateOperationOutput(field variable.FieldDescriptor, expression string, output ref.Val) error {
+	// A null output is unknown at build time, or a no-op merge or splice.
+	if output == nil || output.Type() == types.NullType {
+		return nil
+	}
+	switch field.Operation {
+	case variable.FieldOperationKey:
+		if field.StandaloneExpression && !krocel.IsStringType(output) {
+			return fmt.Errorf("output of key expression %s at path %s can only be of type string",
+				expression, field.Path)
+		}
+	case variable.FieldOperationMerge:
+		if !krocel.IsMapType(output) {
+			return fmt.Errorf("output of merge expression %s at path %s can only be of type map",
+				expression, field.Path)
+		}
+		return validateOperationValues(field, expression, output)
+	case variable.FieldOperationSplice:
+		if !krocel.IsListType(output) {
+			return fmt.Errorf("output of splice expression %s at path %s can only be of type list",
+				expression, field.Path)
+		}
+		return validateOperationValues(field, expression, output)
+	}
+	return nil
+}
+
+// validateOperationValues validates the values added by the expression of a
+// merge or splice against the schema of the values of the map, or of the items
+// of the list.
+func validateOperationValues(field variable.FieldDescriptor, expression string, output ref.Val) error {
+	if field.ExpectedSchema == nil {
+		return nil
+	}
+	value, err := krocel.GoNativeType(output)
+	if err != nil {
+		return fmt.Errorf("failed to convert output of expression %s: %w", expression, err)
+	}
+
+	switch value := value.(type) {
+	case map[string]interface{}:
+		if field.ExpectedSchema.AdditionalProperties == nil {
+			return nil
+		}
+		// The values are validated at the paths they are merged to, in the
+		// map holding the merge.
+		mapPath := strings.TrimSuffix(field.Path, ".$merge")
+		keys := maps.Keys(value)
+		slices.Sort(keys)
+		for _, key := range keys {
+			err := schema.ValidateValueType(value[key], field.ExpectedSchema.AdditionalProperties.Schema,
+				mapPath+"."+key)
+			if err != nil {
+				return fmt.Errorf("output of merge expression %s doesn't match the schema: %w", expression, err)
+			}
+		}
+	case []interface{}:
+		if field.ExpectedSchema.Items == nil {
+			return nil
+		}
+		for _, item := range value {
+			err := schema.ValidateValueType(item, field.ExpectedSchema.Items.Schema, field.Path)
 			if err != nil {
-				return fmt.Errorf("failed to dry-run expression %s: %w", expression, err)
+				return fmt.Errorf("output of splice expression %s doesn't match the schema: %w", expression, err)
 			}
 		}
 	}
@@ -813,11 +1371,18 @@ func ensureResourceExpressions(env *cel.Env, context map[string]*Resource, resou
 
 // ensureReadyWhenExpressions validates the readyWhen expressions in the resource
 // against the resources defined in the resource graph definition.
-func ensureReadyWhenExpressions(resource *Resource) error {
-	env, err := krocel.DefaultEnvironment(krocel.WithResourceIDs([]string{resource.id}))
-	for _, expression := range resource.readyWhenExpressions {
+func ensureReadyWhenExpressions(resource *Resource, config map[string]string, costLimits krocel.CostLimits) BuildErrors {
+	var errs BuildErrors
+	env, err := krocel.DefaultEnvironment(krocel.WithResourceIDs([]string{resource.id}), krocel.WithConfig())
+	for i, expression := range resource.readyWhenExpressions {
+		path := resourcePath(resource.order, fmt.Sprintf("readyWhen[%d]", i))
 		if err != nil {
-			return fmt.Errorf("failed to create CEL environment: %w", err)
+			return BuildErrors{{
+				Code:       ErrorCodeInvalidExpression,
+				ResourceID: resource.id,
+				Path:       path,
+				Err:        fmt.Errorf("failed to create CEL environment: %w", err),
+			}}
 		}
 
 		resourceEmulatedCopy := resource.emulatedObject.DeepCopy()
@@ -830,41 +1395,74 @@ func ensureReadyWhenExpressions(resource *Resource) error {
 		context[resource.id] = &Resource{
 			emulatedObject: resourceEmulatedCopy,
 		}
+		context[krocel.KroVariable] = newConfigResource(config)
 
-		output, err := ensureExpression(env, expression, []string{resource.id}, context)
+		output, err := ensureExpression(env, expression, []string{resource.id}, context, costLimits)
 		if err != nil {
-			return fmt.Errorf("failed to dry-run expression %s: %w", expression, err)
+			errs = append(errs, newExpressionError(resource.id, path, expression,
+				fmt.Errorf("failed to dry-run expression %s: %w", expression, err), maps.Keys(context)))
+			continue
 		}
 		if !krocel.IsBoolType(output) {
-			return fmt.Errorf("output of readyWhen expression %s can only be of type bool", expression)
+			errs = append(errs, newExpressionError(resource.id, path, expression,
+				fmt.Errorf("output of readyWhen expression %s can only be of type bool", expression), nil))
 		}
 	}
-	return nil
+	return errs
 }
 
 // ensureIncludeWhenExpressions validates the includeWhen expressions in the resource
-func ensureIncludeWhenExpressions(env *cel.Env, context map[string]*Resource, resource *Resource) error {
+func ensureIncludeWhenExpressions(
+	env *cel.Env,
+	context map[string]*Resource,
+	resource *Resource,
+	costLimits krocel.CostLimits,
+) BuildErrors {
+	var errs BuildErrors
 	// We need to validate the CEL expressions in the resource.
-	for _, expression := range resource.includeWhenExpressions {
-		output, err := ensureExpression(env, expression, []string{resource.id}, context)
+	for i, expression := range resource.includeWhenExpressions {
+		path := resourcePath(resource.order, fmt.Sprintf("includeWhen[%d]", i))
+		output, err := ensureExpression(env, expression, []string{resource.id}, context, costLimits)
 		if err != nil {
-			return fmt.Errorf("failed to dry-run expression %s: %w", expression, err)
+			errs = append(errs, newExpressionError(resource.id, path, expression,
+				fmt.Errorf("failed to dry-run expression %s: %w", expression, err), maps.Keys(context)))
+			continue
 		}
 		if !krocel.IsBoolType(output) {
-			return fmt.Errorf("output of includeWhen expression %s can only be of type bool", expression)
+			errs = append(errs, newExpressionError(resource.id, path, expression,
+				fmt.Errorf("output of includeWhen expression %s can only be of type bool", expression), nil))
 		}
 	}
-	return nil
+	return errs
+}
+
+// newConfigResource returns a pseudo resource holding the global configuration
+// values under its config field, used as the kro variable when dry-running
+// expressions.
+func newConfigResource(config map[string]string) *Resource {
+	object := make(map[string]interface{}, len(config))
+	for key, value := range config {
+		object[key] = value
+	}
+	return &Resource{
+		emulatedObject: &unstructured.Unstructured{Object: map[string]interface{}{"config": object}},
+	}
 }
 
 // ensureExpression validates the CEL expression in the context of the resources
-func ensureExpression(env *cel.Env, expression string, resources []string, context map[string]*Resource) (ref.Val, error) {
+func ensureExpression(
+	env *cel.Env,
+	expression string,
+	resources []string,
+	context map[string]*Resource,
+	costLimits krocel.CostLimits,
+) (ref.Val, error) {
 	err := validateCELExpressionContext(env, expression, resources)
 	if err != nil {
 		return nil, fmt.Errorf("failed to validate expression %s: %w", expression, err)
 	}
 
-	output, err := dryRunExpression(env, expression, context)
+	output, err := dryRunExpression(env, expression, context, costLimits)
 	if err != nil {
 		return nil, fmt.Errorf("failed to dry-run expression %s: %w", expression, err)
 	}
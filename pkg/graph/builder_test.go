@@ -15,14 +15,26 @@
 package graph
 
 import (
+	"context"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/maps"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/rest"
+	"k8s.io/utils/ptr"
 
+	"github.com/kubernetes-sigs/kro/api/v1alpha1"
+	krocel "github.com/kubernetes-sigs/kro/pkg/cel"
+	"github.com/kubernetes-sigs/kro/pkg/globalconfig"
 	"github.com/kubernetes-sigs/kro/pkg/graph/emulator"
+	"github.com/kubernetes-sigs/kro/pkg/graph/schema/offline"
 	"github.com/kubernetes-sigs/kro/pkg/graph/variable"
+	"github.com/kubernetes-sigs/kro/pkg/metadata"
 	"github.com/kubernetes-sigs/kro/pkg/testutil/generator"
 	"github.com/kubernetes-sigs/kro/pkg/testutil/k8s"
 	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
@@ -229,7 +241,7 @@ func TestGraphBuilder_Validation(t *testing.T) {
 				}, nil, nil),
 			},
 			wantErr: true,
-			errMsg:  "failed to validate resource CEL expression",
+			errMsg:  "undeclared reference to 'vpc'",
 		},
 		{
 			name: "valid VPC with valid conditional subnets",
@@ -452,7 +464,7 @@ func TestGraphBuilder_Validation(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			rgd := generator.NewResourceGraphDefinition("test-group", tt.resourceGraphDefinitionOpts...)
-			_, err := builder.NewResourceGraphDefinition(rgd)
+			_, err := builder.NewResourceGraphDefinition(context.Background(), rgd)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -1031,7 +1043,7 @@ func TestGraphBuilder_DependencyValidation(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			rgd := generator.NewResourceGraphDefinition("testrgd", tt.resourceGraphDefinitionOpts...)
-			g, err := builder.NewResourceGraphDefinition(rgd)
+			g, err := builder.NewResourceGraphDefinition(context.Background(), rgd)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -1364,7 +1376,7 @@ func TestGraphBuilder_ExpressionParsing(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			rgd := generator.NewResourceGraphDefinition("testrgd", tt.resourceGraphDefinitionOpts...)
-			g, err := builder.NewResourceGraphDefinition(rgd)
+			g, err := builder.NewResourceGraphDefinition(context.Background(), rgd)
 			require.NoError(t, err)
 			if tt.validateVars != nil {
 				tt.validateVars(t, g)
@@ -1401,6 +1413,75 @@ func TestNewBuilder(t *testing.T) {
 	builder, err := NewBuilder(&rest.Config{})
 	assert.Nil(t, err)
 	assert.NotNil(t, builder)
+
+	_, err = NewBuilder(nil)
+	assert.Error(t, err)
+
+	fakeResolver, fakeDiscovery := k8s.NewFakeResolver()
+	builder, err = NewBuilder(nil, WithSchemaResolver(fakeResolver, fakeDiscovery))
+	require.NoError(t, err)
+	assert.Equal(t, fakeResolver, builder.SchemaResolver())
+	assert.Nil(t, builder.typeLibraryResolver)
+}
+
+func TestNewOfflineBuilder(t *testing.T) {
+	local := offline.NewLocalResolver()
+	builder := NewOfflineBuilder(local, local)
+
+	deployment := map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"name": "${schema.spec.name}",
+		},
+		"spec": map[string]interface{}{
+			"replicas": "${schema.spec.replicas}",
+			"selector": map[string]interface{}{
+				"matchLabels": map[string]interface{}{"app": "${schema.spec.name}"},
+			},
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"labels": map[string]interface{}{"app": "${schema.spec.name}"},
+				},
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{"name": "app", "image": "${schema.spec.image}"},
+					},
+				},
+			},
+		},
+	}
+
+	t.Run("builds with the built-in schemas", func(t *testing.T) {
+		g, err := builder.NewResourceGraphDefinition(context.Background(), generator.NewResourceGraphDefinition("test-group",
+			generator.WithSchema("Test", "v1alpha1", map[string]interface{}{
+				"name":     "string",
+				"image":    "string",
+				"replicas": "integer",
+			}, map[string]interface{}{
+				"availableReplicas": "${deployment.status.availableReplicas}",
+			}),
+			generator.WithResource("deployment", deployment,
+				[]string{"${deployment.spec.replicas == deployment.status.availableReplicas}"}, nil),
+		))
+		require.NoError(t, err)
+		require.Contains(t, g.Resources, "deployment")
+		assert.True(t, g.Resources["deployment"].IsNamespaced())
+	})
+
+	t.Run("reports the unknown kinds", func(t *testing.T) {
+		_, err := builder.NewResourceGraphDefinition(context.Background(), generator.NewResourceGraphDefinition("test-group",
+			generator.WithSchema("Test", "v1alpha1", map[string]interface{}{"name": "string"}, nil),
+			generator.WithResource("widget", map[string]interface{}{
+				"apiVersion": "example.com/v1",
+				"kind":       "Widget",
+				"metadata":   map[string]interface{}{"name": "${schema.spec.name}"},
+			}, nil, nil),
+		))
+		var buildErrs BuildErrors
+		require.ErrorAs(t, err, &buildErrs)
+		assert.Equal(t, ErrorCodeUnknownKind, buildErrs[0].Code)
+	})
 }
 
 func Test_ValidateOpenAPISchema(t *testing.T) {
@@ -1442,7 +1523,7 @@ func Test_ValidateOpenAPISchema(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			rgd := generator.NewResourceGraphDefinition("testrgd", tt.resourceGraphDefinitionOpts...)
-			g, err := builder.NewResourceGraphDefinition(rgd)
+			g, err := builder.NewResourceGraphDefinition(context.Background(), rgd)
 			require.NoError(t, err)
 			require.Len(t, g.Instance.crd.Spec.Versions, 1)
 			require.NotNil(t, g.Instance.crd.Spec.Versions[0].Schema.OpenAPIV3Schema)
@@ -1450,3 +1531,1080 @@ func Test_ValidateOpenAPISchema(t *testing.T) {
 		})
 	}
 }
+
+type fakeTypeLibraryResolver map[string]*v1alpha1.TypeLibrary
+
+func (f fakeTypeLibraryResolver) ResolveTypeLibrary(_ context.Context, name string) (*v1alpha1.TypeLibrary, error) {
+	library, ok := f[name]
+	if !ok {
+		return nil, fmt.Errorf("type library %s not found", name)
+	}
+	return library, nil
+}
+
+func TestGraphBuilder_TypeLibraryImports(t *testing.T) {
+	fakeResolver, fakeDiscovery := k8s.NewFakeResolver()
+	builder := &Builder{
+		schemaResolver:   fakeResolver,
+		discoveryClient:  fakeDiscovery,
+		resourceEmulator: emulator.NewEmulator(),
+		typeLibraryResolver: fakeTypeLibraryResolver{
+			"common": {
+				ObjectMeta: metav1.ObjectMeta{Name: "common"},
+				Spec: v1alpha1.TypeLibrarySpec{
+					Version: "v1",
+					Types: runtime.RawExtension{
+						Raw: []byte(`{"Container": {"image": "string | required=true", "port": "integer"}}`),
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name                        string
+		resourceGraphDefinitionOpts []generator.ResourceGraphDefinitionOption
+		wantErr                     bool
+		errMsg                      string
+		validateFunc                func(t *testing.T, schema *extv1.JSONSchemaProps)
+	}{
+		{
+			name: "imported type used in spec",
+			resourceGraphDefinitionOpts: []generator.ResourceGraphDefinitionOption{
+				generator.WithSchema(
+					"Test", "v1alpha1",
+					map[string]interface{}{
+						"containers": "[]Container",
+					},
+					nil,
+				),
+				generator.WithTypeImport("common", "v1"),
+			},
+			validateFunc: func(t *testing.T, schema *extv1.JSONSchemaProps) {
+				containers := schema.Properties["spec"].Properties["containers"]
+				require.NotNil(t, containers.Items)
+				assert.Equal(t, []string{"image"}, containers.Items.Schema.Required)
+				assert.Contains(t, containers.Items.Schema.Properties, "port")
+			},
+		},
+		{
+			name: "imported type without a pinned version",
+			resourceGraphDefinitionOpts: []generator.ResourceGraphDefinitionOption{
+				generator.WithSchema(
+					"Test", "v1alpha1",
+					map[string]interface{}{
+						"container": "Container",
+					},
+					nil,
+				),
+				generator.WithTypeImport("common", ""),
+			},
+			validateFunc: func(t *testing.T, schema *extv1.JSONSchemaProps) {
+				assert.Contains(t, schema.Properties["spec"].Properties, "container")
+			},
+		},
+		{
+			name: "version mismatch",
+			resourceGraphDefinitionOpts: []generator.ResourceGraphDefinitionOption{
+				generator.WithSchema(
+					"Test", "v1alpha1",
+					map[string]interface{}{
+						"container": "Container",
+					},
+					nil,
+				),
+				generator.WithTypeImport("common", "v2"),
+			},
+			wantErr: true,
+			errMsg:  "but version \"v2\" is imported",
+		},
+		{
+			name: "unknown type library",
+			resourceGraphDefinitionOpts: []generator.ResourceGraphDefinitionOption{
+				generator.WithSchema(
+					"Test", "v1alpha1",
+					map[string]interface{}{
+						"container": "Container",
+					},
+					nil,
+				),
+				generator.WithTypeImport("unknown", ""),
+			},
+			wantErr: true,
+			errMsg:  "failed to resolve type library unknown",
+		},
+		{
+			name: "imported type without import",
+			resourceGraphDefinitionOpts: []generator.ResourceGraphDefinitionOption{
+				generator.WithSchema(
+					"Test", "v1alpha1",
+					map[string]interface{}{
+						"container": "Container",
+					},
+					nil,
+				),
+			},
+			wantErr: true,
+			errMsg:  "unknown type: Container",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rgd := generator.NewResourceGraphDefinition("testrgd", tt.resourceGraphDefinitionOpts...)
+			g, err := builder.NewResourceGraphDefinition(context.Background(), rgd)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			tt.validateFunc(t, g.Instance.crd.Spec.Versions[0].Schema.OpenAPIV3Schema)
+		})
+	}
+}
+
+func TestGraphBuilder_RGDComposition(t *testing.T) {
+	fakeResolver, fakeDiscovery := k8s.NewFakeResolver()
+	builder := &Builder{
+		schemaResolver:   fakeResolver,
+		discoveryClient:  fakeDiscovery,
+		resourceEmulator: emulator.NewEmulator(),
+		registry:         NewRegistry(),
+	}
+
+	databaseGVK := metadata.GetResourceGraphDefinitionInstanceGVK("kro.run", "v1alpha1", "Database")
+	database := generator.NewResourceGraphDefinition("database",
+		generator.WithSchema(
+			"Database", "v1alpha1",
+			map[string]interface{}{
+				"engine": "string",
+			},
+			map[string]interface{}{
+				"endpoint": "${vpc.status.vpcID}",
+			},
+		),
+		generator.WithResource("vpc", map[string]interface{}{
+			"apiVersion": "ec2.services.k8s.aws/v1alpha1",
+			"kind":       "VPC",
+			"metadata": map[string]interface{}{
+				"name": "${schema.spec.engine}",
+			},
+		}, nil, nil),
+	)
+	database.Spec.Schema.Group = "kro.run"
+
+	application := func(readyWhen []string) *v1alpha1.ResourceGraphDefinition {
+		return generator.NewResourceGraphDefinition("application",
+			generator.WithSchema(
+				"Application", "v1alpha1",
+				map[string]interface{}{
+					"name": "string",
+				},
+				nil,
+			),
+			generator.WithResource("database", map[string]interface{}{
+				"apiVersion": "kro.run/v1alpha1",
+				"kind":       "Database",
+				"metadata": map[string]interface{}{
+					"name": "${schema.spec.name}",
+				},
+				"spec": map[string]interface{}{
+					"engine": "postgres",
+				},
+			}, readyWhen, nil),
+			generator.WithResource("subnet", map[string]interface{}{
+				"apiVersion": "ec2.services.k8s.aws/v1alpha1",
+				"kind":       "Subnet",
+				"metadata": map[string]interface{}{
+					"name": "${database.status.endpoint}",
+				},
+				"spec": map[string]interface{}{
+					"cidrBlock": "10.0.0.0/24",
+					"vpcID":     "${database.status.endpoint}",
+				},
+			}, nil, nil),
+		)
+	}
+
+	t.Run("waits for the providing rgd", func(t *testing.T) {
+		builder.registry.Declare("database", databaseGVK)
+		_, err := builder.NewResourceGraphDefinition(context.Background(), application(nil))
+		require.Error(t, err)
+		var dependencyErr *RGDDependencyError
+		require.ErrorAs(t, err, &dependencyErr)
+		assert.Equal(t, "database", dependencyErr.RGDName)
+		assert.Equal(t, databaseGVK, dependencyErr.GVK)
+	})
+
+	t.Run("resolves the schema from the providing rgd", func(t *testing.T) {
+		databaseGraph, err := builder.NewResourceGraphDefinition(context.Background(), database)
+		require.NoError(t, err)
+		builder.registry.Register("database", databaseGraph)
+
+		g, err := builder.NewResourceGraphDefinition(context.Background(), application(nil))
+		require.NoError(t, err)
+		assert.Equal(t, "database", g.Resources["database"].GetDependsOnRGD())
+		assert.True(t, g.Resources["database"].IsNamespaced())
+		assert.Equal(t, []string{`database.status.state == "ACTIVE"`}, g.Resources["database"].GetReadyWhenExpressions())
+		assert.Equal(t, []string{"database", "subnet"}, g.TopologicalOrder)
+	})
+
+	t.Run("keeps user defined readyWhen", func(t *testing.T) {
+		g, err := builder.NewResourceGraphDefinition(context.Background(), application([]string{"${database.status.endpoint != ''}"}))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"database.status.endpoint != ''"}, g.Resources["database"].GetReadyWhenExpressions())
+	})
+
+	t.Run("rejects its own instance kind", func(t *testing.T) {
+		builder.registry.Declare("application", metadata.GetResourceGraphDefinitionInstanceGVK("kro.run", "v1alpha1", "Application"))
+		rgd := generator.NewResourceGraphDefinition("application",
+			generator.WithSchema("Application", "v1alpha1", map[string]interface{}{"name": "string"}, nil),
+			generator.WithResource("child", map[string]interface{}{
+				"apiVersion": "kro.run/v1alpha1",
+				"kind":       "Application",
+				"metadata": map[string]interface{}{
+					"name": "child",
+				},
+			}, nil, nil),
+		)
+		_, err := builder.NewResourceGraphDefinition(context.Background(), rgd)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "can't use its own instance kind")
+	})
+
+	t.Run("deregister removes the kind", func(t *testing.T) {
+		builder.registry.Deregister("database")
+		_, _, ok := builder.registry.Lookup(databaseGVK)
+		assert.False(t, ok)
+	})
+}
+
+func TestGraphBuilder_RGDCompositionCycle(t *testing.T) {
+	fakeResolver, fakeDiscovery := k8s.NewFakeResolver()
+	builder := &Builder{
+		schemaResolver:   fakeResolver,
+		discoveryClient:  fakeDiscovery,
+		resourceEmulator: emulator.NewEmulator(),
+		registry:         NewRegistry(),
+	}
+	var notified [][]string
+	builder.registry.OnChange(func(rgdNames []string) {
+		notified = append(notified, rgdNames)
+	})
+
+	// composite returns a resource graph definition providing the given kind,
+	// and using the other one.
+	composite := func(name, kind, usedKind string) *v1alpha1.ResourceGraphDefinition {
+		rgd := generator.NewResourceGraphDefinition(name,
+			generator.WithSchema(kind, "v1alpha1", map[string]interface{}{"name": "string"}, nil),
+			generator.WithResource("child", map[string]interface{}{
+				"apiVersion": "kro.run/v1alpha1",
+				"kind":       usedKind,
+				"metadata": map[string]interface{}{
+					"name": "${schema.spec.name}",
+				},
+			}, nil, nil),
+		)
+		rgd.Spec.Schema.Group = "kro.run"
+		builder.registry.Declare(name, metadata.GetResourceGraphDefinitionInstanceGVK("kro.run", "v1alpha1", kind))
+		return rgd
+	}
+	frontend := composite("frontend", "Frontend", "Backend")
+	backend := composite("backend", "Backend", "Frontend")
+
+	_, err := builder.NewResourceGraphDefinition(context.Background(), frontend)
+	require.Error(t, err)
+	assert.True(t, IsWaitingForRGD(err))
+
+	_, err = builder.NewResourceGraphDefinition(context.Background(), backend)
+	require.Error(t, err)
+	assert.False(t, IsWaitingForRGD(err))
+	var cycleErr *RGDCycleError
+	require.ErrorAs(t, err, &cycleErr)
+	assert.Equal(t, []string{"backend", "frontend"}, cycleErr.Cycle)
+	var buildErrs BuildErrors
+	require.ErrorAs(t, err, &buildErrs)
+	assert.Equal(t, ErrorCodeDependencyCycle, buildErrs[0].Code)
+	// frontend waits for backend, and is told to report the cycle as well.
+	assert.Equal(t, [][]string{{"frontend"}}, notified)
+
+	_, err = builder.NewResourceGraphDefinition(context.Background(), frontend)
+	require.ErrorAs(t, err, &cycleErr)
+	assert.Equal(t, []string{"frontend", "backend"}, cycleErr.Cycle)
+	// The cycle is already known, nobody is notified again.
+	assert.Len(t, notified, 1)
+}
+
+func TestGraphBuilder_GlobalConfig(t *testing.T) {
+	fakeResolver, fakeDiscovery := k8s.NewFakeResolver()
+	builder := &Builder{
+		schemaResolver:   fakeResolver,
+		discoveryClient:  fakeDiscovery,
+		resourceEmulator: emulator.NewEmulator(),
+		globalConfig: globalconfig.NewStore(map[string]string{
+			"clusterName": "dev",
+		}),
+	}
+
+	rgd := func(expression string) *v1alpha1.ResourceGraphDefinition {
+		return generator.NewResourceGraphDefinition("test-group",
+			generator.WithSchema(
+				"Test", "v1alpha1",
+				map[string]interface{}{
+					"name": "string",
+				},
+				map[string]interface{}{
+					"vpcID":   "${vpc.status.vpcID}",
+					"cluster": "${vpc.status.vpcID + kro.config.clusterName}",
+				},
+			),
+			generator.WithResource("vpc", map[string]interface{}{
+				"apiVersion": "ec2.services.k8s.aws/v1alpha1",
+				"kind":       "VPC",
+				"metadata": map[string]interface{}{
+					"name": expression,
+				},
+				"spec": map[string]interface{}{
+					"cidrBlocks": []interface{}{"192.168.0.0/16"},
+				},
+			}, nil, []string{"${kro.config.clusterName == 'dev'}"}),
+			generator.WithResource("subnet", map[string]interface{}{
+				"apiVersion": "ec2.services.k8s.aws/v1alpha1",
+				"kind":       "Subnet",
+				"metadata": map[string]interface{}{
+					"name": "${kro.config.clusterName}-subnet",
+				},
+				"spec": map[string]interface{}{
+					"cidrBlock": "10.0.0.0/24",
+					"vpcID":     "${vpc.status.vpcID}",
+				},
+			}, nil, nil),
+		)
+	}
+
+	t.Run("config is not a dependency", func(t *testing.T) {
+		g, err := builder.NewResourceGraphDefinition(context.Background(), rgd("${schema.spec.name}-${kro.config.clusterName}"))
+		require.NoError(t, err)
+		assert.Empty(t, g.Resources["vpc"].GetDependencies())
+		assert.Equal(t, []string{"vpc"}, g.Resources["subnet"].GetDependencies())
+		assert.Equal(t, []string{"vpc"}, g.Instance.GetDependencies())
+	})
+
+	t.Run("missing config key", func(t *testing.T) {
+		_, err := builder.NewResourceGraphDefinition(context.Background(), rgd("${kro.config.accountID}"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no such key: accountID")
+	})
+
+	t.Run("config values are strings", func(t *testing.T) {
+		_, err := builder.NewResourceGraphDefinition(context.Background(), rgd("${string(kro.config.clusterName + 1)}"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no matching overload")
+	})
+
+	t.Run("config can be a resource id", func(t *testing.T) {
+		g, err := builder.NewResourceGraphDefinition(context.Background(), generator.NewResourceGraphDefinition("test-group",
+			generator.WithSchema("Test", "v1alpha1", map[string]interface{}{}, map[string]interface{}{
+				"configName": "${config.metadata.name}",
+			}),
+			generator.WithResource("config", map[string]interface{}{
+				"apiVersion": "ec2.services.k8s.aws/v1alpha1",
+				"kind":       "VPC",
+				"metadata": map[string]interface{}{
+					"name": "${kro.config.clusterName}-config",
+				},
+				"spec": map[string]interface{}{
+					"cidrBlocks": []interface{}{"192.168.0.0/16"},
+				},
+			}, nil, nil),
+		))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"config"}, g.Instance.GetDependencies())
+	})
+
+	t.Run("kro can't be a resource id", func(t *testing.T) {
+		_, err := builder.NewResourceGraphDefinition(context.Background(), generator.NewResourceGraphDefinition("test-group",
+			generator.WithSchema("Test", "v1alpha1", map[string]interface{}{}, nil),
+			generator.WithResource("kro", map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"metadata": map[string]interface{}{
+					"name": "kro",
+				},
+			}, nil, nil),
+		))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "reserved keyword")
+	})
+}
+
+func TestGraphBuilder_CostLimits(t *testing.T) {
+	fakeResolver, fakeDiscovery := k8s.NewFakeResolver()
+	builder := &Builder{
+		schemaResolver:   fakeResolver,
+		discoveryClient:  fakeDiscovery,
+		resourceEmulator: emulator.NewEmulator(),
+		costLimits:       krocel.DefaultCostLimits(),
+	}
+
+	rgd := func(name, readyWhen string) *v1alpha1.ResourceGraphDefinition {
+		return generator.NewResourceGraphDefinition("test-group",
+			generator.WithSchema(
+				"Test", "v1alpha1",
+				map[string]interface{}{
+					"name":  "string",
+					"zones": "[]string",
+				},
+				nil,
+			),
+			generator.WithResource("vpc", map[string]interface{}{
+				"apiVersion": "ec2.services.k8s.aws/v1alpha1",
+				"kind":       "VPC",
+				"metadata": map[string]interface{}{
+					"name": name,
+				},
+				"spec": map[string]interface{}{
+					"cidrBlocks": []interface{}{"192.168.0.0/16"},
+				},
+			}, []string{readyWhen}, nil),
+		)
+	}
+
+	t.Run("expressions within the limit", func(t *testing.T) {
+		g, err := builder.NewResourceGraphDefinition(context.Background(), rgd(
+			"${schema.spec.name + '-' + schema.spec.zones.join('-')}",
+			"${vpc.status.state == 'available'}",
+		))
+		require.NoError(t, err)
+		assert.Equal(t, krocel.DefaultCostLimits(), g.costLimits)
+	})
+
+	t.Run("expensive resource expression", func(t *testing.T) {
+		expression := "string(schema.spec.zones.map(a, schema.spec.zones.map(b, schema.spec.zones.map(c, a + b + c))).size())"
+		_, err := builder.NewResourceGraphDefinition(context.Background(), rgd("${"+expression+"}", "${vpc.status.state == 'available'}"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "expression "+expression+" is too expensive")
+		assert.Contains(t, err.Error(), "exceeds the cost limit of 1000000000")
+	})
+
+	t.Run("nested comprehension", func(t *testing.T) {
+		expression := "schema.spec.zones.all(a, vpc.spec.cidrBlocks.exists(b, a == b))"
+		_, err := builder.NewResourceGraphDefinition(context.Background(), generator.NewResourceGraphDefinition("test-group",
+			generator.WithSchema(
+				"Test", "v1alpha1",
+				map[string]interface{}{
+					"zones": "[]string",
+				},
+				nil,
+			),
+			generator.WithResource("vpc", map[string]interface{}{
+				"apiVersion": "ec2.services.k8s.aws/v1alpha1",
+				"kind":       "VPC",
+				"metadata": map[string]interface{}{
+					"name": "vpc",
+				},
+				"spec": map[string]interface{}{
+					"cidrBlocks": []interface{}{"192.168.0.0/16"},
+				},
+			}, nil, nil),
+			generator.WithResource("subnet", map[string]interface{}{
+				"apiVersion": "ec2.services.k8s.aws/v1alpha1",
+				"kind":       "Subnet",
+				"metadata": map[string]interface{}{
+					"name": "${string(" + expression + ")}",
+				},
+				"spec": map[string]interface{}{
+					"cidrBlock": "10.0.0.0/24",
+				},
+			}, nil, nil),
+		))
+		require.NoError(t, err)
+	})
+
+	t.Run("expensive readyWhen expression", func(t *testing.T) {
+		expression := "vpc.status.subnets.all(a, vpc.status.subnets.all(b, vpc.status.subnets.all(c, a != b || b != c)))"
+		_, err := builder.NewResourceGraphDefinition(context.Background(), rgd("${schema.spec.name}", "${"+expression+"}"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "expression "+expression+" is too expensive")
+	})
+}
+
+func TestGraphBuilder_Programs(t *testing.T) {
+	fakeResolver, fakeDiscovery := k8s.NewFakeResolver()
+	builder := &Builder{
+		schemaResolver:   fakeResolver,
+		discoveryClient:  fakeDiscovery,
+		resourceEmulator: emulator.NewEmulator(),
+	}
+
+	g, err := builder.NewResourceGraphDefinition(context.Background(), generator.NewResourceGraphDefinition("test-group",
+		generator.WithSchema(
+			"Test", "v1alpha1",
+			map[string]interface{}{
+				"name":      "string",
+				"enableVPC": "boolean",
+			},
+			map[string]interface{}{
+				"vpcID": "${vpc.status.vpcID}",
+			},
+		),
+		generator.WithResource("vpc", map[string]interface{}{
+			"apiVersion": "ec2.services.k8s.aws/v1alpha1",
+			"kind":       "VPC",
+			"metadata": map[string]interface{}{
+				"name": "${schema.spec.name}-vpc",
+			},
+			"spec": map[string]interface{}{
+				"cidrBlocks": []interface{}{"192.168.0.0/16"},
+			},
+		}, []string{"${vpc.status.state == 'available'}"}, []string{"${schema.spec.enableVPC}"}),
+	))
+	require.NoError(t, err)
+
+	// Every expression is compiled once, when the graph is built.
+	assert.ElementsMatch(t, []string{
+		"schema.spec.name",
+		"vpc.status.state == 'available'",
+		"schema.spec.enableVPC",
+		"vpc.status.vpcID",
+	}, maps.Keys(g.programs))
+}
+
+func TestGraphBuilder_EscapedExpressions(t *testing.T) {
+	fakeResolver, fakeDiscovery := k8s.NewFakeResolver()
+	builder := &Builder{
+		schemaResolver:   fakeResolver,
+		discoveryClient:  fakeDiscovery,
+		resourceEmulator: emulator.NewEmulator(),
+	}
+
+	g, err := builder.NewResourceGraphDefinition(context.Background(), generator.NewResourceGraphDefinition("test-group",
+		generator.WithSchema(
+			"Test", "v1alpha1",
+			map[string]interface{}{
+				"name": "string",
+			},
+			nil,
+		),
+		generator.WithResource("policy", map[string]interface{}{
+			"apiVersion": "iam.services.k8s.aws/v1alpha1",
+			"kind":       "Policy",
+			"metadata": map[string]interface{}{
+				"name": "${schema.spec.name}",
+			},
+			"spec": map[string]interface{}{
+				"name":     "$${aws:username}",
+				"document": `{"Resource": "arn:aws:s3:::${schema.spec.name}/$${aws:username}/*"}`,
+			},
+		}, nil, nil),
+	))
+	require.NoError(t, err)
+
+	rt, err := g.NewGraphRuntime(&unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"name": "bucket",
+		},
+	}})
+	require.NoError(t, err)
+
+	policy, _ := rt.GetResource("policy")
+	require.NotNil(t, policy)
+	assert.Equal(t, "$${aws:username}", g.Resources["policy"].originalObject.Object["spec"].(map[string]interface{})["name"])
+	assert.Equal(t, map[string]interface{}{
+		"name":     "${aws:username}",
+		"document": `{"Resource": "arn:aws:s3:::bucket/${aws:username}/*"}`,
+	}, policy.Object["spec"])
+}
+
+func TestGraphBuilder_StructuralOperations(t *testing.T) {
+	fakeResolver, fakeDiscovery := k8s.NewFakeResolver()
+	builder := &Builder{
+		schemaResolver:   fakeResolver,
+		discoveryClient:  fakeDiscovery,
+		resourceEmulator: emulator.NewEmulator(),
+	}
+
+	newRGD := func(pod map[string]interface{}) *v1alpha1.ResourceGraphDefinition {
+		return generator.NewResourceGraphDefinition("test-group",
+			generator.WithSchema(
+				"Test", "v1alpha1",
+				map[string]interface{}{
+					"name":     "string",
+					"labelKey": "string",
+					"labels":   "map[string]string",
+					"env":      "map[string]string",
+				},
+				nil,
+			),
+			generator.WithResource("pod", pod, nil, nil),
+		)
+	}
+
+	t.Run("resolves key expressions, merges and splices", func(t *testing.T) {
+		g, err := builder.NewResourceGraphDefinition(context.Background(), newRGD(map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata": map[string]interface{}{
+				"name": "${schema.spec.name}",
+				"labels": map[string]interface{}{
+					"$merge":                  "${schema.spec.labels}",
+					"${schema.spec.labelKey}": "${schema.spec.name}",
+				},
+			},
+			"spec": map[string]interface{}{
+				"containers": []interface{}{
+					map[string]interface{}{
+						"name":  "app",
+						"image": "nginx",
+						"env": []interface{}{
+							map[string]interface{}{"name": "NAME", "value": "${schema.spec.name}"},
+							map[string]interface{}{
+								"$splice": "${schema.spec.env.map(k, {'name': k, 'value': schema.spec.env[k]})}",
+							},
+						},
+					},
+				},
+			},
+		}))
+		require.NoError(t, err)
+
+		rt, err := g.NewGraphRuntime(&unstructured.Unstructured{Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"name":     "web",
+				"labelKey": "app",
+				"labels":   map[string]interface{}{"team": "platform", "app": "overridden"},
+				"env":      map[string]interface{}{"MODE": "prod"},
+			},
+		}})
+		require.NoError(t, err)
+
+		// Resolving twice must give the same object.
+		_, err = rt.Synchronize()
+		require.NoError(t, err)
+
+		pod, _ := rt.GetResource("pod")
+		require.NotNil(t, pod)
+		assert.Equal(t, map[string]string{"app": "web", "team": "platform"}, pod.GetLabels())
+		containers := pod.Object["spec"].(map[string]interface{})["containers"].([]interface{})
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"name": "NAME", "value": "web"},
+			map[string]interface{}{"name": "MODE", "value": "prod"},
+		}, containers[0].(map[string]interface{})["env"])
+	})
+
+	t.Run("rejects key expressions outside of maps", func(t *testing.T) {
+		_, err := builder.NewResourceGraphDefinition(context.Background(), newRGD(map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata": map[string]interface{}{
+				"name":                    "${schema.spec.name}",
+				"${schema.spec.labelKey}": "value",
+			},
+		}))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "key expressions are only supported in maps")
+	})
+
+	t.Run("rejects merges of non map values", func(t *testing.T) {
+		_, err := builder.NewResourceGraphDefinition(context.Background(), newRGD(map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata": map[string]interface{}{
+				"name":   "${schema.spec.name}",
+				"labels": map[string]interface{}{"$merge": "${schema.spec.name}"},
+			},
+		}))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "can only be of type map")
+	})
+
+	t.Run("rejects merges of values not matching the schema", func(t *testing.T) {
+		_, err := builder.NewResourceGraphDefinition(context.Background(), newRGD(map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata": map[string]interface{}{
+				"name":   "${schema.spec.name}",
+				"labels": map[string]interface{}{"$merge": "${{'tier': 1}}"},
+			},
+		}))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "doesn't match the schema: expected string at path metadata.labels.tier, got integer")
+	})
+
+	t.Run("rejects splices of items not matching the schema", func(t *testing.T) {
+		newPod := func(splice string) map[string]interface{} {
+			return map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Pod",
+				"metadata": map[string]interface{}{
+					"name": "${schema.spec.name}",
+				},
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{
+							"name":  "app",
+							"image": "nginx",
+							"env": []interface{}{
+								map[string]interface{}{"$splice": splice},
+							},
+						},
+					},
+				},
+			}
+		}
+
+		_, err := builder.NewResourceGraphDefinition(context.Background(), newRGD(newPod(
+			"${[{'name': 'MODE', 'value': 1}]}",
+		)))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "doesn't match the schema: expected string at path")
+
+		_, err = builder.NewResourceGraphDefinition(context.Background(), newRGD(newPod(
+			"${[{'name': 'MODE', 'val': 'prod'}]}",
+		)))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown field at path spec.containers[0].env[0].val")
+	})
+}
+
+func TestGraphBuilder_DependsOn(t *testing.T) {
+	fakeResolver, fakeDiscovery := k8s.NewFakeResolver()
+	builder := &Builder{
+		schemaResolver:   fakeResolver,
+		discoveryClient:  fakeDiscovery,
+		resourceEmulator: emulator.NewEmulator(),
+	}
+
+	newPod := func(name string) map[string]interface{} {
+		return map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata": map[string]interface{}{
+				"name": name,
+			},
+			"spec": map[string]interface{}{
+				"containers": []interface{}{
+					map[string]interface{}{"name": "app", "image": "nginx"},
+				},
+			},
+		}
+	}
+	newRGD := func(opts ...generator.ResourceGraphDefinitionOption) *v1alpha1.ResourceGraphDefinition {
+		return generator.NewResourceGraphDefinition("test-group", append([]generator.ResourceGraphDefinitionOption{
+			generator.WithSchema("Test", "v1alpha1", map[string]interface{}{"name": "string"}, nil),
+			generator.WithResource("first", newPod("first"), nil, nil),
+			generator.WithResource("second", newPod("second"), nil, nil),
+		}, opts...)...)
+	}
+
+	t.Run("adds the explicit dependencies to the graph", func(t *testing.T) {
+		g, err := builder.NewResourceGraphDefinition(context.Background(), newRGD(
+			generator.WithDependsOn("first", "second"),
+		))
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"second", "first"}, g.TopologicalOrder)
+		assert.Equal(t, []string{"second"}, g.Resources["first"].GetDependencies())
+		assert.Equal(t, []string{"second"}, g.Resources["first"].GetExplicitDependencies())
+		assert.Empty(t, g.Resources["second"].GetDependencies())
+	})
+
+	t.Run("rejects unknown resources", func(t *testing.T) {
+		_, err := builder.NewResourceGraphDefinition(context.Background(), newRGD(
+			generator.WithDependsOn("first", "third"),
+		))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "resource first depends on unknown resource third")
+	})
+
+	t.Run("rejects cycles", func(t *testing.T) {
+		_, err := builder.NewResourceGraphDefinition(context.Background(), newRGD(
+			generator.WithDependsOn("first", "second"),
+			generator.WithDependsOn("second", "first"),
+		))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cycle")
+	})
+}
+
+func TestGraphBuilder_PropagationPolicy(t *testing.T) {
+	fakeResolver, fakeDiscovery := k8s.NewFakeResolver()
+	builder := &Builder{
+		schemaResolver:   fakeResolver,
+		discoveryClient:  fakeDiscovery,
+		resourceEmulator: emulator.NewEmulator(),
+	}
+
+	newPod := func(name string) map[string]interface{} {
+		return map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata": map[string]interface{}{
+				"name": name,
+			},
+			"spec": map[string]interface{}{
+				"containers": []interface{}{
+					map[string]interface{}{"name": "app", "image": "nginx"},
+				},
+			},
+		}
+	}
+
+	g, err := builder.NewResourceGraphDefinition(context.Background(), generator.NewResourceGraphDefinition("test-group",
+		generator.WithSchema("Test", "v1alpha1", map[string]interface{}{"name": "string"}, nil),
+		generator.WithResource("first", newPod("first"), nil, nil),
+		generator.WithResource("second", newPod("second"), nil, nil),
+		generator.WithPropagationPolicy("", metav1.DeletePropagationForeground),
+		generator.WithPropagationPolicy("second", metav1.DeletePropagationOrphan),
+	))
+	require.NoError(t, err)
+
+	assert.Equal(t, metav1.DeletePropagationForeground, g.Resources["first"].GetPropagationPolicy())
+	assert.Equal(t, metav1.DeletePropagationOrphan, g.Resources["second"].GetPropagationPolicy())
+}
+
+func TestGraphBuilder_UpdateStrategy(t *testing.T) {
+	fakeResolver, fakeDiscovery := k8s.NewFakeResolver()
+	builder := &Builder{
+		schemaResolver:   fakeResolver,
+		discoveryClient:  fakeDiscovery,
+		resourceEmulator: emulator.NewEmulator(),
+	}
+
+	newPod := func(name string) map[string]interface{} {
+		return map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata": map[string]interface{}{
+				"name": name,
+			},
+			"spec": map[string]interface{}{
+				"containers": []interface{}{
+					map[string]interface{}{"name": "app", "image": "nginx"},
+				},
+			},
+		}
+	}
+
+	rgd := generator.NewResourceGraphDefinition("test-group",
+		generator.WithSchema("Test", "v1alpha1", map[string]interface{}{"name": "string"}, nil),
+		generator.WithResource("first", newPod("first"), nil, nil),
+		generator.WithResource("second", newPod("second"), nil, nil),
+	)
+	rgd.Spec.Resources[1].UpdateStrategy = v1alpha1.UpdateStrategyRecreate
+
+	g, err := builder.NewResourceGraphDefinition(context.Background(), rgd)
+	require.NoError(t, err)
+
+	assert.Equal(t, v1alpha1.UpdateStrategyApply, g.Resources["first"].GetUpdateStrategy())
+	assert.Equal(t, v1alpha1.UpdateStrategyRecreate, g.Resources["second"].GetUpdateStrategy())
+}
+
+func TestGraphBuilder_FieldOwnership(t *testing.T) {
+	fakeResolver, fakeDiscovery := k8s.NewFakeResolver()
+	builder := &Builder{
+		schemaResolver:   fakeResolver,
+		discoveryClient:  fakeDiscovery,
+		resourceEmulator: emulator.NewEmulator(),
+	}
+
+	newRGD := func(ignoreFields []string, forceApply *bool) *v1alpha1.ResourceGraphDefinition {
+		rgd := generator.NewResourceGraphDefinition("test-group",
+			generator.WithSchema("Test", "v1alpha1", map[string]interface{}{"name": "string"}, nil),
+			generator.WithResource("pod", map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Pod",
+				"metadata": map[string]interface{}{
+					"name": "${schema.spec.name}",
+				},
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{"name": "app", "image": "nginx"},
+					},
+				},
+			}, nil, nil),
+		)
+		rgd.Spec.Resources[0].IgnoreFields = ignoreFields
+		rgd.Spec.Resources[0].ForceApply = forceApply
+		return rgd
+	}
+
+	tests := []struct {
+		name         string
+		ignoreFields []string
+		forceApply   *bool
+		wantForce    bool
+		wantErr      string
+	}{
+		{
+			name:         "valid ignored fields",
+			ignoreFields: []string{"spec.containers[0].image", `metadata.annotations["example.com/owner"]`},
+			wantForce:    true,
+		},
+		{
+			name:       "apply without force",
+			forceApply: ptr.To(false),
+			wantForce:  false,
+		},
+		{
+			name:         "path ending with an index",
+			ignoreFields: []string{"spec.containers[0]"},
+			wantErr:      "must end with a field name",
+		},
+		{
+			name:         "identifying field",
+			ignoreFields: []string{"metadata.name"},
+			wantErr:      "refers to a field identifying the resource",
+		},
+		{
+			name:         "invalid path",
+			ignoreFields: []string{"spec[0"},
+			wantErr:      "failed to parse path",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := builder.NewResourceGraphDefinition(context.Background(), newRGD(tt.ignoreFields, tt.forceApply))
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.ignoreFields, g.Resources["pod"].GetIgnoreFields())
+			assert.Equal(t, tt.wantForce, g.Resources["pod"].IsForceApply())
+		})
+	}
+}
+
+func TestGraphBuilder_BuildErrors(t *testing.T) {
+	fakeResolver, fakeDiscovery := k8s.NewFakeResolver()
+	builder := &Builder{
+		schemaResolver:   fakeResolver,
+		discoveryClient:  fakeDiscovery,
+		resourceEmulator: emulator.NewEmulator(),
+	}
+
+	newPod := func(name, image string) map[string]interface{} {
+		return map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata": map[string]interface{}{
+				"name": name,
+			},
+			"spec": map[string]interface{}{
+				"containers": []interface{}{
+					map[string]interface{}{"name": "app", "image": image},
+				},
+			},
+		}
+	}
+
+	t.Run("collects the invalid expressions of all the resources", func(t *testing.T) {
+		_, err := builder.NewResourceGraphDefinition(context.Background(), generator.NewResourceGraphDefinition("test-group",
+			generator.WithSchema("Test", "v1alpha1", map[string]interface{}{"image": "string"}, nil),
+			generator.WithResource("first", newPod("first", "${schema.spec.imag}"), nil, nil),
+			generator.WithResource("second", newPod("${frist.metadata.name}", "${schema.spec.image}"),
+				[]string{"${second.status.phase}"}, nil),
+		))
+		require.Error(t, err)
+
+		var buildErrs BuildErrors
+		require.ErrorAs(t, err, &buildErrs)
+		require.Len(t, buildErrs, 3)
+
+		assert.Equal(t, ErrorCodeInvalidExpression, buildErrs[0].Code)
+		assert.Equal(t, "first", buildErrs[0].ResourceID)
+		assert.Equal(t, "spec.resources[0].template.spec.containers[0].image", buildErrs[0].Path)
+		assert.Equal(t, "schema.spec.imag", buildErrs[0].Expression)
+
+		assert.Equal(t, "second", buildErrs[1].ResourceID)
+		assert.Equal(t, "spec.resources[1].template.metadata.name", buildErrs[1].Path)
+		assert.Equal(t, `did you mean "first"?`, buildErrs[1].Suggestion)
+
+		assert.Equal(t, "spec.resources[1].readyWhen[0]", buildErrs[2].Path)
+		assert.Contains(t, buildErrs[2].Error(), "can only be of type bool")
+	})
+
+	t.Run("collects the invalid resources", func(t *testing.T) {
+		_, err := builder.NewResourceGraphDefinition(context.Background(), generator.NewResourceGraphDefinition("test-group",
+			generator.WithSchema("Test", "v1alpha1", map[string]interface{}{"image": "string"}, nil),
+			generator.WithResource("first", map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Unknown",
+				"metadata":   map[string]interface{}{"name": "first"},
+			}, nil, nil),
+			generator.WithResource("second", newPod("second", "nginx"), nil, nil),
+			generator.WithResource("third", map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+			}, nil, nil),
+		))
+		require.Error(t, err)
+
+		var buildErrs BuildErrors
+		require.ErrorAs(t, err, &buildErrs)
+		require.Len(t, buildErrs, 2)
+		assert.Equal(t, ErrorCodeUnknownKind, buildErrs[0].Code)
+		assert.Equal(t, "spec.resources[0].template.kind", buildErrs[0].Path)
+		assert.Equal(t, ErrorCodeInvalidResource, buildErrs[1].Code)
+		assert.Equal(t, "spec.resources[2].template", buildErrs[1].Path)
+	})
+
+	t.Run("collects the naming convention violations", func(t *testing.T) {
+		_, err := builder.NewResourceGraphDefinition(context.Background(), generator.NewResourceGraphDefinition("test-group",
+			generator.WithSchema("test", "v1alpha1", map[string]interface{}{"image": "string"}, nil),
+			generator.WithResource("first-pod", newPod("first", "nginx"), nil, nil),
+		))
+		require.Error(t, err)
+
+		var buildErrs BuildErrors
+		require.ErrorAs(t, err, &buildErrs)
+		require.Len(t, buildErrs, 2)
+		assert.Equal(t, "spec.schema.kind", buildErrs[0].Path)
+		assert.Equal(t, "spec.resources[0].id", buildErrs[1].Path)
+	})
+
+	t.Run("points at unknown dependencies", func(t *testing.T) {
+		_, err := builder.NewResourceGraphDefinition(context.Background(), generator.NewResourceGraphDefinition("test-group",
+			generator.WithSchema("Test", "v1alpha1", map[string]interface{}{"image": "string"}, nil),
+			generator.WithResource("first", newPod("first", "nginx"), nil, nil),
+			generator.WithResource("second", newPod("second", "nginx"), nil, nil),
+			generator.WithDependsOn("second", "frist"),
+		))
+		require.Error(t, err)
+
+		var buildErr *BuildError
+		require.ErrorAs(t, err, &buildErr)
+		assert.Equal(t, ErrorCodeUnknownDependency, buildErr.Code)
+		assert.Equal(t, "spec.resources[1].dependsOn[0]", buildErr.Path)
+		assert.Equal(t, `did you mean "first"?`, buildErr.Suggestion)
+	})
+}
+
+func TestSynthesizeInstanceCRD(t *testing.T) {
+	rgd := generator.NewResourceGraphDefinition("test-group",
+		generator.WithSchema("Test", "v1alpha1", map[string]interface{}{
+			"image":    "string | required=true",
+			"replicas": "integer | default=2",
+		}, map[string]interface{}{
+			"ready": "${deployment.status.ready}",
+		}),
+	)
+
+	crd, err := SynthesizeInstanceCRD(rgd)
+	require.NoError(t, err)
+	assert.Equal(t, "Test", crd.Spec.Names.Kind)
+	require.Len(t, crd.Spec.Versions, 1)
+	assert.Equal(t, "v1alpha1", crd.Spec.Versions[0].Name)
+
+	spec := crd.Spec.Versions[0].Schema.OpenAPIV3Schema.Properties["spec"]
+	assert.ElementsMatch(t, []string{"image", "replicas"}, maps.Keys(spec.Properties))
+	assert.Equal(t, []string{"image"}, spec.Required)
+
+	rgd.Spec.Schema.Imports = []v1alpha1.TypeImport{{Name: "network-types"}}
+	_, err = SynthesizeInstanceCRD(rgd)
+	assert.ErrorContains(t, err, "failed to resolve imported types")
+}
@@ -0,0 +1,88 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"sigs.k8s.io/yaml"
+
+	"github.com/kubernetes-sigs/kro/api/v1alpha1"
+	krocel "github.com/kubernetes-sigs/kro/pkg/cel"
+	"github.com/kubernetes-sigs/kro/pkg/cel/ast"
+	"github.com/kubernetes-sigs/kro/pkg/graph/parser"
+)
+
+// UsesGlobalConfig returns true if the expressions of the resource graph
+// definition reference the global configuration, through the kro variable.
+// Those resource graph definitions are built again when the configuration
+// changes, since building them evaluates their expressions against it.
+//
+// Resource graph definitions that can't be parsed are reported as using it,
+// so that they are built again, and their errors reported.
+func UsesGlobalConfig(rgd *v1alpha1.ResourceGraphDefinition) bool {
+	var expressions []string
+	addFields := func(raw []byte) bool {
+		if len(raw) == 0 {
+			return true
+		}
+		var object map[string]interface{}
+		if err := yaml.Unmarshal(raw, &object); err != nil {
+			return false
+		}
+		fields, err := parser.ParseSchemalessResource(object)
+		if err != nil {
+			return false
+		}
+		for _, field := range fields {
+			expressions = append(expressions, field.Expressions...)
+		}
+		return true
+	}
+
+	if rgd.Spec.Schema != nil && !addFields(rgd.Spec.Schema.Status.Raw) {
+		return true
+	}
+	for _, resource := range rgd.Spec.Resources {
+		if resource == nil {
+			continue
+		}
+		if !addFields(resource.Template.Raw) {
+			return true
+		}
+		for _, conditions := range [][]string{resource.IncludeWhen, resource.ReadyWhen} {
+			conditionExpressions, err := parser.ParseConditionExpressions(conditions)
+			if err != nil {
+				return true
+			}
+			expressions = append(expressions, conditionExpressions...)
+		}
+	}
+
+	inspector, err := ast.DefaultInspector([]string{krocel.KroVariable}, nil)
+	if err != nil {
+		return true
+	}
+	for _, expression := range expressions {
+		inspection, err := inspector.Inspect(expression)
+		if err != nil {
+			return true
+		}
+		for _, dependency := range inspection.ResourceDependencies {
+			if dependency.ID == krocel.KroVariable {
+				return true
+			}
+		}
+	}
+	return false
+}
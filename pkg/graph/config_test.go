@@ -0,0 +1,83 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubernetes-sigs/kro/pkg/testutil/generator"
+)
+
+func TestUsesGlobalConfig(t *testing.T) {
+	deployment := func(name string) map[string]interface{} {
+		return map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata": map[string]interface{}{
+				"name": name,
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		options []generator.ResourceGraphDefinitionOption
+		want    bool
+	}{
+		{
+			name: "template",
+			options: []generator.ResourceGraphDefinitionOption{
+				generator.WithResource("deployment", deployment("${kro.config.clusterName}-app"), nil, nil),
+			},
+			want: true,
+		},
+		{
+			name: "includeWhen",
+			options: []generator.ResourceGraphDefinitionOption{
+				generator.WithResource("deployment", deployment("app"), nil,
+					[]string{"${kro.config.clusterName == 'dev'}"}),
+			},
+			want: true,
+		},
+		{
+			name: "status",
+			options: []generator.ResourceGraphDefinitionOption{
+				generator.WithSchema("Test", "v1alpha1", map[string]interface{}{}, map[string]interface{}{
+					"cluster": "${kro.config.clusterName}",
+				}),
+			},
+			want: true,
+		},
+		{
+			name: "resource named config",
+			options: []generator.ResourceGraphDefinitionOption{
+				generator.WithSchema("Test", "v1alpha1", map[string]interface{}{}, map[string]interface{}{
+					"name": "${config.metadata.name}",
+				}),
+				generator.WithResource("config", deployment("${schema.spec.name}"), nil, nil),
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rgd := generator.NewResourceGraphDefinition("test", tt.options...)
+			assert.Equal(t, tt.want, UsesGlobalConfig(rgd))
+		})
+	}
+}
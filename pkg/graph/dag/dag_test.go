@@ -189,3 +189,38 @@ func checkValidTopologicalOrder(t *testing.T, d *DirectedAcyclicGraph[string], o
 		}
 	}
 }
+
+func TestDAGFrontier(t *testing.T) {
+	// A and B are independent, C depends on both of them, D depends on A.
+	d := NewDirectedAcyclicGraph[string]()
+	for i, id := range []string{"A", "B", "C", "D"} {
+		if err := d.AddVertex(id, i); err != nil {
+			t.Fatalf("error from AddVertex(%s): %v", id, err)
+		}
+	}
+	if err := d.AddDependencies("C", []string{"A", "B"}); err != nil {
+		t.Fatalf("error from AddDependencies(C): %v", err)
+	}
+	if err := d.AddDependencies("D", []string{"A"}); err != nil {
+		t.Fatalf("error from AddDependencies(D): %v", err)
+	}
+
+	f := d.NewFrontier()
+	if got := f.Next(); !reflect.DeepEqual(got, []string{"A", "B"}) {
+		t.Errorf("Next() = %v, want [A B]", got)
+	}
+	if got := f.Next(); len(got) != 0 {
+		t.Errorf("Next() = %v, want no vertex", got)
+	}
+
+	// B is not done, so only D is ready.
+	f.Done("A")
+	if got := f.Next(); !reflect.DeepEqual(got, []string{"D"}) {
+		t.Errorf("Next() = %v, want [D]", got)
+	}
+
+	f.Done("B")
+	if got := f.Next(); !reflect.DeepEqual(got, []string{"C"}) {
+		t.Errorf("Next() = %v, want [C]", got)
+	}
+}
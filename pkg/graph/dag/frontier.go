@@ -0,0 +1,80 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dag
+
+import (
+	"cmp"
+	"sort"
+)
+
+// Frontier walks a graph as its vertices are processed. It exposes the
+// vertices whose dependencies are all done, so that independent branches of
+// the graph can be processed in parallel.
+//
+// A vertex that is never marked as done blocks its dependents, and only them.
+// Frontier is not safe for concurrent use.
+type Frontier[T cmp.Ordered] struct {
+	graph *DirectedAcyclicGraph[T]
+	// pending holds the number of dependencies of every vertex that are not
+	// done yet.
+	pending map[T]int
+	// dependents holds the vertices depending on every vertex.
+	dependents map[T][]T
+	// ready holds the vertices whose dependencies are done, and that weren't
+	// returned by Next yet.
+	ready []T
+}
+
+// NewFrontier returns a Frontier over the vertices of the graph, none of
+// which is done.
+func (d *DirectedAcyclicGraph[T]) NewFrontier() *Frontier[T] {
+	f := &Frontier[T]{
+		graph:      d,
+		pending:    make(map[T]int, len(d.Vertices)),
+		dependents: make(map[T][]T, len(d.Vertices)),
+	}
+	for id, vertex := range d.Vertices {
+		f.pending[id] = len(vertex.DependsOn)
+		for dependency := range vertex.DependsOn {
+			f.dependents[dependency] = append(f.dependents[dependency], id)
+		}
+		if len(vertex.DependsOn) == 0 {
+			f.ready = append(f.ready, id)
+		}
+	}
+	return f
+}
+
+// Next returns the vertices that became ready since the last call, sorted by
+// their order.
+func (f *Frontier[T]) Next() []T {
+	ready := f.ready
+	f.ready = nil
+	sort.Slice(ready, func(i, j int) bool {
+		return f.graph.Vertices[ready[i]].Order < f.graph.Vertices[ready[j]].Order
+	})
+	return ready
+}
+
+// Done marks a vertex as done. Its dependents become ready once all their
+// dependencies are done.
+func (f *Frontier[T]) Done(id T) {
+	for _, dependent := range f.dependents[id] {
+		f.pending[dependent]--
+		if f.pending[dependent] == 0 {
+			f.ready = append(f.ready, dependent)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package diagram renders the resource graphs of ResourceGraphDefinitions as
+// Mermaid flowcharts, Graphviz DOT graphs or SVG images.
+package diagram
+
+import (
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kubernetes-sigs/kro/pkg/graph"
+)
+
+// Node is a resource of the diagram.
+type Node struct {
+	// ID is the id of the resource.
+	ID string
+	// GVK is the group, version and kind of the resource.
+	GVK schema.GroupVersionKind
+	// Conditional indicates if the resource has includeWhen expressions.
+	Conditional bool
+	// ExternalRef indicates if the resource is an external reference, only
+	// read by kro.
+	ExternalRef bool
+}
+
+// Label returns the lines of the label of the node: its id, its kind and
+// apiVersion, and whether it is an external reference.
+func (n *Node) Label() []string {
+	label := []string{n.ID, fmt.Sprintf("%s (%s)", n.GVK.Kind, n.GVK.GroupVersion().String())}
+	if n.ExternalRef {
+		label = append(label, "external reference")
+	}
+	return label
+}
+
+// Edge is a dependency between two resources of the diagram.
+type Edge struct {
+	// From is the id of the resource depended on.
+	From string
+	// To is the id of the dependent resource.
+	To string
+}
+
+// Diagram is the resource graph of a ResourceGraphDefinition.
+type Diagram struct {
+	// Name is the name of the ResourceGraphDefinition.
+	Name string
+	// Nodes are the resources, in topological order.
+	Nodes []Node
+	// Edges are the dependencies between the resources, in the topological
+	// order of their resources.
+	Edges []Edge
+}
+
+// New creates the diagram of the resource graph of the ResourceGraphDefinition
+// with the given name. The edges are the dependencies of the DAG of the graph.
+func New(name string, g *graph.Graph) *Diagram {
+	d := &Diagram{Name: name}
+	order := make(map[string]int, len(g.TopologicalOrder))
+	for i, id := range g.TopologicalOrder {
+		order[id] = i
+		resource := g.Resources[id]
+		d.Nodes = append(d.Nodes, Node{
+			ID:          id,
+			GVK:         resource.Unstructured().GroupVersionKind(),
+			Conditional: len(resource.GetIncludeWhenExpressions()) > 0,
+			ExternalRef: resource.IsExternalRef(),
+		})
+	}
+	for id, vertex := range g.DAG.Vertices {
+		for dependency := range vertex.DependsOn {
+			d.Edges = append(d.Edges, Edge{From: dependency, To: id})
+		}
+	}
+	sort.Slice(d.Edges, func(i, j int) bool {
+		if d.Edges[i].From != d.Edges[j].From {
+			return order[d.Edges[i].From] < order[d.Edges[j].From]
+		}
+		return order[d.Edges[i].To] < order[d.Edges[j].To]
+	})
+	return d
+}
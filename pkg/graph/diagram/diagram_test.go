@@ -0,0 +1,227 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagram
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kubernetes-sigs/kro/api/v1alpha1"
+	"github.com/kubernetes-sigs/kro/pkg/graph"
+	"github.com/kubernetes-sigs/kro/pkg/graph/schema/offline"
+)
+
+const webappRGD = `apiVersion: kro.run/v1alpha1
+kind: ResourceGraphDefinition
+metadata:
+  name: webapp
+spec:
+  schema:
+    apiVersion: v1alpha1
+    kind: WebApp
+    spec:
+      ingress: boolean | default=false
+  resources:
+  - id: settings
+    externalRef:
+      apiVersion: v1
+      kind: ConfigMap
+      metadata:
+        name: webapp-config
+        namespace: default
+  - id: deployment
+    template:
+      apiVersion: apps/v1
+      kind: Deployment
+      metadata:
+        name: ${schema.metadata.name}
+        annotations:
+          config: ${settings.metadata.name}
+      spec:
+        selector:
+          matchLabels:
+            app: ${schema.metadata.name}
+        template:
+          metadata:
+            labels:
+              app: ${schema.metadata.name}
+          spec:
+            containers:
+            - name: app
+              image: nginx
+  - id: service
+    template:
+      apiVersion: v1
+      kind: Service
+      metadata:
+        name: ${deployment.metadata.name}
+      spec:
+        selector: ${deployment.spec.selector.matchLabels}
+  - id: ingress
+    includeWhen:
+    - ${schema.spec.ingress}
+    template:
+      apiVersion: networking.k8s.io/v1
+      kind: Ingress
+      metadata:
+        name: ${service.metadata.name}
+`
+
+func newWebappDiagram(t *testing.T) *Diagram {
+	rgd := &v1alpha1.ResourceGraphDefinition{}
+	require.NoError(t, yaml.Unmarshal([]byte(webappRGD), rgd))
+	local := offline.NewLocalResolver()
+	g, err := graph.NewOfflineBuilder(local, local).NewResourceGraphDefinition(context.Background(), rgd)
+	require.NoError(t, err)
+	return New(rgd.Name, g)
+}
+
+func TestNew(t *testing.T) {
+	d := newWebappDiagram(t)
+
+	assert.Equal(t, "webapp", d.Name)
+	assert.Equal(t, []Node{
+		{ID: "settings", GVK: schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, ExternalRef: true},
+		{ID: "deployment", GVK: schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}},
+		{ID: "service", GVK: schema.GroupVersionKind{Version: "v1", Kind: "Service"}},
+		{
+			ID:          "ingress",
+			GVK:         schema.GroupVersionKind{Group: "networking.k8s.io", Version: "v1", Kind: "Ingress"},
+			Conditional: true,
+		},
+	}, d.Nodes)
+	assert.Equal(t, []Edge{
+		{From: "settings", To: "deployment"},
+		{From: "deployment", To: "service"},
+		{From: "service", To: "ingress"},
+	}, d.Edges)
+	assert.Equal(t, []string{"settings", "ConfigMap (v1)", "external reference"}, d.Nodes[0].Label())
+}
+
+func TestDiagram_WriteMermaid(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, newWebappDiagram(t).WriteMermaid(&out))
+	assert.Equal(t, `---
+title: webapp
+---
+flowchart TD
+    n_settings["settings<br/>ConfigMap (v1)<br/>external reference"]:::external
+    n_deployment["deployment<br/>Deployment (apps/v1)"]
+    n_service["service<br/>Service (v1)"]
+    n_ingress["ingress<br/>Ingress (networking.k8s.io/v1)"]:::conditional
+    n_settings --> n_deployment
+    n_deployment --> n_service
+    n_service --> n_ingress
+    classDef default fill:#e6f4ea,stroke:#333333
+    classDef conditional stroke-dasharray:5 5
+    classDef external fill:#eeeeee
+    classDef externalConditional fill:#eeeeee,stroke-dasharray:5 5
+`, out.String())
+}
+
+func TestDiagram_WriteMermaid_ReservedIDs(t *testing.T) {
+	d := &Diagram{
+		Name: "reserved",
+		Nodes: []Node{
+			{ID: "end", GVK: schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}},
+			{ID: "xsecret", GVK: schema.GroupVersionKind{Version: "v1", Kind: "Secret"}},
+		},
+		Edges: []Edge{{From: "end", To: "xsecret"}},
+	}
+	var out bytes.Buffer
+	require.NoError(t, d.WriteMermaid(&out))
+	assert.Contains(t, out.String(), `    n_end["end<br/>ConfigMap (v1)"]`)
+	assert.Contains(t, out.String(), "    n_end --> n_xsecret\n")
+}
+
+func TestDiagram_WriteDOT(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, newWebappDiagram(t).WriteDOT(&out))
+	assert.Equal(t, `digraph "webapp" {
+    label="webapp";
+    labelloc=t;
+    rankdir=TB;
+    node [shape=box, style="rounded,filled", fillcolor="#e6f4ea", color="#333333", fontname=Helvetica];
+    edge [color="#333333"];
+    "settings" [label="settings\nConfigMap (v1)\nexternal reference", fillcolor="#eeeeee"];
+    "deployment" [label="deployment\nDeployment (apps/v1)"];
+    "service" [label="service\nService (v1)"];
+    "ingress" [label="ingress\nIngress (networking.k8s.io/v1)", style="rounded,filled,dashed"];
+    "settings" -> "deployment";
+    "deployment" -> "service";
+    "service" -> "ingress";
+}
+`, out.String())
+}
+
+func TestDiagram_WriteSVG(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, newWebappDiagram(t).WriteSVG(&out))
+
+	// The image is well-formed XML.
+	decoder := xml.NewDecoder(bytes.NewReader(out.Bytes()))
+	for {
+		_, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+	}
+	svg := out.String()
+	assert.Contains(t, svg, `<text x="`)
+	assert.Contains(t, svg, `>Ingress (networking.k8s.io/v1)</text>`)
+	assert.Contains(t, svg, `stroke-dasharray="5 5"`)
+	assert.Contains(t, svg, `fill="#eeeeee"`)
+	assert.Equal(t, 3, bytes.Count(out.Bytes(), []byte(`marker-end="url(#arrow)"`)))
+}
+
+func TestNewLayout(t *testing.T) {
+	// a, d0 and b are roots, c depends on a and b, and d on b and d0: b is
+	// moved between a and d0 to uncross the edges.
+	d := &Diagram{
+		Name: "layout",
+		Nodes: []Node{
+			{ID: "a"}, {ID: "d0"}, {ID: "b"}, {ID: "c"}, {ID: "d"},
+		},
+		Edges: []Edge{
+			{From: "a", To: "c"},
+			{From: "b", To: "c"},
+			{From: "b", To: "d"},
+			{From: "d0", To: "d"},
+		},
+	}
+	l := newLayout(d)
+	require.Len(t, l.layers, 2)
+	assert.Equal(t, []int{0, 2, 1}, l.layers[0])
+	assert.Equal(t, []int{3, 4}, l.layers[1])
+
+	// The layers are stacked, and the resources of a layer don't overlap.
+	for _, layer := range l.layers {
+		for i := 1; i < len(layer); i++ {
+			assert.Equal(t, l.positions[layer[i-1]].y, l.positions[layer[i]].y)
+			assert.GreaterOrEqual(t, l.positions[layer[i]].x, l.positions[layer[i-1]].x+l.nodeWidth)
+		}
+	}
+	assert.Greater(t, l.positions[3].y, l.positions[0].y+l.nodeHeight)
+	assert.LessOrEqual(t, l.positions[4].x+l.nodeWidth, l.width)
+}
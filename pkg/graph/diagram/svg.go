@@ -0,0 +1,230 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagram
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"sort"
+)
+
+const (
+	// fontSize is the font size of the labels, in pixels.
+	fontSize = 12
+	// charWidth is the approximate width of a character of the labels.
+	charWidth = 7
+	// lineHeight is the height of a line of the labels.
+	lineHeight = 16
+	// nodePadding is the space between the labels and the borders of the
+	// resources.
+	nodePadding = 10
+	// horizontalGap is the space between the resources of a layer.
+	horizontalGap = 40
+	// verticalGap is the space between the layers.
+	verticalGap = 60
+	// margin is the space around the diagram.
+	margin = 20
+	// titleHeight is the height of the title of the diagram.
+	titleHeight = 30
+	// orderingSweeps is the number of sweeps ordering the resources of the
+	// layers to reduce the edge crossings.
+	orderingSweeps = 4
+)
+
+// position is the position of the top left corner of a resource.
+type position struct {
+	x, y int
+}
+
+// layout is the position of the resources of a diagram, drawn top to bottom
+// in layers: a resource is in the layer below the lowest of its dependencies.
+type layout struct {
+	// layers are the indices of the nodes of each layer, left to right.
+	layers [][]int
+	// positions are the positions of the nodes, by index.
+	positions []position
+	// nodeWidth and nodeHeight are the size of every resource.
+	nodeWidth, nodeHeight int
+	// width and height are the size of the diagram.
+	width, height int
+}
+
+// newLayout lays the diagram out.
+func newLayout(d *Diagram) *layout {
+	index := make(map[string]int, len(d.Nodes))
+	for i, node := range d.Nodes {
+		index[node.ID] = i
+	}
+	dependencies := make([][]int, len(d.Nodes))
+	dependents := make([][]int, len(d.Nodes))
+	for _, edge := range d.Edges {
+		from, to := index[edge.From], index[edge.To]
+		dependencies[to] = append(dependencies[to], from)
+		dependents[from] = append(dependents[from], to)
+	}
+
+	// The nodes are in topological order, so the layers of the dependencies
+	// of a node are known before its own.
+	l := &layout{positions: make([]position, len(d.Nodes))}
+	layerOf := make([]int, len(d.Nodes))
+	maxLines := 0
+	maxChars := 0
+	for i, node := range d.Nodes {
+		for _, dependency := range dependencies[i] {
+			layerOf[i] = max(layerOf[i], layerOf[dependency]+1)
+		}
+		if layerOf[i] == len(l.layers) {
+			l.layers = append(l.layers, nil)
+		}
+		l.layers[layerOf[i]] = append(l.layers[layerOf[i]], i)
+
+		label := node.Label()
+		maxLines = max(maxLines, len(label))
+		for _, line := range label {
+			maxChars = max(maxChars, len(line))
+		}
+	}
+	l.orderLayers(dependencies, dependents)
+
+	l.nodeWidth = maxChars*charWidth + 2*nodePadding
+	l.nodeHeight = maxLines*lineHeight + 2*nodePadding
+	widest := 0
+	for _, layer := range l.layers {
+		widest = max(widest, len(layer))
+	}
+	l.width = 2*margin + widest*l.nodeWidth + max(widest-1, 0)*horizontalGap
+	l.height = 2*margin + titleHeight + len(l.layers)*l.nodeHeight + max(len(l.layers)-1, 0)*verticalGap
+
+	for i, layer := range l.layers {
+		layerWidth := len(layer)*l.nodeWidth + (len(layer)-1)*horizontalGap
+		x := (l.width - layerWidth) / 2
+		y := margin + titleHeight + i*(l.nodeHeight+verticalGap)
+		for _, node := range layer {
+			l.positions[node] = position{x: x, y: y}
+			x += l.nodeWidth + horizontalGap
+		}
+	}
+	return l
+}
+
+// orderLayers orders the nodes of the layers to reduce the edge crossings,
+// with the barycenter heuristic: the layers are swept down and up, and the
+// nodes of each layer sorted by the mean rank of their neighbours in the
+// previous layer of the sweep.
+func (l *layout) orderLayers(dependencies, dependents [][]int) {
+	rank := make([]float64, len(l.positions))
+	setRanks := func(layer []int) {
+		for r, node := range layer {
+			rank[node] = float64(r)
+		}
+	}
+	for _, layer := range l.layers {
+		setRanks(layer)
+	}
+
+	sortLayer := func(layer []int, neighbours [][]int) {
+		barycenter := make(map[int]float64, len(layer))
+		for _, node := range layer {
+			// The nodes without neighbours keep their rank.
+			barycenter[node] = rank[node]
+			if len(neighbours[node]) == 0 {
+				continue
+			}
+			sum := 0.0
+			for _, neighbour := range neighbours[node] {
+				sum += rank[neighbour]
+			}
+			barycenter[node] = sum / float64(len(neighbours[node]))
+		}
+		sort.SliceStable(layer, func(i, j int) bool {
+			return barycenter[layer[i]] < barycenter[layer[j]]
+		})
+		setRanks(layer)
+	}
+
+	for sweep := 0; sweep < orderingSweeps; sweep++ {
+		if sweep%2 == 0 {
+			for i := 1; i < len(l.layers); i++ {
+				sortLayer(l.layers[i], dependencies)
+			}
+		} else {
+			for i := len(l.layers) - 2; i >= 0; i-- {
+				sortLayer(l.layers[i], dependents)
+			}
+		}
+	}
+}
+
+// WriteSVG writes the diagram as an SVG image, laid out without Graphviz. The
+// conditional resources have dashed borders, and the external references are
+// grey.
+func (d *Diagram) WriteSVG(w io.Writer) error {
+	l := newLayout(d)
+	index := make(map[string]int, len(d.Nodes))
+	for i, node := range d.Nodes {
+		index[node.ID] = i
+	}
+
+	b := bufio.NewWriter(w)
+	fmt.Fprintf(b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" `+
+		`font-family="Helvetica, Arial, sans-serif" font-size="%d">`+"\n",
+		l.width, l.height, l.width, l.height, fontSize)
+	fmt.Fprintf(b, `  <defs><marker id="arrow" viewBox="0 0 10 10" refX="10" refY="5" `+
+		`markerWidth="7" markerHeight="7" orient="auto-start-reverse">`+
+		`<path d="M 0 0 L 10 5 L 0 10 z" fill="%s"/></marker></defs>`+"\n", strokeColor)
+	fmt.Fprintf(b, `  <rect width="100%%" height="100%%" fill="white"/>`+"\n")
+	fmt.Fprintf(b, `  <text x="%d" y="%d" text-anchor="middle" font-size="16" font-weight="bold">%s</text>`+"\n",
+		l.width/2, margin+fontSize+4, html.EscapeString(d.Name))
+
+	for _, edge := range d.Edges {
+		from, to := l.positions[index[edge.From]], l.positions[index[edge.To]]
+		x1, y1 := from.x+l.nodeWidth/2, from.y+l.nodeHeight
+		x2, y2 := to.x+l.nodeWidth/2, to.y
+		curve := (y2 - y1) / 2
+		fmt.Fprintf(b, `  <path d="M %d %d C %d %d, %d %d, %d %d" fill="none" stroke="%s" marker-end="url(#arrow)"/>`+"\n",
+			x1, y1, x1, y1+curve, x2, y2-curve, x2, y2, strokeColor)
+	}
+
+	for i, node := range d.Nodes {
+		p := l.positions[i]
+		fill := fillColor
+		if node.ExternalRef {
+			fill = externalRefFillColor
+		}
+		dash := ""
+		if node.Conditional {
+			dash = ` stroke-dasharray="5 5"`
+		}
+		fmt.Fprintf(b, `  <g id="%s">`+"\n", html.EscapeString(node.ID))
+		fmt.Fprintf(b, `    <rect x="%d" y="%d" width="%d" height="%d" rx="6" fill="%s" stroke="%s"%s/>`+"\n",
+			p.x, p.y, l.nodeWidth, l.nodeHeight, fill, strokeColor, dash)
+		label := node.Label()
+		// The lines are centered vertically in the resource.
+		y := p.y + (l.nodeHeight-len(label)*lineHeight)/2 + fontSize
+		for j, line := range label {
+			weight := ""
+			if j == 0 {
+				weight = ` font-weight="bold"`
+			}
+			fmt.Fprintf(b, `    <text x="%d" y="%d" text-anchor="middle"%s>%s</text>`+"\n",
+				p.x+l.nodeWidth/2, y+j*lineHeight, weight, html.EscapeString(line))
+		}
+		fmt.Fprintln(b, "  </g>")
+	}
+	fmt.Fprintln(b, "</svg>")
+	return b.Flush()
+}
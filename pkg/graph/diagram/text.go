@@ -0,0 +1,115 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagram
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	// fillColor is the fill color of the resources.
+	fillColor = "#e6f4ea"
+	// externalRefFillColor is the fill color of the external references.
+	externalRefFillColor = "#eeeeee"
+	// strokeColor is the color of the borders of the resources and of the
+	// edges.
+	strokeColor = "#333333"
+)
+
+// WriteMermaid writes the diagram as a Mermaid flowchart, which GitHub and
+// GitLab render in Markdown. The conditional resources have dashed borders,
+// and the external references are grey.
+func (d *Diagram) WriteMermaid(w io.Writer) error {
+	b := bufio.NewWriter(w)
+	fmt.Fprintf(b, "---\ntitle: %s\n---\n", d.Name)
+	fmt.Fprintln(b, "flowchart TD")
+	for _, node := range d.Nodes {
+		fmt.Fprintf(b, "    %s[\"%s\"]", mermaidID(node.ID), strings.Join(escapeMermaid(node.Label()), "<br/>"))
+		switch {
+		case node.ExternalRef && node.Conditional:
+			fmt.Fprint(b, ":::externalConditional")
+		case node.ExternalRef:
+			fmt.Fprint(b, ":::external")
+		case node.Conditional:
+			fmt.Fprint(b, ":::conditional")
+		}
+		fmt.Fprintln(b)
+	}
+	for _, edge := range d.Edges {
+		fmt.Fprintf(b, "    %s --> %s\n", mermaidID(edge.From), mermaidID(edge.To))
+	}
+	fmt.Fprintf(b, "    classDef default fill:%s,stroke:%s\n", fillColor, strokeColor)
+	fmt.Fprintln(b, "    classDef conditional stroke-dasharray:5 5")
+	fmt.Fprintf(b, "    classDef external fill:%s\n", externalRefFillColor)
+	fmt.Fprintf(b, "    classDef externalConditional fill:%s,stroke-dasharray:5 5\n", externalRefFillColor)
+	return b.Flush()
+}
+
+// mermaidID returns the Mermaid node ID of a resource. The resource IDs are
+// prefixed, as some of them, like end, are keywords of Mermaid flowcharts,
+// and others, starting with o or x, would be parsed as edge heads.
+func mermaidID(id string) string {
+	return "n_" + id
+}
+
+// escapeMermaid escapes the double quotes of the lines of a label, which would
+// end it.
+func escapeMermaid(lines []string) []string {
+	escaped := make([]string, len(lines))
+	for i, line := range lines {
+		escaped[i] = strings.ReplaceAll(line, `"`, "#quot;")
+	}
+	return escaped
+}
+
+// WriteDOT writes the diagram as a Graphviz DOT graph. The conditional
+// resources have dashed borders, and the external references are grey.
+func (d *Diagram) WriteDOT(w io.Writer) error {
+	b := bufio.NewWriter(w)
+	fmt.Fprintf(b, "digraph %s {\n", quoteDOT(d.Name))
+	fmt.Fprintf(b, "    label=%s;\n", quoteDOT(d.Name))
+	fmt.Fprintln(b, "    labelloc=t;")
+	fmt.Fprintln(b, "    rankdir=TB;")
+	fmt.Fprintf(b, "    node [shape=box, style=\"rounded,filled\", fillcolor=%s, color=%s, fontname=Helvetica];\n",
+		quoteDOT(fillColor), quoteDOT(strokeColor))
+	fmt.Fprintf(b, "    edge [color=%s];\n", quoteDOT(strokeColor))
+	for _, node := range d.Nodes {
+		attributes := []string{"label=" + quoteDOT(strings.Join(node.Label(), "\n"))}
+		if node.Conditional {
+			attributes = append(attributes, `style="rounded,filled,dashed"`)
+		}
+		if node.ExternalRef {
+			attributes = append(attributes, "fillcolor="+quoteDOT(externalRefFillColor))
+		}
+		fmt.Fprintf(b, "    %s [%s];\n", quoteDOT(node.ID), strings.Join(attributes, ", "))
+	}
+	for _, edge := range d.Edges {
+		fmt.Fprintf(b, "    %s -> %s;\n", quoteDOT(edge.From), quoteDOT(edge.To))
+	}
+	fmt.Fprintln(b, "}")
+	return b.Flush()
+}
+
+// quoteDOT quotes a DOT identifier. The new lines are kept as DOT escape
+// sequences, which center the lines of labels.
+func quoteDOT(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
@@ -0,0 +1,216 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ErrorCode identifies the kind of problem found in a resource graph
+// definition.
+type ErrorCode string
+
+const (
+	// ErrorCodeInvalidName is used when the kind or a resource id doesn't
+	// follow the naming conventions.
+	ErrorCodeInvalidName ErrorCode = "InvalidName"
+	// ErrorCodeDuplicateID is used when several resources share the same id.
+	ErrorCodeDuplicateID ErrorCode = "DuplicateID"
+	// ErrorCodeInvalidResource is used when a resource template isn't a valid
+	// Kubernetes object.
+	ErrorCodeInvalidResource ErrorCode = "InvalidResource"
+	// ErrorCodeUnknownKind is used when the schema of a resource kind can't be
+	// found.
+	ErrorCodeUnknownKind ErrorCode = "UnknownKind"
+	// ErrorCodeInvalidSchema is used when the schema of the instance is
+	// invalid.
+	ErrorCodeInvalidSchema ErrorCode = "InvalidSchema"
+	// ErrorCodeInvalidExpression is used when an expression can't be parsed,
+	// compiled or evaluated, or has the wrong type.
+	ErrorCodeInvalidExpression ErrorCode = "InvalidExpression"
+	// ErrorCodeInvalidField is used when a field of a resource, other than its
+	// template, is invalid.
+	ErrorCodeInvalidField ErrorCode = "InvalidField"
+	// ErrorCodeUnknownDependency is used when a resource depends on a resource
+	// that doesn't exist.
+	ErrorCodeUnknownDependency ErrorCode = "UnknownDependency"
+	// ErrorCodeDependencyCycle is used when the resources depend on each other.
+	ErrorCodeDependencyCycle ErrorCode = "DependencyCycle"
+)
+
+// BuildError is a problem found while building a resource graph definition.
+// It points at the field of the resource graph definition holding the problem,
+// so that it can be reported next to it.
+type BuildError struct {
+	// Code identifies the kind of problem.
+	Code ErrorCode
+	// ResourceID is the id of the resource holding the problem, if any.
+	ResourceID string
+	// Path is the path of the field holding the problem in the resource graph
+	// definition, e.g spec.resources[2].template.spec.replicas.
+	Path string
+	// Expression is the offending expression, if any.
+	Expression string
+	// Suggestion is a hint on how to fix the problem, if any.
+	Suggestion string
+	// Err is the underlying error.
+	Err error
+}
+
+// Error returns the message of the error, prefixed by the resource and the
+// path of the field holding the problem.
+func (e *BuildError) Error() string {
+	var b strings.Builder
+	switch {
+	case e.ResourceID != "" && e.Path != "":
+		fmt.Fprintf(&b, "resource %q at %s: ", e.ResourceID, e.Path)
+	case e.ResourceID != "":
+		fmt.Fprintf(&b, "resource %q: ", e.ResourceID)
+	case e.Path != "":
+		fmt.Fprintf(&b, "%s: ", e.Path)
+	}
+	b.WriteString(e.Err.Error())
+	if e.Suggestion != "" {
+		fmt.Fprintf(&b, " (%s)", e.Suggestion)
+	}
+	return b.String()
+}
+
+// Unwrap returns the underlying error.
+func (e *BuildError) Unwrap() error {
+	return e.Err
+}
+
+// BuildErrors are all the problems found while building a resource graph
+// definition.
+type BuildErrors []*BuildError
+
+// Error returns the messages of the errors, one per line.
+func (e BuildErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "\n")
+}
+
+// Unwrap returns the errors, so that errors.Is and errors.As look into each
+// one of them.
+func (e BuildErrors) Unwrap() []error {
+	errs := make([]error, 0, len(e))
+	for _, err := range e {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// errorOrNil returns the errors, or nil if there are none.
+func (e BuildErrors) errorOrNil() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// asBuildErrors returns the BuildErrors held by err, or err wrapped in a
+// BuildError with the given code, resource id and path if it isn't one.
+func asBuildErrors(err error, code ErrorCode, resourceID, path string) BuildErrors {
+	switch err := err.(type) {
+	case BuildErrors:
+		return err
+	case *BuildError:
+		return BuildErrors{err}
+	}
+	return BuildErrors{{Code: code, ResourceID: resourceID, Path: path, Err: err}}
+}
+
+// resourcePath returns the path of a field of the resource at the given
+// order in the resource graph definition. An empty field is the resource
+// itself.
+func resourcePath(order int, field string) string {
+	path := fmt.Sprintf("spec.resources[%d]", order)
+	if field != "" {
+		path += "." + field
+	}
+	return path
+}
+
+// templatePath returns the path of a field of the template, or of the
+// external reference, of the resource in the resource graph definition.
+func (r *Resource) templatePath(field string) string {
+	if r.isExternalRef {
+		return resourcePath(r.order, "externalRef."+field)
+	}
+	return resourcePath(r.order, "template."+field)
+}
+
+// undeclaredReferenceRegex matches the CEL errors of the expressions referring
+// to unknown variables.
+var undeclaredReferenceRegex = regexp.MustCompile(`undeclared reference to '([^']+)'`)
+
+// newExpressionError returns a BuildError for an invalid expression. Unknown
+// variables close to one of the candidates are reported along with the
+// candidate, as they're probably typos.
+func newExpressionError(resourceID, path, expression string, err error, candidates []string) *BuildError {
+	buildErr := &BuildError{
+		Code:       ErrorCodeInvalidExpression,
+		ResourceID: resourceID,
+		Path:       path,
+		Expression: expression,
+		Err:        err,
+	}
+	if match := undeclaredReferenceRegex.FindStringSubmatch(err.Error()); match != nil {
+		buildErr.Suggestion = suggest(match[1], candidates)
+	}
+	return buildErr
+}
+
+// suggest returns a hint naming the candidate closest to name, or an empty
+// string if none of them is close enough to be a typo.
+func suggest(name string, candidates []string) string {
+	best, bestDistance := "", max(2, len(name)/3)+1
+	for _, candidate := range candidates {
+		if distance := levenshtein(name, candidate); distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+	if best == "" {
+		return ""
+	}
+	return fmt.Sprintf("did you mean %q?", best)
+}
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestBuildErrors(t *testing.T) {
+	dependencyErr := &RGDDependencyError{RGDName: "network", GVK: schema.GroupVersionKind{Kind: "Network"}}
+	errs := BuildErrors{
+		{
+			Code:       ErrorCodeInvalidExpression,
+			ResourceID: "deployment",
+			Path:       "spec.resources[2].template.spec.replicas",
+			Expression: "schema.spec.replica",
+			Suggestion: `did you mean "replicas"?`,
+			Err:        fmt.Errorf("no such key: replica"),
+		},
+		{
+			Code:       ErrorCodeUnknownKind,
+			ResourceID: "network",
+			Path:       "spec.resources[3].template.kind",
+			Err:        dependencyErr,
+		},
+		{
+			Code: ErrorCodeInvalidName,
+			Path: "spec.schema.kind",
+			Err:  fmt.Errorf("invalid kind"),
+		},
+	}
+
+	assert.Equal(t,
+		`resource "deployment" at spec.resources[2].template.spec.replicas: `+
+			`no such key: replica (did you mean "replicas"?)`+"\n"+
+			`resource "network" at spec.resources[3].template.kind: `+dependencyErr.Error()+"\n"+
+			`spec.schema.kind: invalid kind`,
+		errs.Error())
+
+	var target *RGDDependencyError
+	assert.True(t, errors.As(fmt.Errorf("wrapped: %w", errs), &target))
+	assert.Equal(t, dependencyErr, target)
+
+	assert.Nil(t, BuildErrors(nil).errorOrNil())
+}
+
+func TestSuggest(t *testing.T) {
+	tests := []struct {
+		name       string
+		candidates []string
+		want       string
+	}{
+		{name: "deploymnet", candidates: []string{"service", "deployment"}, want: `did you mean "deployment"?`},
+		{name: "svc", candidates: []string{"service", "deployment"}, want: ""},
+		{name: "schem", candidates: []string{"schema", "config"}, want: `did you mean "schema"?`},
+		{name: "unrelated", candidates: nil, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, suggest(tt.name, tt.candidates))
+		})
+	}
+}
@@ -37,7 +37,7 @@ func Build(segments []Segment) string {
 			continue
 		}
 
-		if strings.Contains(segment.Name, ".") {
+		if segment.Name == "" || strings.ContainsAny(segment.Name, ".[]") {
 			b.WriteString(fmt.Sprintf(`[%q]`, segment.Name))
 		} else {
 			b.WriteString(segment.Name)
@@ -16,7 +16,10 @@ package graph
 
 import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sschema "k8s.io/apimachinery/pkg/runtime/schema"
 
+	krocel "github.com/kubernetes-sigs/kro/pkg/cel"
+	"github.com/kubernetes-sigs/kro/pkg/globalconfig"
 	"github.com/kubernetes-sigs/kro/pkg/graph/dag"
 	"github.com/kubernetes-sigs/kro/pkg/runtime"
 )
@@ -33,6 +36,15 @@ type Graph struct {
 	Resources map[string]*Resource
 	// TopologicalOrder is the topological order of the resources in the resource graph definition.
 	TopologicalOrder []string
+	// globalConfig holds the global configuration values, read every time a
+	// new runtime is created.
+	globalConfig *globalconfig.Store
+	// costLimits bounds the cost of the expressions evaluated by the runtimes
+	// of the graph.
+	costLimits krocel.CostLimits
+	// programs holds the compiled programs of the expressions, shared by the
+	// runtimes of the graph.
+	programs runtime.Programs
 }
 
 // NewGraphRuntime creates a new runtime resource graph definition from the resource graph definition instance.
@@ -46,9 +58,22 @@ func (rgd *Graph) NewGraphRuntime(newInstance *unstructured.Unstructured) (*runt
 
 	instance := rgd.Instance.DeepCopy()
 	instance.originalObject = newInstance
-	rt, err := runtime.NewResourceGraphDefinitionRuntime(instance, resources, rgd.TopologicalOrder)
+	rt, err := runtime.NewResourceGraphDefinitionRuntime(
+		instance, resources, rgd.TopologicalOrder, rgd.globalConfig.Values(), rgd.costLimits, rgd.programs,
+	)
 	if err != nil {
 		return nil, err
 	}
 	return rt, nil
 }
+
+// instanceGVK returns the GroupVersionKind of the instances of the resource
+// graph definition.
+func (rgd *Graph) instanceGVK() k8sschema.GroupVersionKind {
+	crd := rgd.Instance.crd
+	return k8sschema.GroupVersionKind{
+		Group:   crd.Spec.Group,
+		Version: crd.Spec.Versions[0].Name,
+		Kind:    crd.Spec.Names.Kind,
+	}
+}
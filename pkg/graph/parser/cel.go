@@ -23,6 +23,9 @@ const (
 	// In kro, CEL expressions are enclosed between "${" and "}"
 	exprStart = "${"
 	exprEnd   = "}"
+	// "$${" escapes a literal "${", e.g "$${HOME}" is rendered as "${HOME}" and
+	// is not an expression.
+	escapedExprStart = "$${"
 )
 
 // Allow nested expressions, but only if they are escaped with quotes ${outer("${inner}")} is allowed, but ${outer(${inner})} is not
@@ -44,6 +47,12 @@ func extractExpressions(str string) ([]string, error) {
 		// Adjust the start index to the actual position in the string
 		startIdx += start
 
+		// Skip escaped expressions, they are literal strings
+		if isEscapedAt(str, startIdx) {
+			start = startIdx + len(exprStart)
+			continue
+		}
+
 		// We need to find the matching end bracket, being careful about
 		// nested expressions, dictionary building expressions, and string literals
 		bracketCount := 1
@@ -105,6 +114,18 @@ func extractExpressions(str string) ([]string, error) {
 	return expressions, nil
 }
 
+// isEscapedAt returns true if the "${" at the given index of the string is
+// escaped, i.e. preceded by a "$".
+func isEscapedAt(str string, idx int) bool {
+	return idx > 0 && str[idx-1] == '$'
+}
+
+// hasEscapedExpressions returns true if the string contains escaped
+// expressions, which need to be unescaped when the string is resolved.
+func hasEscapedExpressions(str string) bool {
+	return strings.Contains(str, escapedExprStart)
+}
+
 // isStandaloneExpression returns true if the string is a single, complete non-nested expression.
 // It returns an error if it encounters a nested expression.
 func isStandaloneExpression(str string) (bool, error) {
@@ -167,6 +167,26 @@ func TestExtractExpressions(t *testing.T) {
 			want:    []string{},
 			wantErr: true,
 		},
+		{
+			name:  "Escaped expression",
+			input: "$${HOME}",
+			want:  []string{},
+		},
+		{
+			name:  "Mixed escaped and real expressions",
+			input: "$${HOME}/${resource.dir}/$${USER:-root}",
+			want:  []string{"resource.dir"},
+		},
+		{
+			name:  "Escaped and real expressions with the same content",
+			input: "$${resource.field}=${resource.field}",
+			want:  []string{"resource.field"},
+		},
+		{
+			name:  "Escaped expression with nested braces",
+			input: "$${{ .Values.name }} ${resource.field}",
+			want:  []string{"resource.field"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -219,6 +239,7 @@ func TestIsOneShotExpression(t *testing.T) {
 		{"Nested expression but with quotes", "${outer(\"${inner}\")}", true, false},
 		{"Nested closing brace without opening one", "${\"text with }} inside\"}", true, false},
 		{"Nested open brace without closing one", "${\"text with { inside\"}", true, false},
+		{"Escaped expression", "$${resource.field}", false, false},
 	}
 
 	for _, tt := range tests {
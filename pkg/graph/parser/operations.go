@@ -0,0 +1,130 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+
+	"github.com/kubernetes-sigs/kro/pkg/graph/variable"
+)
+
+const (
+	// mergeKey is the key of the map entries whose expression evaluates to a
+	// map merged into the map holding them.
+	mergeKey = "$merge"
+	// spliceKey is the key of the list items whose expression evaluates to a
+	// list spliced into the list holding them.
+	spliceKey = "$splice"
+)
+
+// parseKey returns the descriptor of a map entry whose key contains
+// expressions, or nil if the key is a literal string.
+func parseKey(key string, path string) (*variable.FieldDescriptor, error) {
+	expressions, err := extractExpressions(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(expressions) == 0 && !hasEscapedExpressions(key) {
+		return nil, nil
+	}
+	// Paths quote the keys with double quotes, they can't contain any.
+	if strings.Contains(key, `"`) {
+		return nil, fmt.Errorf("key %s at path %s can't contain double quotes, use single quotes in key expressions",
+			key, path)
+	}
+	standalone, err := isStandaloneExpression(key)
+	if err != nil {
+		return nil, err
+	}
+	return &variable.FieldDescriptor{
+		Path:                 joinPathAndFieldName(path, key),
+		Expressions:          expressions,
+		ExpectedTypes:        []string{"string"},
+		StandaloneExpression: standalone,
+		Operation:            variable.FieldOperationKey,
+	}, nil
+}
+
+// parseMerge returns the descriptor of the $merge entry of a map. Its value
+// must be a standalone expression.
+func parseMerge(value interface{}, path string, schema *spec.Schema) (variable.FieldDescriptor, error) {
+	expression, err := standaloneExpression(value)
+	if err != nil {
+		return variable.FieldDescriptor{}, fmt.Errorf("%s at path %s: %w", mergeKey, path, err)
+	}
+	return variable.FieldDescriptor{
+		Path:                 joinPathAndFieldName(path, mergeKey),
+		Expressions:          []string{expression},
+		ExpectedTypes:        []string{"object"},
+		ExpectedSchema:       schema,
+		StandaloneExpression: true,
+		Operation:            variable.FieldOperationMerge,
+	}, nil
+}
+
+// parseSplice returns the descriptor of a list item holding a single $splice
+// entry, or nil if the item is not a splice.
+func parseSplice(item interface{}, path string, schema *spec.Schema) (*variable.FieldDescriptor, error) {
+	entries, ok := item.(map[string]interface{})
+	if !ok {
+		return nil, nil
+	}
+	value, ok := entries[spliceKey]
+	if !ok {
+		return nil, nil
+	}
+	if len(entries) != 1 {
+		return nil, fmt.Errorf("%s must be the only key of the list item at path %s", spliceKey, path)
+	}
+	expression, err := standaloneExpression(value)
+	if err != nil {
+		return nil, fmt.Errorf("%s at path %s: %w", spliceKey, path, err)
+	}
+	return &variable.FieldDescriptor{
+		Path:                 path,
+		Expressions:          []string{expression},
+		ExpectedTypes:        []string{"array"},
+		ExpectedSchema:       schema,
+		StandaloneExpression: true,
+		Operation:            variable.FieldOperationSplice,
+	}, nil
+}
+
+// standaloneExpression returns the expression of a value, which must be a
+// standalone expression.
+func standaloneExpression(value interface{}) (string, error) {
+	str, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("expected a standalone expression, got %T", value)
+	}
+	standalone, err := isStandaloneExpression(str)
+	if err != nil {
+		return "", err
+	}
+	if !standalone {
+		return "", fmt.Errorf("expected a standalone expression, got %q", str)
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(str, exprStart), exprEnd), nil
+}
+
+// isMapSchema returns true if the schema describes a map, whose keys are
+// arbitrary.
+func isMapSchema(schema *spec.Schema) bool {
+	return schema.AdditionalProperties != nil &&
+		(schema.AdditionalProperties.Allows || schema.AdditionalProperties.Schema != nil)
+}
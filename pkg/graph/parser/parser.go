@@ -198,6 +198,29 @@ func parseObject(field map[string]interface{}, schema *spec.Schema, path string,
 
 	var expressionsFields []variable.FieldDescriptor
 	for fieldName, value := range field {
+		if fieldName == mergeKey {
+			if !isMapSchema(schema) {
+				return nil, fmt.Errorf("%s is only supported in maps, path %s is not a map", mergeKey, path)
+			}
+			merge, err := parseMerge(value, path, schema)
+			if err != nil {
+				return nil, err
+			}
+			expressionsFields = append(expressionsFields, merge)
+			continue
+		}
+
+		key, err := parseKey(fieldName, path)
+		if err != nil {
+			return nil, err
+		}
+		if key != nil {
+			if !isMapSchema(schema) {
+				return nil, fmt.Errorf("key expressions are only supported in maps, path %s is not a map", path)
+			}
+			expressionsFields = append(expressionsFields, *key)
+		}
+
 		fieldSchema, err := getFieldSchema(schema, fieldName)
 		if err != nil {
 			return nil, fmt.Errorf("error getting field schema for path %s: %v", path+"."+fieldName, err)
@@ -241,6 +264,14 @@ func parseArray(field []interface{}, schema *spec.Schema, path string, expectedT
 	var expressionsFields []variable.FieldDescriptor
 	for i, item := range field {
 		itemPath := fmt.Sprintf("%s[%d]", path, i)
+		splice, err := parseSplice(item, itemPath, schema)
+		if err != nil {
+			return nil, err
+		}
+		if splice != nil {
+			expressionsFields = append(expressionsFields, *splice)
+			continue
+		}
 		itemExpressions, err := parseResource(item, itemSchema, itemPath)
 		if err != nil {
 			return nil, err
@@ -274,7 +305,9 @@ func parseString(field string, schema *spec.Schema, path string, expectedTypes [
 	if err != nil {
 		return nil, err
 	}
-	if len(expressions) > 0 {
+	// Strings with escaped expressions only are still resolved, to unescape
+	// them.
+	if len(expressions) > 0 || hasEscapedExpressions(field) {
 		return []variable.FieldDescriptor{{
 			Expressions:   expressions,
 			ExpectedTypes: expectedTypes,
@@ -363,10 +396,11 @@ func isInteger(v interface{}) bool {
 }
 
 // joinPathAndField appends a field name to a path. If the fieldName contains
-// a dot or is empty, the path will be appended using ["fieldName"] instead of
-// .fieldName to avoid ambiguity and simplify parsing back the path.
+// a dot or a bracket, or is empty, the path will be appended using
+// ["fieldName"] instead of .fieldName to avoid ambiguity and simplify parsing
+// back the path.
 func joinPathAndFieldName(path, fieldName string) string {
-	if fieldName == "" || strings.Contains(fieldName, ".") {
+	if fieldName == "" || strings.ContainsAny(fieldName, ".[]") {
 		return fmt.Sprintf("%s[%q]", path, fieldName)
 	}
 	if path == "" {
@@ -1673,3 +1673,206 @@ func TestCollectTypesFromSubSchemas(t *testing.T) {
 		})
 	}
 }
+
+func TestParseEscapedExpressions(t *testing.T) {
+	schema := &spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			AdditionalProperties: &spec.SchemaOrBool{
+				Schema: &spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+			},
+		},
+	}
+	resource := map[string]interface{}{
+		"script":    "#!/bin/sh\necho $${HOME}",
+		"mixed":     "$${PREFIX}-${schema.spec.name}-$${SUFFIX}",
+		"sameText":  "$${schema.spec.name} is ${schema.spec.name}",
+		"plain":     "echo $HOME",
+		"realValue": "${schema.spec.name}",
+	}
+
+	got, err := ParseResource(resource, schema)
+	if err != nil {
+		t.Fatalf("ParseResource() error = %v", err)
+	}
+
+	want := []variable.FieldDescriptor{
+		{
+			Path:          "script",
+			ExpectedTypes: []string{"string"},
+		},
+		{
+			Path:          "mixed",
+			Expressions:   []string{"schema.spec.name"},
+			ExpectedTypes: []string{"string"},
+		},
+		{
+			Path:          "sameText",
+			Expressions:   []string{"schema.spec.name"},
+			ExpectedTypes: []string{"string"},
+		},
+		{
+			Path:                 "realValue",
+			Expressions:          []string{"schema.spec.name"},
+			ExpectedTypes:        []string{"string"},
+			StandaloneExpression: true,
+		},
+	}
+	if !areEqualExpressionFields(got, want) {
+		t.Errorf("ParseResource() = %v, want %v", got, want)
+	}
+}
+
+func TestParseOperations(t *testing.T) {
+	stringSchema := &spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"string"}}}
+	mapSchema := &spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type:                 []string{"object"},
+			AdditionalProperties: &spec.SchemaOrBool{Schema: stringSchema},
+		},
+	}
+	schema := &spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"labels": *mapSchema,
+				"args": {
+					SchemaProps: spec.SchemaProps{
+						Type:  []string{"array"},
+						Items: &spec.SchemaOrArray{Schema: stringSchema},
+					},
+				},
+				"name": *stringSchema,
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		resource map[string]interface{}
+		want     []variable.FieldDescriptor
+		wantErr  string
+	}{
+		{
+			name: "key expressions",
+			resource: map[string]interface{}{
+				"labels": map[string]interface{}{
+					"${schema.spec.key}":              "${schema.spec.value}",
+					"example.com/${schema.spec.name}": "true",
+				},
+			},
+			want: []variable.FieldDescriptor{
+				{
+					Path:                 `labels["${schema.spec.key}"]`,
+					Expressions:          []string{"schema.spec.key"},
+					ExpectedTypes:        []string{"string"},
+					StandaloneExpression: true,
+					Operation:            variable.FieldOperationKey,
+				},
+				{
+					Path:                 `labels["${schema.spec.key}"]`,
+					Expressions:          []string{"schema.spec.value"},
+					ExpectedTypes:        []string{"string"},
+					StandaloneExpression: true,
+				},
+				{
+					Path:          `labels["example.com/${schema.spec.name}"]`,
+					Expressions:   []string{"schema.spec.name"},
+					ExpectedTypes: []string{"string"},
+					Operation:     variable.FieldOperationKey,
+				},
+			},
+		},
+		{
+			name: "merge",
+			resource: map[string]interface{}{
+				"labels": map[string]interface{}{
+					"$merge": "${schema.spec.labels}",
+					"app":    "web",
+				},
+			},
+			want: []variable.FieldDescriptor{
+				{
+					Path:                 "labels.$merge",
+					Expressions:          []string{"schema.spec.labels"},
+					ExpectedTypes:        []string{"object"},
+					StandaloneExpression: true,
+					Operation:            variable.FieldOperationMerge,
+				},
+			},
+		},
+		{
+			name: "splice",
+			resource: map[string]interface{}{
+				"args": []interface{}{
+					"--verbose",
+					map[string]interface{}{"$splice": "${schema.spec.args}"},
+				},
+			},
+			want: []variable.FieldDescriptor{
+				{
+					Path:                 "args[1]",
+					Expressions:          []string{"schema.spec.args"},
+					ExpectedTypes:        []string{"array"},
+					StandaloneExpression: true,
+					Operation:            variable.FieldOperationSplice,
+				},
+			},
+		},
+		{
+			name: "key expression outside of a map",
+			resource: map[string]interface{}{
+				"${schema.spec.key}": "value",
+			},
+			wantErr: "key expressions are only supported in maps",
+		},
+		{
+			name: "merge outside of a map",
+			resource: map[string]interface{}{
+				"$merge": "${schema.spec.fields}",
+			},
+			wantErr: "$merge is only supported in maps",
+		},
+		{
+			name: "merge of a templated string",
+			resource: map[string]interface{}{
+				"labels": map[string]interface{}{"$merge": "prefix-${schema.spec.labels}"},
+			},
+			wantErr: "expected a standalone expression",
+		},
+		{
+			name: "splice with other keys",
+			resource: map[string]interface{}{
+				"args": []interface{}{
+					map[string]interface{}{"$splice": "${schema.spec.args}", "other": "value"},
+				},
+			},
+			wantErr: "must be the only key",
+		},
+		{
+			name: "key with double quotes",
+			resource: map[string]interface{}{
+				"labels": map[string]interface{}{`${schema.spec.labels["key"]}`: "value"},
+			},
+			wantErr: "can't contain double quotes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseResource(tt.resource, schema)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseResource() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseResource() error = %v", err)
+			}
+			if !areEqualExpressionFields(got, tt.want) {
+				t.Errorf("ParseResource() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -35,6 +35,22 @@ func parseSchemalessResource(resource interface{}, path string) ([]variable.Fiel
 	switch field := resource.(type) {
 	case map[string]interface{}:
 		for field, value := range field {
+			if field == mergeKey {
+				merge, err := parseMerge(value, path, nil)
+				if err != nil {
+					return nil, err
+				}
+				expressionsFields = append(expressionsFields, merge)
+				continue
+			}
+			key, err := parseKey(field, path)
+			if err != nil {
+				return nil, err
+			}
+			if key != nil {
+				expressionsFields = append(expressionsFields, *key)
+			}
+
 			fieldPath := joinPathAndFieldName(path, field)
 			fieldExpressions, err := parseSchemalessResource(value, fieldPath)
 			if err != nil {
@@ -45,6 +61,14 @@ func parseSchemalessResource(resource interface{}, path string) ([]variable.Fiel
 	case []interface{}:
 		for i, item := range field {
 			itemPath := fmt.Sprintf("%s[%d]", path, i)
+			splice, err := parseSplice(item, itemPath, nil)
+			if err != nil {
+				return nil, err
+			}
+			if splice != nil {
+				expressionsFields = append(expressionsFields, *splice)
+				continue
+			}
 			itemExpressions, err := parseSchemalessResource(item, itemPath)
 			if err != nil {
 				return nil, err
@@ -68,7 +92,7 @@ func parseSchemalessResource(resource interface{}, path string) ([]variable.Fiel
 			if err != nil {
 				return nil, err
 			}
-			if len(expressions) > 0 {
+			if len(expressions) > 0 || hasEscapedExpressions(field) {
 				expressionsFields = append(expressionsFields, variable.FieldDescriptor{
 					Expressions:   expressions,
 					ExpectedTypes: []string{"any"},
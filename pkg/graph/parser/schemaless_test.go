@@ -26,14 +26,23 @@ func areEqualExpressionFields(a, b []variable.FieldDescriptor) bool {
 		return false
 	}
 
-	sort.Slice(a, func(i, j int) bool { return a[i].Path < a[j].Path })
-	sort.Slice(b, func(i, j int) bool { return b[i].Path < b[j].Path })
+	less := func(fields []variable.FieldDescriptor) func(i, j int) bool {
+		return func(i, j int) bool {
+			if fields[i].Path != fields[j].Path {
+				return fields[i].Path < fields[j].Path
+			}
+			return fields[i].Operation < fields[j].Operation
+		}
+	}
+	sort.Slice(a, less(a))
+	sort.Slice(b, less(b))
 
 	for i := range a {
 		if !equalStrings(a[i].Expressions, b[i].Expressions) ||
 			!areEqualSlices(a[i].ExpectedTypes, b[i].ExpectedTypes) ||
 			a[i].Path != b[i].Path ||
-			a[i].StandaloneExpression != b[i].StandaloneExpression {
+			a[i].StandaloneExpression != b[i].StandaloneExpression ||
+			a[i].Operation != b[i].Operation {
 			return false
 		}
 	}
@@ -78,6 +87,60 @@ func TestParseSchemalessResource(t *testing.T) {
 		want     []variable.FieldDescriptor
 		wantErr  bool
 	}{
+		{
+			name: "Escaped expressions",
+			resource: map[string]interface{}{
+				"script": "echo $${HOME}",
+				"mixed":  "$${HOME}/${resource.dir}",
+				"plain":  "echo $HOME",
+			},
+			want: []variable.FieldDescriptor{
+				{
+					Expressions:   []string{"resource.dir"},
+					ExpectedTypes: []string{"any"},
+					Path:          "mixed",
+				},
+				{
+					ExpectedTypes: []string{"any"},
+					Path:          "script",
+				},
+			},
+		},
+		{
+			name: "Structural operations",
+			resource: map[string]interface{}{
+				"labels": map[string]interface{}{
+					"$merge":          "${resource.labels}",
+					"${resource.key}": "value",
+				},
+				"items": []interface{}{
+					map[string]interface{}{"$splice": "${resource.items}"},
+				},
+			},
+			want: []variable.FieldDescriptor{
+				{
+					Expressions:          []string{"resource.items"},
+					ExpectedTypes:        []string{"array"},
+					Path:                 "items[0]",
+					StandaloneExpression: true,
+					Operation:            variable.FieldOperationSplice,
+				},
+				{
+					Expressions:          []string{"resource.labels"},
+					ExpectedTypes:        []string{"object"},
+					Path:                 "labels.$merge",
+					StandaloneExpression: true,
+					Operation:            variable.FieldOperationMerge,
+				},
+				{
+					Expressions:          []string{"resource.key"},
+					ExpectedTypes:        []string{"string"},
+					Path:                 `labels["${resource.key}"]`,
+					StandaloneExpression: true,
+					Operation:            variable.FieldOperationKey,
+				},
+			},
+		},
 		{
 			name: "Simple string field",
 			resource: map[string]interface{}{
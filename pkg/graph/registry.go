@@ -0,0 +1,309 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8sschema "k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// RGDDependencyError is returned by the builder when a resource graph
+// definition uses the instance kind of another resource graph definition
+// that has not been compiled yet.
+type RGDDependencyError struct {
+	// RGDName is the name of the resource graph definition providing the kind.
+	RGDName string
+	// GVK is the instance kind provided by the resource graph definition.
+	GVK k8sschema.GroupVersionKind
+}
+
+func (e *RGDDependencyError) Error() string {
+	return fmt.Sprintf("waiting for resourcegraphdefinition %q providing kind %s to be active",
+		e.RGDName, e.GVK.String())
+}
+
+// RGDCycleError is returned by the builder when resource graph definitions
+// use each other's instance kinds, directly or through other resource graph
+// definitions. None of them can ever become active.
+type RGDCycleError struct {
+	// Cycle is the names of the resource graph definitions in the cycle, each
+	// one using the instance kind of the next one, the last one using the
+	// instance kind of the first one.
+	Cycle []string
+}
+
+func (e *RGDCycleError) Error() string {
+	return fmt.Sprintf("resourcegraphdefinitions use each other's instance kinds: %s -> %s",
+		strings.Join(e.Cycle, " -> "), e.Cycle[0])
+}
+
+// IsWaitingForRGD returns true if the error only consists of RGDDependencyErrors,
+// meaning the resource graph definition is valid as far as it could be built,
+// but waits for other resource graph definitions to be active.
+func IsWaitingForRGD(err error) bool {
+	if err == nil {
+		return false
+	}
+	var dependencyErr *RGDDependencyError
+	var buildErrs BuildErrors
+	if !errors.As(err, &buildErrs) {
+		return errors.As(err, &dependencyErr)
+	}
+	for _, buildErr := range buildErrs {
+		if !errors.As(buildErr, &dependencyErr) {
+			return false
+		}
+	}
+	return len(buildErrs) > 0
+}
+
+// Registry keeps track of the instance kinds provided by the resource graph
+// definitions, and their compiled graphs. The builder uses it to resolve the
+// schema of a resource that is an instance of another resource graph
+// definition directly from its graph, instead of going through discovery.
+//
+// A kind is first declared, when the controller starts reconciling the resource
+// graph definition providing it, and later registered with its graph, once the
+// resource graph definition is active.
+//
+// The registry also records which resource graph definitions use the kinds of
+// which others, to detect the cycles between them, and to notify the
+// dependents of a resource graph definition when its kind becomes available,
+// changes or goes away.
+//
+// Registry is safe for concurrent use.
+type Registry struct {
+	mu sync.RWMutex
+	// entries is keyed by the GroupVersionKind of the instances.
+	entries map[k8sschema.GroupVersionKind]*registryEntry
+	// dependencies maps the name of a resource graph definition to the names
+	// of the resource graph definitions providing the kinds it uses.
+	dependencies map[string]map[string]bool
+	// onChange are the functions notified of the resource graph definitions
+	// to build again.
+	onChange []func(rgdNames []string)
+}
+
+type registryEntry struct {
+	rgdName string
+	// graph is nil until the resource graph definition is active.
+	graph *Graph
+}
+
+// NewRegistry creates a new, empty, Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		entries:      make(map[k8sschema.GroupVersionKind]*registryEntry),
+		dependencies: make(map[string]map[string]bool),
+	}
+}
+
+// OnChange registers a function called with the names of the resource graph
+// definitions to build again: the dependents of a resource graph definition
+// whose instance kind becomes available, changes or goes away, and the
+// members of a newly found cycle.
+func (r *Registry) OnChange(fn func(rgdNames []string)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.onChange = append(r.onChange, fn)
+}
+
+// Declare records that the given resource graph definition provides the given
+// instance kind. A graph previously registered by the same resource graph
+// definition for that kind is kept, so that dependents keep building against
+// the last active version. The kinds it previously provided are removed.
+func (r *Registry) Declare(rgdName string, gvk k8sschema.GroupVersionKind) {
+	r.mu.Lock()
+	if entry, ok := r.entries[gvk]; ok && entry.rgdName == rgdName {
+		r.mu.Unlock()
+		return
+	}
+	removed := r.removeEntries(rgdName, gvk)
+	r.entries[gvk] = &registryEntry{rgdName: rgdName}
+	var notify []string
+	if removed {
+		notify = r.dependents(rgdName)
+	}
+	r.mu.Unlock()
+
+	r.notify(notify)
+}
+
+// Register records the compiled graph of the given resource graph definition.
+// Its dependents are notified if its instance kind wasn't available yet, or if
+// its schema changed.
+func (r *Registry) Register(rgdName string, g *Graph) {
+	gvk := g.instanceGVK()
+
+	r.mu.Lock()
+	changed := true
+	if entry, ok := r.entries[gvk]; ok && entry.rgdName == rgdName && entry.graph != nil {
+		changed = !equality.Semantic.DeepEqual(entry.graph.Instance.GetCRD().Spec, g.Instance.GetCRD().Spec)
+	}
+	if r.removeEntries(rgdName, gvk) {
+		changed = true
+	}
+	r.entries[gvk] = &registryEntry{rgdName: rgdName, graph: g}
+	var notify []string
+	if changed {
+		notify = r.dependents(rgdName)
+	}
+	r.mu.Unlock()
+
+	r.notify(notify)
+}
+
+// Deregister removes all the kinds provided by the given resource graph
+// definition, and the kinds it uses, and notifies its dependents.
+func (r *Registry) Deregister(rgdName string) {
+	r.mu.Lock()
+	r.removeEntries(rgdName, k8sschema.GroupVersionKind{})
+	delete(r.dependencies, rgdName)
+	notify := r.dependents(rgdName)
+	r.mu.Unlock()
+
+	r.notify(notify)
+}
+
+// Lookup returns the name of the resource graph definition providing the
+// given kind and its compiled graph. The graph is nil if the kind is declared
+// but not registered yet. The boolean is false if no resource graph definition
+// provides the kind.
+func (r *Registry) Lookup(gvk k8sschema.GroupVersionKind) (string, *Graph, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entry, ok := r.entries[gvk]
+	if !ok {
+		return "", nil, false
+	}
+	return entry.rgdName, entry.graph, true
+}
+
+// addDependency records that the given resource graph definition uses the
+// instance kind of the provider. If the provider uses, directly or not, the
+// kind of the resource graph definition, an RGDCycleError is returned. The
+// other members of the cycle are notified the first time it is found, so that
+// they report it too instead of waiting for each other.
+func (r *Registry) addDependency(rgdName, providerName string) error {
+	r.mu.Lock()
+	providers, ok := r.dependencies[rgdName]
+	if !ok {
+		providers = make(map[string]bool)
+		r.dependencies[rgdName] = providers
+	}
+	added := !providers[providerName]
+	providers[providerName] = true
+
+	path := r.findPath(providerName, rgdName, map[string]bool{})
+	r.mu.Unlock()
+
+	if path == nil {
+		return nil
+	}
+	cycle := append([]string{rgdName}, path[:len(path)-1]...)
+	if added {
+		r.notify(cycle[1:])
+	}
+	return &RGDCycleError{Cycle: cycle}
+}
+
+// setDependencies replaces the names of the resource graph definitions whose
+// kinds are used by the given resource graph definition, once it is built.
+func (r *Registry) setDependencies(rgdName string, providerNames []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(providerNames) == 0 {
+		delete(r.dependencies, rgdName)
+		return
+	}
+	providers := make(map[string]bool, len(providerNames))
+	for _, providerName := range providerNames {
+		providers[providerName] = true
+	}
+	r.dependencies[rgdName] = providers
+}
+
+// findPath returns the names of the resource graph definitions on a path from
+// one to another through the kinds they use, both included, or nil if there is
+// no such path. It must be called with the lock held.
+func (r *Registry) findPath(from, to string, visited map[string]bool) []string {
+	if visited[from] {
+		return nil
+	}
+	visited[from] = true
+	providers := make([]string, 0, len(r.dependencies[from]))
+	for providerName := range r.dependencies[from] {
+		providers = append(providers, providerName)
+	}
+	slices.Sort(providers)
+	for _, providerName := range providers {
+		if providerName == to {
+			return []string{from, to}
+		}
+		if path := r.findPath(providerName, to, visited); path != nil {
+			return append([]string{from}, path...)
+		}
+	}
+	return nil
+}
+
+// removeEntries removes the kinds provided by the given resource graph
+// definition, but the one to keep, and returns true if there were any. It must
+// be called with the lock held.
+func (r *Registry) removeEntries(rgdName string, keep k8sschema.GroupVersionKind) bool {
+	removed := false
+	for gvk, entry := range r.entries {
+		if entry.rgdName == rgdName && gvk != keep {
+			delete(r.entries, gvk)
+			removed = true
+		}
+	}
+	return removed
+}
+
+// dependents returns the names of the resource graph definitions using the
+// kinds of the given one, sorted. It must be called with the lock held.
+func (r *Registry) dependents(providerName string) []string {
+	var names []string
+	for rgdName, providers := range r.dependencies {
+		if providers[providerName] {
+			names = append(names, rgdName)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// notify calls the registered functions with the names of the resource graph
+// definitions to build again, if any. It must be called without the lock.
+func (r *Registry) notify(rgdNames []string) {
+	if len(rgdNames) == 0 {
+		return
+	}
+	r.mu.RLock()
+	onChange := r.onChange
+	r.mu.RUnlock()
+	for _, fn := range onChange {
+		fn(rgdNames)
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	k8sschema "k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// registryGraph returns a graph providing the given kind, whose instances have
+// the given spec fields.
+func registryGraph(kind string, fields ...string) *Graph {
+	properties := map[string]extv1.JSONSchemaProps{}
+	for _, field := range fields {
+		properties[field] = extv1.JSONSchemaProps{Type: "string"}
+	}
+	return &Graph{Instance: &Resource{crd: &extv1.CustomResourceDefinition{
+		Spec: extv1.CustomResourceDefinitionSpec{
+			Group: "kro.run",
+			Names: extv1.CustomResourceDefinitionNames{Kind: kind},
+			Versions: []extv1.CustomResourceDefinitionVersion{{
+				Name: "v1alpha1",
+				Schema: &extv1.CustomResourceValidation{
+					OpenAPIV3Schema: &extv1.JSONSchemaProps{Properties: properties},
+				},
+			}},
+		},
+	}}}
+}
+
+func TestRegistry_NotifiesDependents(t *testing.T) {
+	databaseGVK := k8sschema.GroupVersionKind{Group: "kro.run", Version: "v1alpha1", Kind: "Database"}
+	registry := NewRegistry()
+	var notified [][]string
+	registry.OnChange(func(rgdNames []string) {
+		notified = append(notified, rgdNames)
+	})
+
+	registry.Declare("database", databaseGVK)
+	require.NoError(t, registry.addDependency("application", "database"))
+	require.NoError(t, registry.addDependency("service", "database"))
+	assert.Empty(t, notified)
+
+	// The kind becomes available.
+	registry.Register("database", registryGraph("Database", "engine"))
+	assert.Equal(t, [][]string{{"application", "service"}}, notified)
+
+	// The graph is built again, without changes.
+	registry.Declare("database", databaseGVK)
+	registry.Register("database", registryGraph("Database", "engine"))
+	assert.Len(t, notified, 1)
+
+	// The schema changes.
+	registry.Register("database", registryGraph("Database", "engine", "version"))
+	assert.Len(t, notified, 2)
+
+	// application doesn't use the kind anymore.
+	registry.setDependencies("application", nil)
+	registry.Deregister("database")
+	assert.Equal(t, []string{"service"}, notified[2])
+	_, _, ok := registry.Lookup(databaseGVK)
+	assert.False(t, ok)
+}
+
+func TestRegistry_RemovesPreviousKind(t *testing.T) {
+	registry := NewRegistry()
+	oldGVK := k8sschema.GroupVersionKind{Group: "kro.run", Version: "v1alpha1", Kind: "Database"}
+	newGVK := k8sschema.GroupVersionKind{Group: "kro.run", Version: "v1alpha1", Kind: "PostgresDatabase"}
+
+	registry.Declare("database", oldGVK)
+	registry.Register("database", registryGraph("Database"))
+	registry.Declare("database", newGVK)
+
+	_, _, ok := registry.Lookup(oldGVK)
+	assert.False(t, ok)
+	name, g, ok := registry.Lookup(newGVK)
+	assert.True(t, ok)
+	assert.Equal(t, "database", name)
+	assert.Nil(t, g)
+}
+
+func TestRegistry_DetectsCycles(t *testing.T) {
+	registry := NewRegistry()
+	require.NoError(t, registry.addDependency("a", "b"))
+	require.NoError(t, registry.addDependency("b", "c"))
+
+	err := registry.addDependency("c", "a")
+	var cycleErr *RGDCycleError
+	require.ErrorAs(t, err, &cycleErr)
+	assert.Equal(t, []string{"c", "a", "b"}, cycleErr.Cycle)
+	assert.Equal(t, "resourcegraphdefinitions use each other's instance kinds: c -> a -> b -> c", err.Error())
+
+	// Breaking the cycle.
+	registry.setDependencies("b", nil)
+	assert.NoError(t, registry.addDependency("c", "a"))
+}
+
+func TestIsWaitingForRGD(t *testing.T) {
+	dependencyErr := &RGDDependencyError{RGDName: "database"}
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "dependency", err: dependencyErr, want: true},
+		{name: "wrapped dependency", err: fmt.Errorf("failed: %w", dependencyErr), want: true},
+		{
+			name: "build errors of dependencies",
+			err:  BuildErrors{{Code: ErrorCodeUnknownKind, Err: dependencyErr}},
+			want: true,
+		},
+		{
+			name: "build errors with other problems",
+			err: BuildErrors{
+				{Code: ErrorCodeUnknownKind, Err: dependencyErr},
+				{Code: ErrorCodeInvalidExpression, Err: errors.New("bad expression")},
+			},
+			want: false,
+		},
+		{name: "other error", err: errors.New("boom"), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsWaitingForRGD(tt.err))
+		})
+	}
+}
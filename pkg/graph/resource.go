@@ -18,10 +18,12 @@ import (
 	"slices"
 
 	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/kube-openapi/pkg/validation/spec"
 
+	"github.com/kubernetes-sigs/kro/api/v1alpha1"
 	"github.com/kubernetes-sigs/kro/pkg/graph/variable"
 )
 
@@ -60,6 +62,9 @@ type Resource struct {
 	variables []*variable.ResourceField
 	// dependencies is a list of the resources this resource depends on.
 	dependencies []string
+	// explicitDependencies is the list of the resources this resource depends
+	// on through dependsOn. They are also part of dependencies.
+	explicitDependencies []string
 	// readyWhenExpressions is a list of the expressions that need to be evaluated
 	// before the resource is considered ready.
 	readyWhenExpressions []string
@@ -75,6 +80,21 @@ type Resource struct {
 	order int
 	// isExternalRef indicates if the resource should only be read and not created/updated
 	isExternalRef bool
+	// dependsOnRGD is the name of the resource graph definition providing the
+	// kind of this resource, if the resource is an instance of another resource
+	// graph definition.
+	dependsOnRGD string
+	// propagationPolicy is the propagation policy used when deleting the
+	// resource. The cluster default applies when empty.
+	propagationPolicy metav1.DeletionPropagation
+	// updateStrategy is how the resource is updated once it exists.
+	updateStrategy v1alpha1.UpdateStrategy
+	// ignoreFields are the paths of the fields only set when the resource is
+	// created.
+	ignoreFields []string
+	// forceApply indicates if the fields managed by other field managers are
+	// taken over when applying the resource.
+	forceApply bool
 }
 
 // GetDependencies returns the dependencies of the resource.
@@ -82,6 +102,12 @@ func (r *Resource) GetDependencies() []string {
 	return r.dependencies
 }
 
+// GetExplicitDependencies returns the dependencies declared in the dependsOn
+// field of the resource.
+func (r *Resource) GetExplicitDependencies() []string {
+	return r.explicitDependencies
+}
+
 // HasDependency checks if the resource has a dependency on another resource.
 func (r *Resource) HasDependency(dep string) bool {
 	for _, d := range r.dependencies {
@@ -166,6 +192,36 @@ func (r *Resource) IsExternalRef() bool {
 	return r.isExternalRef
 }
 
+// GetDependsOnRGD returns the name of the resource graph definition providing
+// the kind of this resource, or an empty string if the kind isn't provided by
+// a resource graph definition.
+func (r *Resource) GetDependsOnRGD() string {
+	return r.dependsOnRGD
+}
+
+// GetPropagationPolicy returns the propagation policy used when deleting the
+// resource, or an empty string for the cluster default.
+func (r *Resource) GetPropagationPolicy() metav1.DeletionPropagation {
+	return r.propagationPolicy
+}
+
+// GetUpdateStrategy returns how the resource is updated once it exists.
+func (r *Resource) GetUpdateStrategy() v1alpha1.UpdateStrategy {
+	return r.updateStrategy
+}
+
+// GetIgnoreFields returns the paths of the fields only set when the resource
+// is created.
+func (r *Resource) GetIgnoreFields() []string {
+	return r.ignoreFields
+}
+
+// IsForceApply returns true if the fields managed by other field managers are
+// taken over when applying the resource.
+func (r *Resource) IsForceApply() bool {
+	return r.forceApply
+}
+
 // DeepCopy returns a deep copy of the resource.
 func (r *Resource) DeepCopy() *Resource {
 	return &Resource{
@@ -176,9 +232,15 @@ func (r *Resource) DeepCopy() *Resource {
 		originalObject:         r.originalObject.DeepCopy(),
 		variables:              slices.Clone(r.variables),
 		dependencies:           slices.Clone(r.dependencies),
+		explicitDependencies:   slices.Clone(r.explicitDependencies),
 		readyWhenExpressions:   slices.Clone(r.readyWhenExpressions),
 		includeWhenExpressions: slices.Clone(r.includeWhenExpressions),
 		namespaced:             r.namespaced,
 		isExternalRef:          r.isExternalRef,
+		dependsOnRGD:           r.dependsOnRGD,
+		propagationPolicy:      r.propagationPolicy,
+		updateStrategy:         r.updateStrategy,
+		ignoreFields:           slices.Clone(r.ignoreFields),
+		forceApply:             r.forceApply,
 	}
 }
@@ -0,0 +1,40 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package offline resolves the schemas of resources without a cluster: the
+// built-in types from the OpenAPI definitions generated from k8s.io/api, the
+// custom resources from local CRD files, and any resource from the schema
+// bundles snapshotted from clusters. Only the offline tools use it, so the
+// generated definitions aren't linked into the controller.
+package offline
+
+import (
+	"sync"
+
+	"k8s.io/apiserver/pkg/cel/openapi/resolver"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	builtinopenapi "github.com/kubernetes-sigs/kro/pkg/generated/openapi"
+)
+
+// BuiltinResolver returns the resolver of the schemas of the built-in types,
+// the ones registered in the client-go scheme, for when there is no cluster to
+// serve them. The schemas are looked up in the OpenAPI definitions generated
+// from k8s.io/api, like the ones the API server serves, so they hold the
+// descriptions, enums, formats and validations of the fields.
+//
+// The resolver only reads the definitions, and can be shared.
+var BuiltinResolver = sync.OnceValue(func() *resolver.DefinitionsSchemaResolver {
+	return resolver.NewDefinitionsSchemaResolver(builtinopenapi.GetOpenAPIDefinitions, scheme.Scheme)
+})
@@ -0,0 +1,252 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package offline
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/cel/openapi/resolver"
+	"k8s.io/client-go/discovery"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// SchemaBundleVersion is the version of the format of the schema bundles. It
+// is bumped on incompatible changes, bundles of other versions being
+// rejected.
+const SchemaBundleVersion = "v1"
+
+// SchemaBundle is a snapshot of the OpenAPI schemas of the resources served
+// by a cluster, to build resource graph definitions against without the
+// cluster, e.g. in CI.
+type SchemaBundle struct {
+	// Version is the version of the format of the bundle.
+	Version string `json:"version"`
+	// KubernetesVersion is the version of the cluster the schemas were
+	// snapshotted from.
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+	// Schemas are the schemas of the resources, sorted by GroupVersionKind.
+	Schemas []BundledSchema `json:"schemas"`
+}
+
+// BundledSchema is the schema of a resource in a SchemaBundle, along with its
+// scope.
+type BundledSchema struct {
+	Group      string       `json:"group,omitempty"`
+	Version    string       `json:"version"`
+	Kind       string       `json:"kind"`
+	Namespaced bool         `json:"namespaced"`
+	Schema     *spec.Schema `json:"schema"`
+}
+
+// GroupVersionKind returns the GroupVersionKind of the resource.
+func (s *BundledSchema) GroupVersionKind() schema.GroupVersionKind {
+	return schema.GroupVersionKind{Group: s.Group, Version: s.Version, Kind: s.Kind}
+}
+
+// SnapshotSchemas snapshots the schemas of the resources served by a cluster,
+// and their scope. The resources are listed by the discovery client, and
+// their schemas resolved by the schema resolver, usually a
+// ClientDiscoveryResolver of the same client. When selected isn't nil, only
+// the resources it selects are snapshotted.
+//
+// The resources whose schemas can't be resolved, like those of the aggregated
+// APIs without OpenAPI schemas, are left out of the bundle and reported in
+// the returned error, along with the bundle of the others.
+func SnapshotSchemas(
+	client discovery.DiscoveryInterface,
+	schemaResolver resolver.SchemaResolver,
+	selected func(schema.GroupVersionKind) bool,
+) (*SchemaBundle, error) {
+	bundle := &SchemaBundle{Version: SchemaBundleVersion}
+	if serverVersion, err := client.ServerVersion(); err == nil {
+		bundle.KubernetesVersion = serverVersion.GitVersion
+	}
+
+	var errs []error
+	_, lists, err := client.ServerGroupsAndResources()
+	if err != nil {
+		if !discovery.IsGroupDiscoveryFailedError(err) {
+			return nil, fmt.Errorf("failed to list the resources: %w", err)
+		}
+		// The groups that could be discovered are still snapshotted.
+		errs = append(errs, err)
+	}
+
+	seen := map[schema.GroupVersionKind]bool{}
+	for _, list := range lists {
+		gv, err := schema.ParseGroupVersion(list.GroupVersion)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid group version %q: %w", list.GroupVersion, err))
+			continue
+		}
+		for _, resource := range list.APIResources {
+			// Subresources share the kinds of their resources, or aren't
+			// resources at all.
+			if strings.Contains(resource.Name, "/") {
+				continue
+			}
+			gvk := gv.WithKind(resource.Kind)
+			if seen[gvk] || (selected != nil && !selected(gvk)) {
+				continue
+			}
+			seen[gvk] = true
+
+			s, err := schemaResolver.ResolveSchema(gvk)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to resolve schema of %v: %w", gvk, err))
+				continue
+			}
+			bundle.Schemas = append(bundle.Schemas, BundledSchema{
+				Group:      gvk.Group,
+				Version:    gvk.Version,
+				Kind:       gvk.Kind,
+				Namespaced: resource.Namespaced,
+				Schema:     s,
+			})
+		}
+	}
+	sort.Slice(bundle.Schemas, func(i, j int) bool {
+		return bundle.Schemas[i].GroupVersionKind().String() < bundle.Schemas[j].GroupVersionKind().String()
+	})
+	return bundle, errors.Join(errs...)
+}
+
+// Write writes the bundle as JSON.
+func (b *SchemaBundle) Write(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(b)
+}
+
+// ReadSchemaBundle reads a bundle written by SchemaBundle.Write.
+func ReadSchemaBundle(r io.Reader) (*SchemaBundle, error) {
+	bundle := &SchemaBundle{}
+	if err := json.NewDecoder(r).Decode(bundle); err != nil {
+		return nil, fmt.Errorf("failed to decode schema bundle: %w", err)
+	}
+	if bundle.Version != SchemaBundleVersion {
+		return nil, fmt.Errorf("unsupported schema bundle version %q, expected %q", bundle.Version, SchemaBundleVersion)
+	}
+	return bundle, nil
+}
+
+// BundleResolver resolves the schemas of the resources from schema bundles.
+// Like a discovery client, it also lists the namespaced resources, so that it
+// can stand for the cluster the bundles were snapshotted from when building
+// resource graph definitions.
+type BundleResolver struct {
+	schemas    map[schema.GroupVersionKind]*spec.Schema
+	namespaced map[schema.GroupVersionKind]bool
+}
+
+// NewBundleResolver creates a resolver of the schemas of the given bundles.
+// When several bundles hold the schema of a resource, the last one wins.
+func NewBundleResolver(bundles ...*SchemaBundle) *BundleResolver {
+	r := &BundleResolver{
+		schemas:    map[schema.GroupVersionKind]*spec.Schema{},
+		namespaced: map[schema.GroupVersionKind]bool{},
+	}
+	for _, bundle := range bundles {
+		for _, s := range bundle.Schemas {
+			r.schemas[s.GroupVersionKind()] = s.Schema
+			r.namespaced[s.GroupVersionKind()] = s.Namespaced
+		}
+	}
+	return r
+}
+
+// LoadBundleResolver creates a resolver of the schemas of the bundles in the
+// given files, or in the JSON files of the given directories.
+func LoadBundleResolver(paths ...string) (*BundleResolver, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read schema bundle: %w", err)
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		matches, err := filepath.Glob(filepath.Join(path, "*.json"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list schema bundles in %s: %w", path, err)
+		}
+		files = append(files, matches...)
+	}
+
+	bundles := make([]*SchemaBundle, 0, len(files))
+	for _, file := range files {
+		bundle, err := readSchemaBundleFile(file)
+		if err != nil {
+			return nil, err
+		}
+		bundles = append(bundles, bundle)
+	}
+	return NewBundleResolver(bundles...), nil
+}
+
+// readSchemaBundleFile reads the bundle in the given file.
+func readSchemaBundleFile(path string) (*SchemaBundle, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema bundle: %w", err)
+	}
+	defer f.Close()
+	bundle, err := ReadSchemaBundle(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return bundle, nil
+}
+
+// ResolveSchema returns the schema of the resource with the given
+// GroupVersionKind.
+func (r *BundleResolver) ResolveSchema(gvk schema.GroupVersionKind) (*spec.Schema, error) {
+	s, ok := r.schemas[gvk]
+	if !ok {
+		return nil, fmt.Errorf("cannot resolve %v: %w", gvk, resolver.ErrSchemaNotFound)
+	}
+	return s, nil
+}
+
+// ServerPreferredNamespacedResources returns the namespaced resources of the
+// bundles, like the method of the discovery clients.
+func (r *BundleResolver) ServerPreferredNamespacedResources() ([]*metav1.APIResourceList, error) {
+	resources := map[schema.GroupVersion][]metav1.APIResource{}
+	for gvk, namespaced := range r.namespaced {
+		if namespaced {
+			resources[gvk.GroupVersion()] = append(resources[gvk.GroupVersion()],
+				metav1.APIResource{Kind: gvk.Kind, Namespaced: true})
+		}
+	}
+
+	lists := make([]*metav1.APIResourceList, 0, len(resources))
+	for gv, apiResources := range resources {
+		sort.Slice(apiResources, func(i, j int) bool { return apiResources[i].Kind < apiResources[j].Kind })
+		lists = append(lists, &metav1.APIResourceList{GroupVersion: gv.String(), APIResources: apiResources})
+	}
+	sort.Slice(lists, func(i, j int) bool { return lists[i].GroupVersion < lists[j].GroupVersion })
+	return lists, nil
+}
@@ -0,0 +1,127 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package offline
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/apiserver/pkg/cel/openapi/resolver"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+
+	"github.com/kubernetes-sigs/kro/pkg/testutil/k8s"
+)
+
+func TestSnapshotSchemas(t *testing.T) {
+	fakeResolver, fakeDiscovery := k8s.NewFakeResolver()
+	fakeDiscovery.FakedServerVersion = &version.Info{GitVersion: "v1.33.1"}
+	fakeDiscovery.Resources = append(fakeDiscovery.Resources,
+		&metav1.APIResourceList{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{
+				{Name: "pods/status", Namespaced: true, Kind: "Pod"},
+				{Name: "pods/eviction", Namespaced: true, Kind: "Eviction"},
+			},
+		},
+		&metav1.APIResourceList{
+			GroupVersion: "metrics.k8s.io/v1beta1",
+			APIResources: []metav1.APIResource{{Name: "pods", Namespaced: true, Kind: "PodMetrics"}},
+		},
+	)
+
+	t.Run("all resources", func(t *testing.T) {
+		bundle, err := SnapshotSchemas(fakeDiscovery, fakeResolver, nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "PodMetrics")
+		require.NotNil(t, bundle)
+
+		assert.Equal(t, SchemaBundleVersion, bundle.Version)
+		assert.Equal(t, "v1.33.1", bundle.KubernetesVersion)
+		require.Len(t, bundle.Schemas, 9)
+		assert.Equal(t, "Pod", bundle.Schemas[0].Kind)
+		for _, s := range bundle.Schemas {
+			assert.NotEqual(t, "Eviction", s.Kind)
+			assert.Equal(t, s.Kind != "CustomResourceDefinition", s.Namespaced, s.Kind)
+		}
+	})
+
+	t.Run("selected resources", func(t *testing.T) {
+		bundle, err := SnapshotSchemas(fakeDiscovery, fakeResolver, func(gvk schema.GroupVersionKind) bool {
+			return gvk.Group == "ec2.services.k8s.aws"
+		})
+		require.NoError(t, err)
+		kinds := []string{}
+		for _, s := range bundle.Schemas {
+			kinds = append(kinds, s.Kind)
+		}
+		assert.Equal(t, []string{"SecurityGroup", "Subnet", "VPC"}, kinds)
+	})
+}
+
+func TestBundleResolver(t *testing.T) {
+	fakeResolver, fakeDiscovery := k8s.NewFakeResolver()
+	snapshot, err := SnapshotSchemas(fakeDiscovery, fakeResolver, nil)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, snapshot.Write(&buf))
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "cluster.json"), buf.Bytes(), 0o600))
+
+	r, err := LoadBundleResolver(dir)
+	require.NoError(t, err)
+
+	vpcGVK := schema.GroupVersionKind{Group: "ec2.services.k8s.aws", Version: "v1alpha1", Kind: "VPC"}
+	vpc, err := r.ResolveSchema(vpcGVK)
+	require.NoError(t, err)
+	expected, err := fakeResolver.ResolveSchema(vpcGVK)
+	require.NoError(t, err)
+	assert.Equal(t, expected.Properties["spec"].Properties["cidrBlocks"].Type,
+		vpc.Properties["spec"].Properties["cidrBlocks"].Type)
+	assert.Equal(t, spec.StringOrArray{"object"}, vpc.Properties["status"].Type)
+
+	_, err = r.ResolveSchema(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"})
+	assert.ErrorIs(t, err, resolver.ErrSchemaNotFound)
+
+	lists, err := r.ServerPreferredNamespacedResources()
+	require.NoError(t, err)
+	groupVersions := []string{}
+	for _, list := range lists {
+		groupVersions = append(groupVersions, list.GroupVersion)
+	}
+	assert.Equal(t, []string{
+		"ec2.services.k8s.aws/v1alpha1",
+		"eks.services.k8s.aws/v1alpha1",
+		"iam.services.k8s.aws/v1alpha1",
+		"v1",
+	}, groupVersions)
+
+	t.Run("unsupported version", func(t *testing.T) {
+		_, err := ReadSchemaBundle(bytes.NewBufferString(`{"version": "v0", "schemas": []}`))
+		assert.ErrorContains(t, err, "unsupported schema bundle version")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := LoadBundleResolver(filepath.Join(dir, "missing.json"))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,248 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package offline
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/apiserver/pkg/cel/openapi/resolver"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+
+	kroschema "github.com/kubernetes-sigs/kro/pkg/graph/schema"
+	schemaresolver "github.com/kubernetes-sigs/kro/pkg/graph/schema/resolver"
+)
+
+// clusterScopedKinds are the built-in kinds whose objects aren't namespaced.
+var clusterScopedKinds = map[schema.GroupKind]bool{
+	{Group: "", Kind: "Namespace"}:                                                    true,
+	{Group: "", Kind: "Node"}:                                                         true,
+	{Group: "", Kind: "PersistentVolume"}:                                             true,
+	{Group: "", Kind: "ComponentStatus"}:                                              true,
+	{Group: "admissionregistration.k8s.io", Kind: "MutatingWebhookConfiguration"}:     true,
+	{Group: "admissionregistration.k8s.io", Kind: "ValidatingWebhookConfiguration"}:   true,
+	{Group: "admissionregistration.k8s.io", Kind: "ValidatingAdmissionPolicy"}:        true,
+	{Group: "admissionregistration.k8s.io", Kind: "ValidatingAdmissionPolicyBinding"}: true,
+	{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}:                 true,
+	{Group: "apiregistration.k8s.io", Kind: "APIService"}:                             true,
+	{Group: "certificates.k8s.io", Kind: "CertificateSigningRequest"}:                 true,
+	{Group: "certificates.k8s.io", Kind: "ClusterTrustBundle"}:                        true,
+	{Group: "flowcontrol.apiserver.k8s.io", Kind: "FlowSchema"}:                       true,
+	{Group: "flowcontrol.apiserver.k8s.io", Kind: "PriorityLevelConfiguration"}:       true,
+	{Group: "networking.k8s.io", Kind: "IngressClass"}:                                true,
+	{Group: "networking.k8s.io", Kind: "IPAddress"}:                                   true,
+	{Group: "networking.k8s.io", Kind: "ServiceCIDR"}:                                 true,
+	{Group: "node.k8s.io", Kind: "RuntimeClass"}:                                      true,
+	{Group: "rbac.authorization.k8s.io", Kind: "ClusterRole"}:                         true,
+	{Group: "rbac.authorization.k8s.io", Kind: "ClusterRoleBinding"}:                  true,
+	{Group: "resource.k8s.io", Kind: "DeviceClass"}:                                   true,
+	{Group: "resource.k8s.io", Kind: "ResourceSlice"}:                                 true,
+	{Group: "scheduling.k8s.io", Kind: "PriorityClass"}:                               true,
+	{Group: "storage.k8s.io", Kind: "CSIDriver"}:                                      true,
+	{Group: "storage.k8s.io", Kind: "CSINode"}:                                        true,
+	{Group: "storage.k8s.io", Kind: "StorageClass"}:                                   true,
+	{Group: "storage.k8s.io", Kind: "VolumeAttachment"}:                               true,
+	{Group: "storage.k8s.io", Kind: "VolumeAttributesClass"}:                          true,
+}
+
+// LocalResolver resolves the schemas of the resources without a cluster. The
+// schemas of the custom resources are read from CustomResourceDefinition
+// manifests, and the schemas of the built-in types are looked up in their
+// generated OpenAPI definitions.
+//
+// Like a discovery client, it also lists the namespaced resources, so that it
+// can stand for a cluster when building resource graph definitions offline.
+type LocalResolver struct {
+	builtin *resolver.DefinitionsSchemaResolver
+
+	mu sync.RWMutex
+	// schemas are the schemas of the custom resources, by GroupVersionKind.
+	schemas map[schema.GroupVersionKind]*spec.Schema
+	// namespaced tells whether the objects of the custom resources are
+	// namespaced.
+	namespaced map[schema.GroupVersionKind]bool
+}
+
+// NewLocalResolver creates a resolver of the built-in types, to which the
+// custom resources are added with AddCRD or LoadCRDs.
+func NewLocalResolver() *LocalResolver {
+	return &LocalResolver{
+		builtin:    BuiltinResolver(),
+		schemas:    map[schema.GroupVersionKind]*spec.Schema{},
+		namespaced: map[schema.GroupVersionKind]bool{},
+	}
+}
+
+// AddCRD adds the schemas of the served versions of the custom resource.
+func (r *LocalResolver) AddCRD(crd *extv1.CustomResourceDefinition) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, version := range crd.Spec.Versions {
+		if !version.Served {
+			continue
+		}
+		gvk := schema.GroupVersionKind{Group: crd.Spec.Group, Version: version.Name, Kind: crd.Spec.Names.Kind}
+		s := &spec.Schema{}
+		if version.Schema != nil && version.Schema.OpenAPIV3Schema != nil {
+			var err error
+			s, err = kroschema.ConvertJSONSchemaPropsToSpecSchema(version.Schema.OpenAPIV3Schema)
+			if err != nil {
+				return fmt.Errorf("failed to convert schema of %v: %w", gvk, err)
+			}
+		}
+		// The API server serves the schemas of the custom resources along with
+		// the fields common to all the objects.
+		if s.Properties == nil {
+			s.Properties = map[string]spec.Schema{}
+		}
+		s.Properties["apiVersion"] = *spec.StringProperty()
+		s.Properties["kind"] = *spec.StringProperty()
+		s.Properties["metadata"] = *schemaresolver.ObjectMetaSchema()
+
+		r.schemas[gvk] = s
+		r.namespaced[gvk] = crd.Spec.Scope == extv1.NamespaceScoped
+	}
+	return nil
+}
+
+// LoadCRDs adds the custom resources defined in the given files, or in the
+// files of the given directories and their subdirectories. The YAML and JSON
+// files can hold several documents, the ones that aren't
+// CustomResourceDefinitions are ignored. Files that can't be read or decoded
+// are reported, but don't prevent the other files from being loaded.
+func (r *LocalResolver) LoadCRDs(paths ...string) error {
+	var errs []error
+	for _, root := range paths {
+		err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() {
+				return nil
+			}
+			switch filepath.Ext(path) {
+			case ".yaml", ".yml", ".json":
+			default:
+				if path != root {
+					return nil
+				}
+			}
+			if err := r.loadCRDFile(path); err != nil {
+				errs = append(errs, err)
+			}
+			return nil
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to read %s: %w", root, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// loadCRDFile adds the custom resources defined in the documents of the file.
+func (r *LocalResolver) loadCRDFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	decoder := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+	for {
+		obj := &unstructured.Unstructured{}
+		if err := decoder.Decode(&obj.Object); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("failed to decode %s: %w", path, err)
+		}
+		if obj.GroupVersionKind() != extv1.SchemeGroupVersion.WithKind("CustomResourceDefinition") {
+			continue
+		}
+		crd := &extv1.CustomResourceDefinition{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, crd); err != nil {
+			return fmt.Errorf("failed to decode CustomResourceDefinition %s in %s: %w", obj.GetName(), path, err)
+		}
+		if err := r.AddCRD(crd); err != nil {
+			return fmt.Errorf("invalid CustomResourceDefinition %s in %s: %w", crd.Name, path, err)
+		}
+	}
+}
+
+// ResolveSchema returns the schema of the custom resource with the given
+// GroupVersionKind if there is one, or else of the built-in type.
+func (r *LocalResolver) ResolveSchema(gvk schema.GroupVersionKind) (*spec.Schema, error) {
+	r.mu.RLock()
+	s, ok := r.schemas[gvk]
+	r.mu.RUnlock()
+	if ok {
+		return s, nil
+	}
+	s, err := r.builtin.ResolveSchema(gvk)
+	if err != nil {
+		return nil, fmt.Errorf("cannot resolve %v: %w", gvk, resolver.ErrSchemaNotFound)
+	}
+	return s, nil
+}
+
+// ServerPreferredNamespacedResources returns the namespaced resources among
+// the custom resources and the built-in types, like the method of the
+// discovery clients.
+func (r *LocalResolver) ServerPreferredNamespacedResources() ([]*metav1.APIResourceList, error) {
+	resources := map[schema.GroupVersion][]metav1.APIResource{}
+	for gvk := range scheme.Scheme.AllKnownTypes() {
+		if clusterScopedKinds[gvk.GroupKind()] {
+			continue
+		}
+		obj, err := scheme.Scheme.New(gvk)
+		if err != nil {
+			continue
+		}
+		if _, ok := obj.(metav1.Object); !ok {
+			// Lists and options aren't resources.
+			continue
+		}
+		resources[gvk.GroupVersion()] = append(resources[gvk.GroupVersion()],
+			metav1.APIResource{Kind: gvk.Kind, Namespaced: true})
+	}
+
+	r.mu.RLock()
+	for gvk, namespaced := range r.namespaced {
+		if namespaced {
+			resources[gvk.GroupVersion()] = append(resources[gvk.GroupVersion()],
+				metav1.APIResource{Kind: gvk.Kind, Namespaced: true})
+		}
+	}
+	r.mu.RUnlock()
+
+	lists := make([]*metav1.APIResourceList, 0, len(resources))
+	for gv, apiResources := range resources {
+		lists = append(lists, &metav1.APIResourceList{GroupVersion: gv.String(), APIResources: apiResources})
+	}
+	sort.Slice(lists, func(i, j int) bool { return lists[i].GroupVersion < lists[j].GroupVersion })
+	return lists, nil
+}
@@ -0,0 +1,169 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package offline
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/cel/openapi/resolver"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+const widgetCRD = `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.example.com
+spec:
+  group: example.com
+  names:
+    kind: Widget
+    plural: widgets
+  scope: Namespaced
+  versions:
+  - name: v1
+    served: true
+    storage: true
+    schema:
+      openAPIV3Schema:
+        type: object
+        properties:
+          spec:
+            type: object
+            properties:
+              size:
+                type: integer
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: not-a-crd
+`
+
+const gadgetCRD = `{
+  "apiVersion": "apiextensions.k8s.io/v1",
+  "kind": "CustomResourceDefinition",
+  "metadata": {"name": "gadgets.example.com"},
+  "spec": {
+    "group": "example.com",
+    "names": {"kind": "Gadget", "plural": "gadgets"},
+    "scope": "Cluster",
+    "versions": [{"name": "v1", "served": true, "storage": true,
+      "schema": {"openAPIV3Schema": {"type": "object"}}}]
+  }
+}`
+
+// propertyAt returns the schema of the field at the given path in s.
+func propertyAt(t *testing.T, s *spec.Schema, path ...string) spec.Schema {
+	t.Helper()
+	current := *s
+	for _, segment := range path {
+		if segment == "[]" {
+			require.NotNil(t, current.Items, "no items at %s", segment)
+			current = *current.Items.Schema
+			continue
+		}
+		property, ok := current.Properties[segment]
+		require.True(t, ok, "no property %s", segment)
+		current = property
+	}
+	return current
+}
+
+func TestBuiltinResolver(t *testing.T) {
+	r := BuiltinResolver()
+
+	deployment, err := r.ResolveSchema(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"})
+	require.NoError(t, err)
+	assert.Equal(t, spec.StringOrArray{"string"}, propertyAt(t, deployment, "apiVersion").Type)
+	assert.Equal(t, spec.StringOrArray{"string"}, propertyAt(t, deployment, "metadata", "name").Type)
+	assert.Equal(t, spec.StringOrArray{"string"},
+		propertyAt(t, deployment, "spec", "template", "spec", "containers", "[]", "image").Type)
+	assert.Equal(t, spec.StringOrArray{"integer"}, propertyAt(t, deployment, "status", "availableReplicas").Type)
+
+	// The schemas keep the descriptions, formats, enums and validations of the
+	// definitions.
+	replicas := propertyAt(t, deployment, "spec", "replicas")
+	assert.Equal(t, spec.StringOrArray{"integer"}, replicas.Type)
+	assert.Equal(t, "int32", replicas.Format)
+	assert.Contains(t, replicas.Description, "Number of desired pods.")
+	assert.Equal(t, "date-time", propertyAt(t, deployment, "metadata", "creationTimestamp").Format)
+	assert.Equal(t, []string{"selector", "template"}, propertyAt(t, deployment, "spec").Required)
+	assert.ElementsMatch(t, []interface{}{"Recreate", "RollingUpdate"},
+		propertyAt(t, deployment, "spec", "strategy", "type").Enum)
+	containers := propertyAt(t, deployment, "spec", "template", "spec", "containers")
+	listType, _ := containers.Extensions.GetString("x-kubernetes-list-type")
+	assert.Equal(t, "map", listType)
+	assert.Equal(t, "int-or-string", propertyAt(t, deployment, "spec", "strategy", "rollingUpdate", "maxSurge").Format)
+
+	configMap, err := r.ResolveSchema(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"})
+	require.NoError(t, err)
+	data := propertyAt(t, configMap, "data")
+	require.NotNil(t, data.AdditionalProperties)
+	assert.Equal(t, spec.StringOrArray{"string"}, data.AdditionalProperties.Schema.Type)
+
+	_, err = r.ResolveSchema(schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"})
+	assert.ErrorIs(t, err, resolver.ErrSchemaNotFound)
+}
+
+func TestLocalResolver_LoadCRDs(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "nested"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "widget.yaml"), []byte(widgetCRD), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "nested", "gadget.json"), []byte(gadgetCRD), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("{{ not yaml"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "chart.yaml"), []byte("a: {{ .Values }}"), 0o600))
+
+	r := NewLocalResolver()
+	err := r.LoadCRDs(dir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "chart.yaml")
+	assert.NotContains(t, err.Error(), "notes.txt")
+
+	widget, err := r.ResolveSchema(schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"})
+	require.NoError(t, err)
+	assert.Equal(t, spec.StringOrArray{"integer"}, propertyAt(t, widget, "spec", "size").Type)
+	assert.Equal(t, spec.StringOrArray{"string"}, propertyAt(t, widget, "metadata", "name").Type)
+
+	_, err = r.ResolveSchema(schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Gadget"})
+	require.NoError(t, err)
+
+	_, err = r.ResolveSchema(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"})
+	require.NoError(t, err)
+
+	_, err = r.ResolveSchema(schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Gizmo"})
+	assert.ErrorIs(t, err, resolver.ErrSchemaNotFound)
+
+	lists, err := r.ServerPreferredNamespacedResources()
+	require.NoError(t, err)
+	namespaced := map[schema.GroupKind]bool{}
+	for _, list := range lists {
+		gv, err := schema.ParseGroupVersion(list.GroupVersion)
+		require.NoError(t, err)
+		for _, resource := range list.APIResources {
+			namespaced[gv.WithKind(resource.Kind).GroupKind()] = resource.Namespaced
+		}
+	}
+	assert.True(t, namespaced[schema.GroupKind{Group: "example.com", Kind: "Widget"}])
+	assert.True(t, namespaced[schema.GroupKind{Group: "apps", Kind: "Deployment"}])
+	assert.True(t, namespaced[schema.GroupKind{Kind: "ConfigMap"}])
+	assert.NotContains(t, namespaced, schema.GroupKind{Group: "example.com", Kind: "Gadget"})
+	assert.NotContains(t, namespaced, schema.GroupKind{Kind: "Namespace"})
+	assert.NotContains(t, namespaced, schema.GroupKind{Kind: "ConfigMapList"})
+}
@@ -0,0 +1,75 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolver
+
+import (
+	"sync"
+
+	"k8s.io/apiextensions-apiserver/pkg/generated/openapi"
+	"k8s.io/kube-openapi/pkg/common"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+const objectMetaDefinition = "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"
+
+// ObjectMetaSchema returns the OpenAPI schema of metav1.ObjectMeta, with all
+// the references resolved from the built-in OpenAPI definitions.
+//
+// The schemas served by the API server for CRDs only declare metadata as an
+// object, it is useful to complete schemas that are not obtained through
+// discovery, e.g. the schemas of kro instances.
+var ObjectMetaSchema = sync.OnceValue(func() *spec.Schema {
+	definitions := openapi.GetOpenAPIDefinitions(func(path string) spec.Ref {
+		return spec.MustCreateRef(path)
+	})
+	return resolveDefinitionRefs(definitions, definitions[objectMetaDefinition].Schema)
+})
+
+// resolveDefinitionRefs returns a copy of the given schema where every
+// reference is replaced by the definition it points to.
+func resolveDefinitionRefs(definitions map[string]common.OpenAPIDefinition, s spec.Schema) *spec.Schema {
+	if ref := s.Ref.String(); ref != "" {
+		definition, ok := definitions[ref]
+		if !ok {
+			return &spec.Schema{}
+		}
+		return resolveDefinitionRefs(definitions, definition.Schema)
+	}
+	if len(s.AllOf) == 1 {
+		// Fields with defaults are wrapped in a single allOf.
+		resolved := resolveDefinitionRefs(definitions, s.AllOf[0])
+		resolved.Description = s.Description
+		resolved.Default = s.Default
+		return resolved
+	}
+
+	resolved := s
+	if s.Properties != nil {
+		resolved.Properties = make(map[string]spec.Schema, len(s.Properties))
+		for name, property := range s.Properties {
+			resolved.Properties[name] = *resolveDefinitionRefs(definitions, property)
+		}
+	}
+	if s.Items != nil && s.Items.Schema != nil {
+		resolved.Items = &spec.SchemaOrArray{Schema: resolveDefinitionRefs(definitions, *s.Items.Schema)}
+	}
+	if s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil {
+		resolved.AdditionalProperties = &spec.SchemaOrBool{
+			Allows: true,
+			Schema: resolveDefinitionRefs(definitions, *s.AdditionalProperties.Schema),
+		}
+	}
+	return &resolved
+}
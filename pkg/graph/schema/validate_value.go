@@ -0,0 +1,137 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"fmt"
+	"sort"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+const (
+	xKubernetesPreserveUnknownFields = "x-kubernetes-preserve-unknown-fields"
+	xKubernetesIntOrString           = "x-kubernetes-int-or-string"
+)
+
+// ValidateValueType returns an error if the type of the value, or of the
+// values nested in it, doesn't match the schema. The value is a Go native
+// value, as decoded from JSON. Null values and schemas without a type match
+// anything, and so do the fields of objects preserving unknown fields.
+//
+// Only the types are validated, not the other constraints of the schema, like
+// formats or enums: the values checked at build time are emulated.
+func ValidateValueType(value interface{}, schema *spec.Schema, path string) error {
+	if value == nil || schema == nil {
+		return nil
+	}
+	if hasExtension(schema, xKubernetesIntOrString) {
+		switch value.(type) {
+		case string, int64, uint64, int:
+			return nil
+		}
+		return fmt.Errorf("expected integer or string at path %s, got %s", path, valueType(value))
+	}
+	if len(schema.Type) == 0 {
+		return nil
+	}
+
+	expected := schema.Type[0]
+	actual := valueType(value)
+	switch {
+	case expected == actual:
+	case expected == "number" && actual == "integer":
+	default:
+		return fmt.Errorf("expected %s at path %s, got %s", expected, path, actual)
+	}
+
+	switch value := value.(type) {
+	case []interface{}:
+		if schema.Items == nil {
+			return nil
+		}
+		for i, item := range value {
+			if err := ValidateValueType(item, schema.Items.Schema, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case map[string]interface{}:
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if err := validateFieldType(value[key], schema, key, joinPath(path, key)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateFieldType validates the type of the value of a field of an object.
+// Fields unknown to a schema listing its properties are an error, unless the
+// schema preserves unknown fields.
+func validateFieldType(value interface{}, schema *spec.Schema, field, path string) error {
+	if property, ok := schema.Properties[field]; ok {
+		return ValidateValueType(value, &property, path)
+	}
+	if schema.AdditionalProperties != nil {
+		if schema.AdditionalProperties.Schema != nil {
+			return ValidateValueType(value, schema.AdditionalProperties.Schema, path)
+		}
+		if schema.AdditionalProperties.Allows {
+			return nil
+		}
+	}
+	if len(schema.Properties) > 0 && !hasExtension(schema, xKubernetesPreserveUnknownFields) {
+		return fmt.Errorf("unknown field at path %s", path)
+	}
+	return nil
+}
+
+// hasExtension returns true if the boolean extension is enabled on the schema.
+func hasExtension(schema *spec.Schema, extension string) bool {
+	enabled, _ := schema.Extensions.GetBool(extension)
+	return enabled
+}
+
+// valueType returns the JSON schema type of a Go native value.
+func valueType(value interface{}) string {
+	switch value.(type) {
+	case bool:
+		return "boolean"
+	case int, int64, uint64:
+		return "integer"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// joinPath returns the path of a field of the object at the given path.
+func joinPath(path, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
@@ -0,0 +1,103 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sschema "k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/kubernetes-sigs/kro/api/v1alpha1"
+)
+
+// TypeLibraryResolver resolves the TypeLibraries imported by a
+// ResourceGraphDefinition.
+type TypeLibraryResolver interface {
+	ResolveTypeLibrary(ctx context.Context, name string) (*v1alpha1.TypeLibrary, error)
+}
+
+// typeLibraryGVR is the GroupVersionResource of the TypeLibrary API.
+var typeLibraryGVR = k8sschema.GroupVersionResource{
+	Group:    v1alpha1.GroupVersion.Group,
+	Version:  v1alpha1.GroupVersion.Version,
+	Resource: "typelibraries",
+}
+
+// dynamicTypeLibraryResolver resolves TypeLibraries from the API server using
+// a dynamic client.
+type dynamicTypeLibraryResolver struct {
+	client dynamic.Interface
+}
+
+// NewTypeLibraryResolver returns a TypeLibraryResolver reading TypeLibraries
+// from the API server.
+func NewTypeLibraryResolver(client dynamic.Interface) TypeLibraryResolver {
+	return &dynamicTypeLibraryResolver{client: client}
+}
+
+// ResolveTypeLibrary implements TypeLibraryResolver.
+func (r *dynamicTypeLibraryResolver) ResolveTypeLibrary(
+	ctx context.Context,
+	name string,
+) (*v1alpha1.TypeLibrary, error) {
+	obj, err := r.client.Resource(typeLibraryGVR).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	library := &v1alpha1.TypeLibrary{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, library); err != nil {
+		return nil, fmt.Errorf("failed to convert type library %s: %w", name, err)
+	}
+	return library, nil
+}
+
+// resolveImportedTypes resolves the types of the TypeLibraries imported by the
+// given schema. The types of each library are returned in import order.
+func resolveImportedTypes(
+	ctx context.Context,
+	typeLibraryResolver TypeLibraryResolver,
+	imports []v1alpha1.TypeImport,
+) ([]map[string]interface{}, error) {
+	if len(imports) == 0 {
+		return nil, nil
+	}
+	if typeLibraryResolver == nil {
+		return nil, fmt.Errorf("type library imports are not supported: no type library resolver configured")
+	}
+
+	importedTypes := make([]map[string]interface{}, 0, len(imports))
+	for _, typeImport := range imports {
+		library, err := typeLibraryResolver.ResolveTypeLibrary(ctx, typeImport.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve type library %s: %w", typeImport.Name, err)
+		}
+		if typeImport.Version != "" && typeImport.Version != library.Spec.Version {
+			return nil, fmt.Errorf("type library %s is at version %q, but version %q is imported",
+				typeImport.Name, library.Spec.Version, typeImport.Version)
+		}
+
+		types := map[string]interface{}{}
+		if err := yaml.UnmarshalStrict(library.Spec.Types.Raw, &types); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal types of type library %s: %w", typeImport.Name, err)
+		}
+		importedTypes = append(importedTypes, types)
+	}
+	return importedTypes, nil
+}
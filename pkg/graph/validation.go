@@ -69,8 +69,8 @@ var (
 	}
 )
 
-// isValidResourceID checks if the given id is a valid KRO resource id (loawercase)
-func isValidResourceID(id string) bool {
+// IsValidResourceID checks if the given id is a valid KRO resource id (lower camelCase).
+func IsValidResourceID(id string) bool {
 	return lowerCamelCaseRegex.MatchString(id)
 }
 
@@ -79,8 +79,8 @@ func isValidKindName(name string) bool {
 	return upperCamelCaseRegex.MatchString(name)
 }
 
-// isKROReservedWord checks if the given word is a reserved word in KRO.
-func isKROReservedWord(word string) bool {
+// IsKROReservedWord checks if the given word is a reserved word in KRO.
+func IsKROReservedWord(word string) bool {
 	for _, w := range reservedKeyWords {
 		if w == word {
 			return true
@@ -92,14 +92,17 @@ func isKROReservedWord(word string) bool {
 // validateResourceGraphDefinitionNamingConventions validates the naming conventions of
 // the given resource graph definition.
 func validateResourceGraphDefinitionNamingConventions(rgd *v1alpha1.ResourceGraphDefinition) error {
+	var errs BuildErrors
 	if !isValidKindName(rgd.Spec.Schema.Kind) {
-		return fmt.Errorf("%s: kind '%s' is not a valid KRO kind name: must be UpperCamelCase", ErrNamingConvention, rgd.Spec.Schema.Kind)
+		errs = append(errs, &BuildError{
+			Code: ErrorCodeInvalidName,
+			Path: "spec.schema.kind",
+			Err: fmt.Errorf("%s: kind '%s' is not a valid KRO kind name: must be UpperCamelCase",
+				ErrNamingConvention, rgd.Spec.Schema.Kind),
+		})
 	}
-	err := validateResourceIDs(rgd)
-	if err != nil {
-		return fmt.Errorf("%s: %w", ErrNamingConvention, err)
-	}
-	return nil
+	errs = append(errs, validateResourceIDs(rgd)...)
+	return errs.errorOrNil()
 }
 
 // validateResource performs basic validation on a given resourcegraphdefinition.
@@ -110,23 +113,31 @@ func validateResourceGraphDefinitionNamingConventions(rgd *v1alpha1.ResourceGrap
 // - The id should start with a lowercase letter.
 // - The id should only contain alphanumeric characters.
 // - Does not contain any special characters, underscores, or hyphens.
-func validateResourceIDs(rgd *v1alpha1.ResourceGraphDefinition) error {
+func validateResourceIDs(rgd *v1alpha1.ResourceGraphDefinition) BuildErrors {
+	var errs BuildErrors
 	seen := make(map[string]struct{})
-	for _, res := range rgd.Spec.Resources {
-		if isKROReservedWord(res.ID) {
-			return fmt.Errorf("id %s is a reserved keyword in KRO", res.ID)
-		}
-
-		if !isValidResourceID(res.ID) {
-			return fmt.Errorf("id %s is not a valid KRO resource id: must be lower camelCase", res.ID)
+	for i, res := range rgd.Spec.Resources {
+		buildErr := &BuildError{
+			Code:       ErrorCodeInvalidName,
+			ResourceID: res.ID,
+			Path:       resourcePath(i, "id"),
 		}
-
-		if _, ok := seen[res.ID]; ok {
-			return fmt.Errorf("found duplicate resource IDs %s", res.ID)
+		switch _, duplicate := seen[res.ID]; {
+		case IsKROReservedWord(res.ID):
+			buildErr.Err = fmt.Errorf("%s: id %s is a reserved keyword in KRO", ErrNamingConvention, res.ID)
+		case !IsValidResourceID(res.ID):
+			buildErr.Err = fmt.Errorf("%s: id %s is not a valid KRO resource id: must be lower camelCase",
+				ErrNamingConvention, res.ID)
+		case duplicate:
+			buildErr.Code = ErrorCodeDuplicateID
+			buildErr.Err = fmt.Errorf("%s: found duplicate resource IDs %s", ErrNamingConvention, res.ID)
+		default:
+			seen[res.ID] = struct{}{}
+			continue
 		}
-		seen[res.ID] = struct{}{}
+		errs = append(errs, buildErr)
 	}
-	return nil
+	return errs
 }
 
 // validateKubernetesObjectStructure checks if the given object is a Kubernetes object.
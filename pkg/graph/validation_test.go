@@ -97,8 +97,8 @@ func TestIsKROReservedWord(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.word, func(t *testing.T) {
-			if got := isKROReservedWord(tt.word); got != tt.expected {
-				t.Errorf("isKROReservedWord(%q) = %v, want %v", tt.word, got, tt.expected)
+			if got := IsKROReservedWord(tt.word); got != tt.expected {
+				t.Errorf("IsKROReservedWord(%q) = %v, want %v", tt.word, got, tt.expected)
 			}
 		})
 	}
@@ -119,7 +119,7 @@ func TestIsValidResourceName(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := isValidResourceID(tt.name); got != tt.expected {
+			if got := IsValidResourceID(tt.name); got != tt.expected {
 				t.Errorf("isValidResourceName(%q) = %v, want %v", tt.name, got, tt.expected)
 			}
 		})
@@ -42,8 +42,45 @@ type FieldDescriptor struct {
 	// that is not part of a larger string. example: "${foo}" is a standalone expression
 	// but not "hello-${foo}" or "${foo}${bar}"
 	StandaloneExpression bool
+	// Operation is how the value of the expressions is written into the
+	// resource. By default, it is the value of the field.
+	Operation FieldOperation
 }
 
+// FieldOperation describes how the value of the expressions of a field is
+// written into the resource.
+type FieldOperation string
+
+const (
+	// FieldOperationSet sets the field to the value of its expressions. This
+	// is the default operation.
+	FieldOperationSet FieldOperation = ""
+	// FieldOperationKey renames a map entry. The expressions are in the key of
+	// the entry, and the path is the path of the entry.
+	//
+	// For example:
+	//   labels:
+	//     ${schema.spec.labelKey}: value
+	FieldOperationKey FieldOperation = "key"
+	// FieldOperationMerge merges a map into the map holding the field. The
+	// path is the path of the $merge entry, which is removed. The entries of
+	// the template take precedence over the merged ones.
+	//
+	// For example:
+	//   labels:
+	//     app: my-app
+	//     $merge: ${schema.spec.extraLabels}
+	FieldOperationMerge FieldOperation = "merge"
+	// FieldOperationSplice replaces a list item with the items of a list. The
+	// path is the path of the item, a map with a single $splice entry.
+	//
+	// For example:
+	//   containers:
+	//     - name: app
+	//     - $splice: ${schema.spec.sidecars}
+	FieldOperationSplice FieldOperation = "splice"
+)
+
 // ResourceField ResourceVariable represents a variable in a resource. Variables are any
 // field in a resource (under resources[*].definition) that is not a constant
 // value a.k.a contains one or multiple expressions. For example
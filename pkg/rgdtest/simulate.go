@@ -0,0 +1,192 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rgdtest
+
+import (
+	"errors"
+	"fmt"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	structuraldefaulting "k8s.io/apiextensions-apiserver/pkg/apiserver/schema/defaulting"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/kubernetes-sigs/kro/api/v1alpha1"
+	"github.com/kubernetes-sigs/kro/pkg/graph"
+	"github.com/kubernetes-sigs/kro/pkg/runtime"
+)
+
+// Simulation is the outcome of the reconciliation of an instance simulated
+// by Simulate.
+type Simulation struct {
+	// Resources are the manifests of the resources, as they would be applied,
+	// by resource id. The skipped and pending resources have none.
+	Resources map[string]*unstructured.Unstructured
+	// Skipped are the ids of the resources excluded by their includeWhen
+	// expressions, or by those of their dependencies.
+	Skipped []string
+	// Pending are the ids of the resources whose expressions couldn't be
+	// resolved yet.
+	Pending []string
+	// NotReady are the reasons why the rendered resources that aren't ready
+	// aren't, by resource id.
+	NotReady map[string]string
+	// Instance is the instance, with the status resolved from the resources.
+	Instance *unstructured.Unstructured
+	// State is the state the controller would give the instance.
+	State string
+}
+
+// IsReady tells whether the resource with the given id is rendered and ready.
+func (s *Simulation) IsReady(id string) bool {
+	_, ok := s.Resources[id]
+	_, notReady := s.NotReady[id]
+	return ok && !notReady
+}
+
+// Simulate simulates a reconciliation of an instance of the graph, without a
+// cluster. The resources are processed in topological order, like the
+// controller does: the excluded resources are skipped, and the others are
+// rendered and "applied". The state of each applied resource observed in the
+// cluster is its manifest, merged with the given observed state for its id,
+// e.g. the status a Deployment would have. The observed states are then used
+// to resolve the dependents of the resources, their readiness and the status
+// of the instance.
+//
+// The instance is defaulted with the defaults of its schema, like the API
+// server would.
+func Simulate(
+	g *graph.Graph,
+	instance *unstructured.Unstructured,
+	observed map[string]map[string]interface{},
+) (*Simulation, error) {
+	instance = instance.DeepCopy()
+	if err := defaultInstance(g.Instance.GetCRD(), instance); err != nil {
+		return nil, err
+	}
+
+	rt, err := g.NewGraphRuntime(instance)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create runtime: %w", err)
+	}
+	if err := synchronize(rt); err != nil {
+		return nil, fmt.Errorf("failed to synchronize: %w", err)
+	}
+
+	simulation := &Simulation{
+		Resources: map[string]*unstructured.Unstructured{},
+		NotReady:  map[string]string{},
+		State:     v1alpha1.InstanceStateActive,
+	}
+	for _, id := range rt.TopologicalOrder() {
+		if include, err := rt.ReadyToProcessResource(id); err != nil || !include {
+			// The controller skips the resources whose includeWhen
+			// expressions fail to evaluate as well.
+			rt.IgnoreResource(id)
+			simulation.Skipped = append(simulation.Skipped, id)
+			continue
+		}
+		resource, state := rt.GetResource(id)
+		if state != runtime.ResourceStateResolved {
+			simulation.Pending = append(simulation.Pending, id)
+			simulation.State = v1alpha1.InstanceStateInProgress
+			continue
+		}
+
+		manifest := &unstructured.Unstructured{Object: runtime.DeepCopyObject(resource.Object)}
+		simulation.Resources[id] = manifest
+		rt.SetResource(id, observedObject(manifest, observed[id], instance.GetNamespace(),
+			rt.ResourceDescriptor(id).IsNamespaced()))
+		if err := synchronize(rt); err != nil {
+			return nil, fmt.Errorf("failed to synchronize after resource %s: %w", id, err)
+		}
+
+		if ready, reason, err := rt.IsResourceReady(id); err != nil {
+			simulation.NotReady[id] = err.Error()
+		} else if !ready {
+			simulation.NotReady[id] = reason
+		}
+	}
+	simulation.Instance = rt.GetInstance()
+	return simulation, nil
+}
+
+// synchronize synchronizes the runtime. The data missing from the observed
+// states, like the status of a resource that isn't set, only leaves the
+// expressions reading it unresolved, as if the resource wasn't ready yet.
+func synchronize(rt *runtime.ResourceGraphDefinitionRuntime) error {
+	_, err := rt.Synchronize()
+	var evalErr *runtime.EvalError
+	if errors.As(err, &evalErr) && evalErr.IsIncompleteData {
+		return nil
+	}
+	return err
+}
+
+// observedObject returns the object observed in the cluster once the manifest
+// is applied: the manifest, in the namespace of the instance if it has none
+// and is namespaced, merged with the given observed state.
+func observedObject(
+	manifest *unstructured.Unstructured,
+	observed map[string]interface{},
+	namespace string,
+	namespaced bool,
+) *unstructured.Unstructured {
+	object := &unstructured.Unstructured{Object: runtime.DeepCopyObject(manifest.Object)}
+	if namespaced && object.GetNamespace() == "" {
+		if namespace == "" {
+			namespace = metav1.NamespaceDefault
+		}
+		object.SetNamespace(namespace)
+	}
+	mergeObject(object.Object, runtime.DeepCopyObject(observed))
+	return object
+}
+
+// mergeObject merges the fields of src into dst. Nested objects are merged,
+// and the other values of src replace those of dst.
+func mergeObject(dst, src map[string]interface{}) {
+	for key, value := range src {
+		srcObject, srcIsObject := value.(map[string]interface{})
+		dstObject, dstIsObject := dst[key].(map[string]interface{})
+		if srcIsObject && dstIsObject {
+			mergeObject(dstObject, srcObject)
+			continue
+		}
+		dst[key] = value
+	}
+}
+
+// defaultInstance applies the defaults of the schema of the CRD of the
+// instances to the instance.
+func defaultInstance(crd *extv1.CustomResourceDefinition, instance *unstructured.Unstructured) error {
+	if len(crd.Spec.Versions) == 0 || crd.Spec.Versions[0].Schema == nil {
+		return nil
+	}
+	internal := &apiextensions.JSONSchemaProps{}
+	if err := extv1.Convert_v1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(
+		crd.Spec.Versions[0].Schema.OpenAPIV3Schema, internal, nil,
+	); err != nil {
+		return fmt.Errorf("failed to convert instance schema: %w", err)
+	}
+	structural, err := structuralschema.NewStructural(internal)
+	if err != nil {
+		return fmt.Errorf("failed to convert instance schema: %w", err)
+	}
+	structuraldefaulting.Default(instance.Object, structural)
+	return nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rgdtest
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kubernetes-sigs/kro/api/v1alpha1"
+	"github.com/kubernetes-sigs/kro/pkg/graph"
+	"github.com/kubernetes-sigs/kro/pkg/graph/schema/offline"
+)
+
+func TestSimulate(t *testing.T) {
+	rgd := &v1alpha1.ResourceGraphDefinition{}
+	require.NoError(t, yaml.Unmarshal([]byte(webappRGD), rgd))
+	local := offline.NewLocalResolver()
+	g, err := graph.NewOfflineBuilder(local, local).NewResourceGraphDefinition(context.Background(), rgd)
+	require.NoError(t, err)
+
+	instance := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "kro.run/v1alpha1",
+		"kind":       "WebApp",
+		"metadata":   map[string]interface{}{"name": "shop", "namespace": "default"},
+		"spec":       map[string]interface{}{"image": "nginx"},
+	}}
+
+	t.Run("observed status", func(t *testing.T) {
+		simulation, err := Simulate(g, instance, map[string]map[string]interface{}{
+			"deployment": {"status": map[string]interface{}{"availableReplicas": int64(2)}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.InstanceStateActive, simulation.State)
+		assert.Equal(t, []string{"ingress"}, simulation.Skipped)
+		assert.Empty(t, simulation.Pending)
+		assert.True(t, simulation.IsReady("deployment"))
+		assert.True(t, simulation.IsReady("service"))
+		assert.Equal(t, int64(2), simulation.Resources["deployment"].Object["spec"].(map[string]interface{})["replicas"])
+		status, _ := simulation.Instance.Object["status"].(map[string]interface{})
+		assert.Equal(t, int64(2), status["availableReplicas"])
+	})
+
+	t.Run("missing status", func(t *testing.T) {
+		// The status missing from the deployment only leaves the expressions
+		// reading it unresolved.
+		simulation, err := Simulate(g, instance, nil)
+		require.NoError(t, err)
+		assert.Empty(t, simulation.Pending)
+		assert.Contains(t, simulation.Resources, "service")
+		assert.False(t, simulation.IsReady("deployment"))
+		assert.Contains(t, simulation.NotReady["deployment"], "no such key: status")
+		status, _ := simulation.Instance.Object["status"].(map[string]interface{})
+		assert.NotContains(t, status, "availableReplicas")
+	})
+
+	t.Run("pending resources", func(t *testing.T) {
+		// The resources reading data missing from the observed states wait
+		// for it, like the controller would.
+		rgd := &v1alpha1.ResourceGraphDefinition{}
+		require.NoError(t, yaml.Unmarshal([]byte(strings.Replace(webappRGD,
+			"            name: ${service.metadata.name}\n",
+			"            name: ${service.spec.clusterIP}\n", 1)), rgd))
+		g, err := graph.NewOfflineBuilder(local, local).NewResourceGraphDefinition(context.Background(), rgd)
+		require.NoError(t, err)
+
+		withIngress := instance.DeepCopy()
+		withIngress.Object["spec"].(map[string]interface{})["ingress"] = true
+		simulation, err := Simulate(g, withIngress, nil)
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.InstanceStateInProgress, simulation.State)
+		assert.Equal(t, []string{"ingress"}, simulation.Pending)
+		assert.Contains(t, simulation.Resources, "service")
+
+		simulation, err = Simulate(g, withIngress, map[string]map[string]interface{}{
+			"service": {"spec": map[string]interface{}{"clusterIP": "10.0.0.1"}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.InstanceStateActive, simulation.State)
+		assert.Empty(t, simulation.Pending)
+		assert.Contains(t, simulation.Resources, "ingress")
+	})
+}
@@ -0,0 +1,353 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rgdtest runs declarative unit tests of ResourceGraphDefinitions,
+// without a cluster. A test suite lists instances of a ResourceGraphDefinition,
+// the states observed for their resources, and the expected manifests,
+// readiness and status.
+package rgdtest
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kubernetes-sigs/kro/api/v1alpha1"
+	"github.com/kubernetes-sigs/kro/pkg/graph"
+)
+
+// Suite is a test suite of a ResourceGraphDefinition, read from a YAML file
+// like:
+//
+//	resourceGraphDefinition: webapp.yaml
+//	tests:
+//	- name: creates the deployment
+//	  instance:
+//	    spec:
+//	      replicas: 3
+//	  observed:
+//	    deployment:
+//	      status:
+//	        availableReplicas: 3
+//	  expect:
+//	    resources:
+//	      deployment:
+//	        spec:
+//	          replicas: 3
+//	    skipped: [ingress]
+//	    ready: [deployment]
+//	    status:
+//	      availableReplicas: 3
+type Suite struct {
+	// ResourceGraphDefinition is the path of the ResourceGraphDefinition file,
+	// relative to the suite file.
+	ResourceGraphDefinition string `json:"resourceGraphDefinition"`
+	// Tests are the test cases of the suite.
+	Tests []TestCase `json:"tests"`
+
+	// path is the path of the suite file.
+	path string
+}
+
+// TestCase is a test of an instance of a ResourceGraphDefinition.
+type TestCase struct {
+	// Name describes the test.
+	Name string `json:"name"`
+	// Instance is the instance, whose apiVersion and kind default to those of
+	// the instances of the ResourceGraphDefinition, and whose name and
+	// namespace default to test and default.
+	Instance map[string]interface{} `json:"instance"`
+	// Observed are the states observed in the cluster for the resources, by
+	// resource id, merged over their manifests.
+	Observed map[string]map[string]interface{} `json:"observed,omitempty"`
+	// Expect are the expectations on the outcome of the reconciliation.
+	Expect Expectations `json:"expect"`
+}
+
+// Expectations are the expectations of a test case. Only the set fields are
+// checked.
+type Expectations struct {
+	// Resources are subsets of the expected manifests, by resource id. The
+	// expected fields must be in the manifests, with the same values. The
+	// lists must have the same length, and their items match.
+	Resources map[string]map[string]interface{} `json:"resources,omitempty"`
+	// Skipped are the ids of the resources expected to be skipped.
+	Skipped []string `json:"skipped,omitempty"`
+	// NotSkipped are the ids of the resources expected to be included.
+	NotSkipped []string `json:"notSkipped,omitempty"`
+	// Ready are the ids of the resources expected to be ready.
+	Ready []string `json:"ready,omitempty"`
+	// NotReady are the ids of the resources expected not to be ready.
+	NotReady []string `json:"notReady,omitempty"`
+	// Status is a subset of the expected status of the instance.
+	Status map[string]interface{} `json:"status,omitempty"`
+	// State is the expected state of the instance, ACTIVE or IN_PROGRESS.
+	State string `json:"state,omitempty"`
+}
+
+// TestResult is the result of a test case.
+type TestResult struct {
+	// Name is the name of the test case.
+	Name string
+	// Failures describe the unmet expectations, or why the test couldn't run.
+	Failures []string
+}
+
+// Passed tells whether the test case passed.
+func (r *TestResult) Passed() bool {
+	return len(r.Failures) == 0
+}
+
+// LoadSuite reads the test suite in the given file.
+func LoadSuite(path string) (*Suite, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read test suite: %w", err)
+	}
+	suite := &Suite{path: path}
+	if err := yaml.UnmarshalStrict(data, suite); err != nil {
+		return nil, fmt.Errorf("failed to decode test suite %s: %w", path, err)
+	}
+	if suite.ResourceGraphDefinition == "" {
+		return nil, fmt.Errorf("test suite %s has no resourceGraphDefinition", path)
+	}
+	return suite, nil
+}
+
+// LoadResourceGraphDefinition reads the ResourceGraphDefinition of the suite.
+func (s *Suite) LoadResourceGraphDefinition() (*v1alpha1.ResourceGraphDefinition, error) {
+	path := s.ResourceGraphDefinition
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(filepath.Dir(s.path), path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ResourceGraphDefinition: %w", err)
+	}
+	rgd := &v1alpha1.ResourceGraphDefinition{}
+	if err := yaml.Unmarshal(data, rgd); err != nil {
+		return nil, fmt.Errorf("failed to decode ResourceGraphDefinition %s: %w", path, err)
+	}
+	return rgd, nil
+}
+
+// Run builds the ResourceGraphDefinition of the suite with the builder, and
+// runs its test cases. It only fails if the ResourceGraphDefinition can't be
+// read or built.
+func (s *Suite) Run(ctx context.Context, builder *graph.Builder) ([]TestResult, error) {
+	rgd, err := s.LoadResourceGraphDefinition()
+	if err != nil {
+		return nil, err
+	}
+	g, err := builder.NewResourceGraphDefinition(ctx, rgd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build ResourceGraphDefinition %s: %w", rgd.Name, err)
+	}
+
+	results := make([]TestResult, 0, len(s.Tests))
+	for _, test := range s.Tests {
+		results = append(results, TestResult{Name: test.Name, Failures: test.Run(g)})
+	}
+	return results, nil
+}
+
+// Run runs the test case against the graph, and returns its failures.
+func (t *TestCase) Run(g *graph.Graph) []string {
+	crd := g.Instance.GetCRD()
+	instance := &unstructured.Unstructured{Object: t.Instance}
+	if instance.Object == nil {
+		instance.Object = map[string]interface{}{}
+	}
+	instance = instance.DeepCopy()
+	if instance.GetAPIVersion() == "" {
+		instance.SetAPIVersion(crd.Spec.Group + "/" + crd.Spec.Versions[0].Name)
+	}
+	if instance.GetKind() == "" {
+		instance.SetKind(crd.Spec.Names.Kind)
+	}
+	if instance.GetName() == "" {
+		instance.SetName("test")
+	}
+	if instance.GetNamespace() == "" {
+		instance.SetNamespace("default")
+	}
+
+	simulation, err := Simulate(g, instance, t.Observed)
+	if err != nil {
+		return []string{err.Error()}
+	}
+	return t.Expect.check(simulation)
+}
+
+// check returns the expectations unmet by the simulation.
+func (e *Expectations) check(simulation *Simulation) []string {
+	var failures []string
+
+	ids := make([]string, 0, len(e.Resources))
+	for id := range e.Resources {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		manifest, ok := simulation.Resources[id]
+		if !ok {
+			failures = append(failures, fmt.Sprintf("resource %s: not rendered (%s)", id, resourceState(simulation, id)))
+			continue
+		}
+		for _, mismatch := range matchSubset(id, e.Resources[id], manifest.Object) {
+			failures = append(failures, "resource "+mismatch)
+		}
+	}
+
+	for _, id := range e.Skipped {
+		if !slices.Contains(simulation.Skipped, id) {
+			failures = append(failures, fmt.Sprintf("resource %s: expected to be skipped, but is %s",
+				id, resourceState(simulation, id)))
+		}
+	}
+	for _, id := range e.NotSkipped {
+		if slices.Contains(simulation.Skipped, id) {
+			failures = append(failures, fmt.Sprintf("resource %s: expected not to be skipped", id))
+		}
+	}
+	for _, id := range e.Ready {
+		if !simulation.IsReady(id) {
+			failures = append(failures, fmt.Sprintf("resource %s: expected to be ready, but is %s",
+				id, resourceState(simulation, id)))
+		}
+	}
+	for _, id := range e.NotReady {
+		if simulation.IsReady(id) {
+			failures = append(failures, fmt.Sprintf("resource %s: expected not to be ready", id))
+		}
+	}
+
+	if e.Status != nil {
+		status, _ := simulation.Instance.Object["status"].(map[string]interface{})
+		failures = append(failures, matchSubset("status", e.Status, status)...)
+	}
+	if e.State != "" && e.State != simulation.State {
+		failures = append(failures, fmt.Sprintf("state: expected %s, got %s", e.State, simulation.State))
+	}
+	return failures
+}
+
+// resourceState describes the state of a resource in the simulation.
+func resourceState(simulation *Simulation, id string) string {
+	switch {
+	case slices.Contains(simulation.Skipped, id):
+		return "skipped"
+	case slices.Contains(simulation.Pending, id):
+		return "pending"
+	case simulation.Resources[id] == nil:
+		return "unknown"
+	case simulation.IsReady(id):
+		return "ready"
+	default:
+		return "not ready: " + simulation.NotReady[id]
+	}
+}
+
+// matchSubset returns the mismatches between the expected values and the
+// actual ones, at the given path. Expected objects match the actual objects
+// holding at least their fields, with matching values. Expected lists match
+// actual lists of the same length with matching items. Numbers match
+// regardless of their types.
+func matchSubset(path string, expected, actual interface{}) []string {
+	switch expected := expected.(type) {
+	case map[string]interface{}:
+		actualObject, ok := actual.(map[string]interface{})
+		if !ok {
+			return []string{fmt.Sprintf("%s: expected an object, got %s", path, describe(actual))}
+		}
+		keys := make([]string, 0, len(expected))
+		for key := range expected {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		var mismatches []string
+		for _, key := range keys {
+			value, ok := actualObject[key]
+			if !ok {
+				mismatches = append(mismatches, fmt.Sprintf("%s.%s: missing", path, key))
+				continue
+			}
+			mismatches = append(mismatches, matchSubset(path+"."+key, expected[key], value)...)
+		}
+		return mismatches
+	case []interface{}:
+		actualList, ok := actual.([]interface{})
+		if !ok {
+			return []string{fmt.Sprintf("%s: expected a list, got %s", path, describe(actual))}
+		}
+		if len(actualList) != len(expected) {
+			return []string{fmt.Sprintf("%s: expected %d items, got %d", path, len(expected), len(actualList))}
+		}
+		var mismatches []string
+		for i := range expected {
+			mismatches = append(mismatches, matchSubset(fmt.Sprintf("%s[%d]", path, i), expected[i], actualList[i])...)
+		}
+		return mismatches
+	}
+
+	if expectedNumber, ok := toFloat(expected); ok {
+		if actualNumber, ok := toFloat(actual); ok && expectedNumber == actualNumber {
+			return nil
+		}
+	} else if expected == actual {
+		return nil
+	}
+	return []string{fmt.Sprintf("%s: expected %s, got %s", path, describe(expected), describe(actual))}
+}
+
+// toFloat returns the value of a number of any type.
+func toFloat(value interface{}) (float64, bool) {
+	switch value := value.(type) {
+	case int:
+		return float64(value), true
+	case int32:
+		return float64(value), true
+	case int64:
+		return float64(value), true
+	case uint64:
+		return float64(value), true
+	case float32:
+		return float64(value), true
+	case float64:
+		return value, true
+	}
+	return 0, false
+}
+
+// describe formats a value for the failure messages.
+func describe(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return fmt.Sprintf("%q", value)
+	case map[string]interface{}:
+		return "an object"
+	case []interface{}:
+		return "a list"
+	}
+	return strings.TrimSpace(fmt.Sprint(value))
+}
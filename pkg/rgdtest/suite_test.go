@@ -0,0 +1,218 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rgdtest
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubernetes-sigs/kro/pkg/graph"
+	"github.com/kubernetes-sigs/kro/pkg/graph/schema/offline"
+)
+
+const webappRGD = `apiVersion: kro.run/v1alpha1
+kind: ResourceGraphDefinition
+metadata:
+  name: webapp
+spec:
+  schema:
+    apiVersion: v1alpha1
+    kind: WebApp
+    spec:
+      image: string
+      replicas: integer | default=2
+      ingress: boolean | default=false
+    status:
+      availableReplicas: ${deployment.status.availableReplicas}
+  resources:
+  - id: deployment
+    readyWhen:
+    - ${deployment.status.availableReplicas == deployment.spec.replicas}
+    template:
+      apiVersion: apps/v1
+      kind: Deployment
+      metadata:
+        name: ${schema.metadata.name}
+      spec:
+        replicas: ${schema.spec.replicas}
+        selector:
+          matchLabels:
+            app: ${schema.metadata.name}
+        template:
+          metadata:
+            labels:
+              app: ${schema.metadata.name}
+          spec:
+            containers:
+            - name: app
+              image: ${schema.spec.image}
+  - id: service
+    template:
+      apiVersion: v1
+      kind: Service
+      metadata:
+        name: ${deployment.metadata.name}
+      spec:
+        selector: ${deployment.spec.selector.matchLabels}
+        ports:
+        - port: 80
+  - id: ingress
+    includeWhen:
+    - ${schema.spec.ingress}
+    template:
+      apiVersion: networking.k8s.io/v1
+      kind: Ingress
+      metadata:
+        name: ${service.metadata.name}
+      spec:
+        defaultBackend:
+          service:
+            name: ${service.metadata.name}
+            port:
+              number: 80
+`
+
+const webappSuite = `resourceGraphDefinition: webapp.yaml
+tests:
+- name: defaults
+  instance:
+    metadata:
+      name: shop
+    spec:
+      image: nginx
+  observed:
+    deployment:
+      status:
+        availableReplicas: 2
+  expect:
+    resources:
+      deployment:
+        metadata:
+          name: shop
+        spec:
+          replicas: 2
+          template:
+            spec:
+              containers:
+              - image: nginx
+      service:
+        spec:
+          selector:
+            app: shop
+    skipped: [ingress]
+    ready: [deployment, service]
+    status:
+      availableReplicas: 2
+    state: ACTIVE
+- name: not ready with ingress
+  instance:
+    spec:
+      image: nginx
+      replicas: 3
+      ingress: true
+  observed:
+    deployment:
+      status:
+        availableReplicas: 1
+  expect:
+    notSkipped: [ingress]
+    notReady: [deployment]
+    resources:
+      ingress:
+        metadata:
+          name: test
+- name: failing expectations
+  instance:
+    spec:
+      image: nginx
+  expect:
+    resources:
+      deployment:
+        spec:
+          replicas: 3
+          selector: {}
+      ingress: {}
+    ready: [deployment]
+    status:
+      availableReplicas: 2
+`
+
+func TestSuite_Run(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "webapp.yaml"), []byte(webappRGD), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "webapp_test.yaml"), []byte(webappSuite), 0o600))
+
+	suite, err := LoadSuite(filepath.Join(dir, "webapp_test.yaml"))
+	require.NoError(t, err)
+
+	local := offline.NewLocalResolver()
+	results, err := suite.Run(context.Background(), graph.NewOfflineBuilder(local, local))
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+
+	assert.Empty(t, results[0].Failures)
+	assert.True(t, results[0].Passed())
+	assert.Empty(t, results[1].Failures)
+
+	failures := results[2].Failures
+	require.Len(t, failures, 4)
+	assert.Equal(t, "resource deployment.spec.replicas: expected 3, got 2", failures[0])
+	assert.Equal(t, "resource ingress: not rendered (skipped)", failures[1])
+	assert.Contains(t, failures[2], "resource deployment: expected to be ready, but is not ready")
+	assert.Contains(t, failures[2], "no such key: status")
+	assert.Equal(t, "status.availableReplicas: missing", failures[3])
+}
+
+func TestLoadSuite(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "suite.yaml")
+
+	require.NoError(t, os.WriteFile(path, []byte("tests: []\n"), 0o600))
+	_, err := LoadSuite(path)
+	assert.ErrorContains(t, err, "has no resourceGraphDefinition")
+
+	require.NoError(t, os.WriteFile(path, []byte("resourceGraphDefinition: a.yaml\ntest: []\n"), 0o600))
+	_, err = LoadSuite(path)
+	assert.ErrorContains(t, err, "unknown field")
+}
+
+func TestMatchSubset(t *testing.T) {
+	actual := map[string]interface{}{
+		"replicas": int64(2),
+		"name":     "app",
+		"ports":    []interface{}{map[string]interface{}{"port": int64(80), "protocol": "TCP"}},
+	}
+
+	assert.Empty(t, matchSubset("spec", map[string]interface{}{
+		"replicas": float64(2),
+		"ports":    []interface{}{map[string]interface{}{"port": 80}},
+	}, actual))
+	assert.Equal(t, []string{
+		`spec.missing: missing`,
+		`spec.name: expected "other", got "app"`,
+		`spec.ports: expected 2 items, got 1`,
+		`spec.replicas: expected an object, got 2`,
+	}, matchSubset("spec", map[string]interface{}{
+		"name":     "other",
+		"missing":  true,
+		"ports":    []interface{}{nil, nil},
+		"replicas": map[string]interface{}{},
+	}, actual))
+}
@@ -15,9 +15,11 @@
 package runtime
 
 import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
+	"github.com/kubernetes-sigs/kro/api/v1alpha1"
 	"github.com/kubernetes-sigs/kro/pkg/graph/variable"
 )
 
@@ -102,6 +104,11 @@ type ResourceDescriptor interface {
 	// depends on.
 	GetDependencies() []string
 
+	// GetExplicitDependencies returns the list of resource IDs that this
+	// resource declares a dependency on, whether its expressions refer to
+	// them or not.
+	GetExplicitDependencies() []string
+
 	// GetReadyWhenExpressions returns the list of expressions that need to be
 	// evaluated before the resource is considered ready.
 	GetReadyWhenExpressions() []string
@@ -117,6 +124,21 @@ type ResourceDescriptor interface {
 	// IsExternalRef returns true if the resource is marked as an external reference
 	// This is used for external references
 	IsExternalRef() bool
+
+	// GetPropagationPolicy returns the propagation policy used when deleting
+	// the resource, or an empty string for the cluster default.
+	GetPropagationPolicy() metav1.DeletionPropagation
+
+	// GetUpdateStrategy returns how the resource is updated once it exists.
+	GetUpdateStrategy() v1alpha1.UpdateStrategy
+
+	// GetIgnoreFields returns the paths of the fields only set when the
+	// resource is created.
+	GetIgnoreFields() []string
+
+	// IsForceApply returns true if the fields managed by other field managers
+	// are taken over when applying the resource.
+	IsForceApply() bool
 }
 
 // Resource extends `ResourceDescriptor` to include the actual resource data.
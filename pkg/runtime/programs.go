@@ -0,0 +1,135 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime
+
+import (
+	"fmt"
+	"math/bits"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+
+	krocel "github.com/kubernetes-sigs/kro/pkg/cel"
+)
+
+// Programs holds the compiled CEL programs of the expressions of a resource
+// graph definition, keyed by expression.
+//
+// Programs are compiled once, when the resource graph definition is built, and
+// shared by the runtimes of all its instances. They are safe for concurrent
+// use, but the map itself must not be modified once it is shared.
+type Programs map[string]*Program
+
+// Program is the compiled program of an expression, whose evaluation stops
+// once its cost exceeds the expression cost limit.
+type Program struct {
+	env *cel.Env
+	ast *cel.Ast
+	// program is the program limited to costLimit.
+	program   cel.Program
+	costLimit uint64
+
+	mu sync.Mutex
+	// limited are the programs limited to powers of two lower than costLimit,
+	// by limit, planned once the rest of a reconcile budget is lower than
+	// costLimit.
+	limited map[uint64]cel.Program
+}
+
+// withCostLimit returns the program of the expression limited to at most the
+// given cost, and its limit. Below the expression cost limit, the program is
+// limited to the largest power of two not above the given cost, so it is only
+// planned again once per power of two, and not for every evaluation. A zero
+// limit doesn't stop the evaluation.
+func (p *Program) withCostLimit(costLimit uint64) (cel.Program, uint64, error) {
+	if costLimit == p.costLimit || costLimit == 0 {
+		return p.program, p.costLimit, nil
+	}
+	costLimit = 1 << (bits.Len64(costLimit) - 1)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if program, ok := p.limited[costLimit]; ok {
+		return program, costLimit, nil
+	}
+	program, err := p.env.Program(p.ast, krocel.CostLimitProgramOptions(costLimit)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed programming expression %s: %w", p.ast.Source().Content(), err)
+	}
+	if p.limited == nil {
+		p.limited = make(map[uint64]cel.Program)
+	}
+	p.limited[costLimit] = program
+	return program, costLimit, nil
+}
+
+// CompilePrograms compiles the given expressions in an environment declaring
+// the given resource ids, the instance (schema) and the global configuration.
+// The evaluation of the programs stops once their cost exceeds costLimit,
+// unless it is zero.
+func CompilePrograms(resourceIDs []string, expressions []string, costLimit uint64) (Programs, error) {
+	ids := make([]string, 0, len(resourceIDs)+1)
+	ids = append(ids, resourceIDs...)
+	ids = append(ids, "schema")
+	env, err := krocel.DefaultEnvironment(krocel.WithResourceIDs(ids), krocel.WithConfig())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CEL environment: %w", err)
+	}
+
+	programs := make(Programs, len(expressions))
+	for _, expression := range expressions {
+		if _, ok := programs[expression]; ok {
+			continue
+		}
+		program, err := compileProgram(env, expression, costLimit)
+		if err != nil {
+			return nil, err
+		}
+		programs[expression] = program
+	}
+	return programs, nil
+}
+
+// compileProgram compiles a single expression.
+func compileProgram(env *cel.Env, expression string, costLimit uint64) (*Program, error) {
+	ast, issues := env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, fmt.Errorf("failed compiling expression %s: %w", expression, issues.Err())
+	}
+	program, err := env.Program(ast, krocel.CostLimitProgramOptions(costLimit)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed programming expression %s: %w", expression, err)
+	}
+	return &Program{env: env, ast: ast, program: program, costLimit: costLimit}, nil
+}
+
+// evaluateProgram evaluates the program of an expression, and returns its
+// value and the cost of the evaluation.
+func evaluateProgram(program cel.Program, context map[string]interface{}, expression string) (interface{}, uint64, error) {
+	// We get an error here when the value field we're looking for is not yet defined
+	// For now leaving it as error, in the future when we see different scenarios
+	// of this error, we can make some a reason, and others an error
+	val, details, err := program.Eval(context)
+	var cost uint64
+	if details != nil && details.ActualCost() != nil {
+		cost = *details.ActualCost()
+	}
+	if err != nil {
+		return nil, cost, fmt.Errorf("failed evaluating expression %s: %w", expression, err)
+	}
+
+	native, err := krocel.GoNativeType(val)
+	return native, cost, err
+}
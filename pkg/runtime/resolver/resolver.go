@@ -16,6 +16,7 @@ package resolver
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/kubernetes-sigs/kro/pkg/graph/fieldpath"
@@ -60,14 +61,23 @@ func NewResolver(resource map[string]interface{}, data map[string]interface{}) *
 
 // Resolve processes all the given ExpressionFields and resolves their CEL expressions.
 // It returns a ResolutionSummary containing information about the resolution process.
+//
+// The values of the fields are set first, then the structural operations (key
+// renames, merges and splices) are applied, deepest first, so that they don't
+// invalidate the paths of the other fields.
 func (r *Resolver) Resolve(expressions []variable.FieldDescriptor) ResolutionSummary {
 	summary := ResolutionSummary{
 		TotalExpressions: len(expressions),
 		Results:          make([]ResolutionResult, 0, len(expressions)),
 	}
 
-	for _, field := range expressions {
-		result := r.resolveField(field)
+	for _, field := range orderFields(expressions) {
+		var result ResolutionResult
+		if field.Operation == variable.FieldOperationSet {
+			result = r.resolveField(field)
+		} else {
+			result = r.resolveOperation(field)
+		}
 		summary.Results = append(summary.Results, result)
 		if result.Resolved {
 			summary.ResolvedExpressions++
@@ -123,16 +133,17 @@ func (r *Resolver) resolveField(field variable.FieldDescriptor) ResolutionResult
 			return result
 		}
 
-		replaced := strValue
-		for _, expr := range field.Expressions {
+		replacements := make([]interface{}, len(field.Expressions))
+		for i, expr := range field.Expressions {
 			key := strings.Trim(expr, "${}")
 			replacement, ok := r.data[key]
 			if !ok {
 				result.Error = fmt.Errorf("no data provided for expression: %s", expr)
 				return result
 			}
-			replaced = strings.Replace(replaced, "${"+expr+"}", fmt.Sprintf("%v", replacement), -1)
+			replacements[i] = replacement
 		}
+		replaced := replaceExpressions(strValue, field.Expressions, replacements)
 
 		err = r.setValueAtPath(field.Path, replaced)
 		if err != nil {
@@ -146,6 +157,212 @@ func (r *Resolver) resolveField(field variable.FieldDescriptor) ResolutionResult
 	return result
 }
 
+// orderFields returns the fields in the order they must be resolved: the
+// fields whose value is set, then the structural operations by decreasing
+// depth. At the same depth, key renames come before merges, and splices are
+// applied from the last item of a list to the first one, so that the indexes
+// of the items that remain to splice don't change.
+func orderFields(fields []variable.FieldDescriptor) []variable.FieldDescriptor {
+	type orderedField struct {
+		field    variable.FieldDescriptor
+		segments []fieldpath.Segment
+	}
+	var values []variable.FieldDescriptor
+	var operations []orderedField
+	for _, field := range fields {
+		if field.Operation == variable.FieldOperationSet {
+			values = append(values, field)
+			continue
+		}
+		// Invalid paths are reported when the field is resolved.
+		segments, _ := fieldpath.Parse(field.Path)
+		operations = append(operations, orderedField{field: field, segments: segments})
+	}
+
+	operationRank := map[variable.FieldOperation]int{
+		variable.FieldOperationKey:    0,
+		variable.FieldOperationMerge:  1,
+		variable.FieldOperationSplice: 2,
+	}
+	slices.SortStableFunc(operations, func(a, b orderedField) int {
+		if len(a.segments) != len(b.segments) {
+			return len(b.segments) - len(a.segments)
+		}
+		if a.field.Operation != b.field.Operation {
+			return operationRank[a.field.Operation] - operationRank[b.field.Operation]
+		}
+		if a.field.Operation == variable.FieldOperationSplice && len(a.segments) > 0 {
+			return b.segments[len(b.segments)-1].Index - a.segments[len(a.segments)-1].Index
+		}
+		return 0
+	})
+
+	for _, operation := range operations {
+		values = append(values, operation.field)
+	}
+	return values
+}
+
+// resolveOperation applies the structural operation of a field: renaming a
+// map entry, merging a map or splicing a list.
+func (r *Resolver) resolveOperation(field variable.FieldDescriptor) ResolutionResult {
+	result := ResolutionResult{
+		Path:     field.Path,
+		Original: fmt.Sprintf("%v", field.Expressions),
+	}
+
+	segments, err := fieldpath.Parse(field.Path)
+	if err != nil || len(segments) == 0 {
+		result.Error = fmt.Errorf("invalid path '%s': %v", field.Path, err)
+		return result
+	}
+	last := segments[len(segments)-1]
+	parent, err := r.getValueFromPath(fieldpath.Build(segments[:len(segments)-1]))
+	if err != nil {
+		result.Error = fmt.Errorf("error getting value: %v", err)
+		return result
+	}
+
+	values := make([]interface{}, len(field.Expressions))
+	for i, expr := range field.Expressions {
+		value, ok := r.data[expr]
+		if !ok {
+			result.Error = fmt.Errorf("no data provided for expression: %s", expr)
+			return result
+		}
+		values[i] = value
+	}
+
+	switch field.Operation {
+	case variable.FieldOperationKey:
+		result.Replaced, result.Error = renameKey(parent, last.Name, field, values)
+	case variable.FieldOperationMerge:
+		result.Replaced, result.Error = mergeMap(parent, last.Name, values[0])
+	case variable.FieldOperationSplice:
+		var spliced []interface{}
+		spliced, result.Error = spliceList(parent, last.Index, values[0])
+		if result.Error == nil {
+			result.Replaced = spliced
+			result.Error = r.setValueAtPath(fieldpath.Build(segments[:len(segments)-1]), spliced)
+		}
+	default:
+		result.Error = fmt.Errorf("unknown operation %q for path %s", field.Operation, field.Path)
+	}
+	if result.Error != nil {
+		result.Error = fmt.Errorf("error resolving path %s: %w", field.Path, result.Error)
+		return result
+	}
+	result.Resolved = true
+	return result
+}
+
+// renameKey renames the entry of a map whose key is a template. It returns the
+// new key.
+func renameKey(parent interface{}, key string, field variable.FieldDescriptor, values []interface{}) (string, error) {
+	entries, ok := parent.(map[string]interface{})
+	if !ok {
+		return "", fmt.Errorf("expected map, got %T", parent)
+	}
+	value, ok := entries[key]
+	if !ok {
+		return "", fmt.Errorf("key not found: %s", key)
+	}
+
+	var newKey string
+	if field.StandaloneExpression {
+		newKey, ok = values[0].(string)
+		if !ok {
+			return "", fmt.Errorf("key expression %s must evaluate to a string, got %T", field.Expressions[0], values[0])
+		}
+	} else {
+		newKey = replaceExpressions(key, field.Expressions, values)
+	}
+
+	if newKey == key {
+		return newKey, nil
+	}
+	if _, exists := entries[newKey]; exists {
+		return "", fmt.Errorf("duplicate key %s", newKey)
+	}
+	delete(entries, key)
+	entries[newKey] = value
+	return newKey, nil
+}
+
+// mergeMap merges a map into the parent map, and removes the merge entry. The
+// entries of the parent map take precedence.
+func mergeMap(parent interface{}, mergeKey string, value interface{}) (map[string]interface{}, error) {
+	entries, ok := parent.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected map, got %T", parent)
+	}
+	delete(entries, mergeKey)
+	if value == nil {
+		return entries, nil
+	}
+	merged, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("merge expression must evaluate to a map, got %T", value)
+	}
+	for key, value := range merged {
+		if _, exists := entries[key]; !exists {
+			entries[key] = value
+		}
+	}
+	return entries, nil
+}
+
+// spliceList returns the parent list, with the item at the given index
+// replaced by the items of the spliced list.
+func spliceList(parent interface{}, index int, value interface{}) ([]interface{}, error) {
+	list, ok := parent.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected list, got %T", parent)
+	}
+	if index < 0 || index >= len(list) {
+		return nil, fmt.Errorf("array index out of bounds: %d", index)
+	}
+	var items []interface{}
+	if value != nil {
+		items, ok = value.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("splice expression must evaluate to a list, got %T", value)
+		}
+	}
+	spliced := make([]interface{}, 0, len(list)-1+len(items))
+	spliced = append(spliced, list[:index]...)
+	spliced = append(spliced, items...)
+	spliced = append(spliced, list[index+1:]...)
+	return spliced, nil
+}
+
+// replaceExpressions replaces the expressions of a string template with their
+// values, and unescapes the escaped expressions: "$${...}" becomes "${...}".
+//
+// The expressions are expected in the order they appear in the template, as
+// extracted by the parser. Escaped expressions are left aside, even when they
+// have the same content as an expression.
+func replaceExpressions(template string, expressions []string, values []interface{}) string {
+	var b strings.Builder
+	next := 0
+	for i := 0; i < len(template); {
+		rest := template[i:]
+		switch {
+		case strings.HasPrefix(rest, "$${"):
+			b.WriteString("${")
+			i += len("$${")
+		case next < len(expressions) && strings.HasPrefix(rest, "${"+expressions[next]+"}"):
+			b.WriteString(fmt.Sprintf("%v", values[next]))
+			i += len("${" + expressions[next] + "}")
+			next++
+		default:
+			b.WriteByte(template[i])
+			i++
+		}
+	}
+	return b.String()
+}
+
 // getValueFromPath retrieves a value from the resource using a dot separated path.
 // NOTE(a-hilaly): this is very similar to the `setValueAtPath` function maybe
 // we can refactor something here.
@@ -417,6 +417,45 @@ func TestResolveField(t *testing.T) {
 				Replaced: "prefix-one-two-suffix",
 			},
 		},
+		{
+			name: "escaped expression only",
+			resource: map[string]interface{}{
+				"data": map[string]interface{}{
+					"script": "echo $${HOME}",
+				},
+			},
+			field: variable.FieldDescriptor{
+				Path: "data.script",
+			},
+			want: ResolutionResult{
+				Path:     "data.script",
+				Original: "[]",
+				Resolved: true,
+				Replaced: "echo ${HOME}",
+			},
+		},
+		{
+			name: "mixed escaped and real expressions",
+			resource: map[string]interface{}{
+				"data": map[string]interface{}{
+					"script": "$${value1}=${value1} $${USER:-root} ${value2}",
+				},
+			},
+			data: map[string]interface{}{
+				"value1": "one",
+				"value2": 2,
+			},
+			field: variable.FieldDescriptor{
+				Path:        "data.script",
+				Expressions: []string{"value1", "value2"},
+			},
+			want: ResolutionResult{
+				Path:     "data.script",
+				Original: "[value1 value2]",
+				Resolved: true,
+				Replaced: "${value1}=one ${USER:-root} 2",
+			},
+		},
 		{
 			name: "array path with standalone expression",
 			resource: map[string]interface{}{
@@ -663,3 +702,265 @@ func TestResolver(t *testing.T) {
 	assert.Equal(t, summary.ResolvedExpressions, 1)
 	assert.Equal(t, "resolved-done", summary.Results[0].Replaced)
 }
+
+func TestResolveOperations(t *testing.T) {
+	tests := []struct {
+		name     string
+		resource map[string]interface{}
+		data     map[string]interface{}
+		fields   []variable.FieldDescriptor
+		want     map[string]interface{}
+		wantErr  string
+	}{
+		{
+			name: "standalone key expression",
+			resource: map[string]interface{}{
+				"labels": map[string]interface{}{
+					"${key}": "${value}",
+					"team":   "platform",
+				},
+			},
+			data: map[string]interface{}{"key": "app", "value": "web"},
+			fields: []variable.FieldDescriptor{
+				{
+					Path:                 `labels["${key}"]`,
+					Expressions:          []string{"key"},
+					StandaloneExpression: true,
+					Operation:            variable.FieldOperationKey,
+				},
+				{
+					Path:                 `labels["${key}"]`,
+					Expressions:          []string{"value"},
+					StandaloneExpression: true,
+				},
+			},
+			want: map[string]interface{}{
+				"labels": map[string]interface{}{
+					"app":  "web",
+					"team": "platform",
+				},
+			},
+		},
+		{
+			name: "templated key",
+			resource: map[string]interface{}{
+				"annotations": map[string]interface{}{
+					"${domain}/$${name}": "true",
+				},
+			},
+			data: map[string]interface{}{"domain": "example.com"},
+			fields: []variable.FieldDescriptor{
+				{
+					Path:        `annotations["${domain}/$${name}"]`,
+					Expressions: []string{"domain"},
+					Operation:   variable.FieldOperationKey,
+				},
+			},
+			want: map[string]interface{}{
+				"annotations": map[string]interface{}{
+					"example.com/${name}": "true",
+				},
+			},
+		},
+		{
+			name: "key expression with a non string value",
+			resource: map[string]interface{}{
+				"labels": map[string]interface{}{"${key}": "web"},
+			},
+			data: map[string]interface{}{"key": int64(1)},
+			fields: []variable.FieldDescriptor{
+				{
+					Path:                 `labels["${key}"]`,
+					Expressions:          []string{"key"},
+					StandaloneExpression: true,
+					Operation:            variable.FieldOperationKey,
+				},
+			},
+			wantErr: "must evaluate to a string",
+		},
+		{
+			name: "key expression with a duplicate key",
+			resource: map[string]interface{}{
+				"labels": map[string]interface{}{"${key}": "web", "app": "api"},
+			},
+			data: map[string]interface{}{"key": "app"},
+			fields: []variable.FieldDescriptor{
+				{
+					Path:                 `labels["${key}"]`,
+					Expressions:          []string{"key"},
+					StandaloneExpression: true,
+					Operation:            variable.FieldOperationKey,
+				},
+			},
+			wantErr: "duplicate key app",
+		},
+		{
+			name: "merge keeps the template entries",
+			resource: map[string]interface{}{
+				"labels": map[string]interface{}{
+					"$merge": "${labels}",
+					"app":    "${name}",
+				},
+			},
+			data: map[string]interface{}{
+				"labels": map[string]interface{}{"app": "other", "team": "platform"},
+				"name":   "web",
+			},
+			fields: []variable.FieldDescriptor{
+				{
+					Path:                 "labels.$merge",
+					Expressions:          []string{"labels"},
+					StandaloneExpression: true,
+					Operation:            variable.FieldOperationMerge,
+				},
+				{
+					Path:                 "labels.app",
+					Expressions:          []string{"name"},
+					StandaloneExpression: true,
+				},
+			},
+			want: map[string]interface{}{
+				"labels": map[string]interface{}{
+					"app":  "web",
+					"team": "platform",
+				},
+			},
+		},
+		{
+			name: "merge of a null value",
+			resource: map[string]interface{}{
+				"labels": map[string]interface{}{"$merge": "${labels}"},
+			},
+			data: map[string]interface{}{"labels": nil},
+			fields: []variable.FieldDescriptor{
+				{
+					Path:                 "labels.$merge",
+					Expressions:          []string{"labels"},
+					StandaloneExpression: true,
+					Operation:            variable.FieldOperationMerge,
+				},
+			},
+			want: map[string]interface{}{
+				"labels": map[string]interface{}{},
+			},
+		},
+		{
+			name: "splices in the same list",
+			resource: map[string]interface{}{
+				"env": []interface{}{
+					map[string]interface{}{"$splice": "${first}"},
+					map[string]interface{}{"name": "MIDDLE", "value": "${middle}"},
+					map[string]interface{}{"$splice": "${last}"},
+				},
+			},
+			data: map[string]interface{}{
+				"first": []interface{}{
+					map[string]interface{}{"name": "A"},
+					map[string]interface{}{"name": "B"},
+				},
+				"middle": "m",
+				"last":   nil,
+			},
+			fields: []variable.FieldDescriptor{
+				{
+					Path:                 "env[0]",
+					Expressions:          []string{"first"},
+					StandaloneExpression: true,
+					Operation:            variable.FieldOperationSplice,
+				},
+				{
+					Path:                 "env[1].value",
+					Expressions:          []string{"middle"},
+					StandaloneExpression: true,
+				},
+				{
+					Path:                 "env[2]",
+					Expressions:          []string{"last"},
+					StandaloneExpression: true,
+					Operation:            variable.FieldOperationSplice,
+				},
+			},
+			want: map[string]interface{}{
+				"env": []interface{}{
+					map[string]interface{}{"name": "A"},
+					map[string]interface{}{"name": "B"},
+					map[string]interface{}{"name": "MIDDLE", "value": "m"},
+				},
+			},
+		},
+		{
+			name: "splice of a non list value",
+			resource: map[string]interface{}{
+				"env": []interface{}{
+					map[string]interface{}{"$splice": "${env}"},
+				},
+			},
+			data: map[string]interface{}{"env": "value"},
+			fields: []variable.FieldDescriptor{
+				{
+					Path:                 "env[0]",
+					Expressions:          []string{"env"},
+					StandaloneExpression: true,
+					Operation:            variable.FieldOperationSplice,
+				},
+			},
+			wantErr: "must evaluate to a list",
+		},
+		{
+			name: "nested operations",
+			resource: map[string]interface{}{
+				"${outer}": map[string]interface{}{
+					"items": []interface{}{
+						map[string]interface{}{"$splice": "${items}"},
+					},
+					"${inner}": "value",
+				},
+			},
+			data: map[string]interface{}{
+				"outer": "spec",
+				"inner": "field",
+				"items": []interface{}{"a", "b"},
+			},
+			fields: []variable.FieldDescriptor{
+				{
+					Path:                 `["${outer}"]`,
+					Expressions:          []string{"outer"},
+					StandaloneExpression: true,
+					Operation:            variable.FieldOperationKey,
+				},
+				{
+					Path:                 `["${outer}"].items[0]`,
+					Expressions:          []string{"items"},
+					StandaloneExpression: true,
+					Operation:            variable.FieldOperationSplice,
+				},
+				{
+					Path:                 `["${outer}"]["${inner}"]`,
+					Expressions:          []string{"inner"},
+					StandaloneExpression: true,
+					Operation:            variable.FieldOperationKey,
+				},
+			},
+			want: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"items": []interface{}{"a", "b"},
+					"field": "value",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewResolver(tt.resource, tt.data)
+			summary := r.Resolve(tt.fields)
+			if tt.wantErr != "" {
+				assert.NotEmpty(t, summary.Errors)
+				assert.Contains(t, fmt.Sprint(summary.Errors), tt.wantErr)
+				return
+			}
+			assert.Empty(t, summary.Errors)
+			assert.Equal(t, tt.want, tt.resource)
+		})
+	}
+}
@@ -15,11 +15,11 @@
 package runtime
 
 import (
+	"errors"
 	"fmt"
 	"slices"
 	"strings"
 
-	"github.com/google/cel-go/cel"
 	"golang.org/x/exp/maps"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
@@ -46,11 +46,20 @@ func NewResourceGraphDefinitionRuntime(
 	instance Resource,
 	resources map[string]Resource,
 	topologicalOrder []string,
+	config map[string]string,
+	costLimits krocel.CostLimits,
+	programs Programs,
 ) (*ResourceGraphDefinitionRuntime, error) {
+	if config == nil {
+		config = map[string]string{}
+	}
 	r := &ResourceGraphDefinitionRuntime{
 		instance:                     instance,
 		resources:                    resources,
 		topologicalOrder:             topologicalOrder,
+		config:                       config,
+		costLimits:                   costLimits,
+		programs:                     programs,
 		resolvedResources:            make(map[string]*unstructured.Unstructured),
 		runtimeVariables:             make(map[string][]*expressionEvaluationState),
 		expressionsCache:             make(map[string]*expressionEvaluationState),
@@ -170,6 +179,29 @@ type ResourceGraphDefinitionRuntime struct {
 	// ignoredByConditionsResources holds the resources who's defined conditions returned false
 	// or who's dependencies are ignored
 	ignoredByConditionsResources map[string]bool
+
+	// config holds the global configuration values, exposed to every
+	// expression through the kro.config variable. The values are read once,
+	// when the runtime is created.
+	config map[string]string
+
+	// costLimits bounds the cost of the expressions evaluated by the runtime.
+	// Since a runtime is created for every reconciliation of an instance, the
+	// reconcile limit applies to the total cost of the runtime.
+	costLimits krocel.CostLimits
+
+	// cost is the total cost of the expressions evaluated so far.
+	cost uint64
+
+	// programs holds the compiled programs of the expressions, shared with
+	// the runtimes of the other instances of the resource graph definition.
+	programs Programs
+
+	// templates holds the unresolved objects of the resources, keyed by
+	// resource id. Structural operations (key expressions, merges and
+	// splices) remove the entries holding them once they are resolved, so
+	// resources are always resolved from a copy of their template.
+	templates map[string]map[string]interface{}
 }
 
 // TopologicalOrder returns the topological order of resources.
@@ -231,6 +263,11 @@ func (rt *ResourceGraphDefinitionRuntime) SetInstance(obj *unstructured.Unstruct
 // Every time Synchronize is called, it walks through the resources and tries
 // to resolve as many as possible. If a resource is resolved, it's added to the
 // resolved resources map.
+//
+// The expressions reading data that isn't observed yet, like a status field
+// that isn't set, don't prevent the others from being evaluated and
+// propagated: the first of them is reported as an incomplete data EvalError
+// once everything else is synchronized.
 func (rt *ResourceGraphDefinitionRuntime) Synchronize() (bool, error) {
 	// if everything is resolved, we're done.
 	// TODO(a-hilaly): Add readiness check here.
@@ -238,14 +275,16 @@ func (rt *ResourceGraphDefinitionRuntime) Synchronize() (bool, error) {
 		return false, nil
 	}
 
-	// first synchronize the resources.
-	err := rt.evaluateDynamicVariables()
-	if err != nil {
-		return true, fmt.Errorf("failed to evaluate dynamic variables: %w", err)
+	// first synchronize the resources. The variables missing data don't
+	// prevent the others from being propagated.
+	evalErr := rt.evaluateDynamicVariables()
+	var incompleteErr *EvalError
+	if evalErr != nil && (!errors.As(evalErr, &incompleteErr) || !incompleteErr.IsIncompleteData) {
+		return true, fmt.Errorf("failed to evaluate dynamic variables: %w", evalErr)
 	}
 
 	// Now propagate the resource variables.
-	err = rt.propagateResourceVariables()
+	err := rt.propagateResourceVariables()
 	if err != nil {
 		return true, fmt.Errorf("failed to propagate resource variables: %w", err)
 	}
@@ -256,6 +295,9 @@ func (rt *ResourceGraphDefinitionRuntime) Synchronize() (bool, error) {
 		return true, fmt.Errorf("failed to evaluate instance statuses: %w", err)
 	}
 
+	if evalErr != nil {
+		return true, fmt.Errorf("failed to evaluate dynamic variables: %w", evalErr)
+	}
 	return true, nil
 }
 
@@ -285,6 +327,17 @@ func (rt *ResourceGraphDefinitionRuntime) canProcessResource(resource string) bo
 		}
 	}
 
+	// Explicit dependencies carry no data, they only need to exist and to be
+	// ready.
+	for _, dep := range rt.resources[resource].GetExplicitDependencies() {
+		if _, ok := rt.resolvedResources[dep]; !ok {
+			return false
+		}
+		if ready, _, err := rt.IsResourceReady(dep); err != nil || !ready {
+			return false
+		}
+	}
+
 	// Check if the resource variables are resolved.
 	kk := rt.resourceVariablesResolved(resource)
 	return kk
@@ -306,17 +359,13 @@ func (rt *ResourceGraphDefinitionRuntime) resourceVariablesResolved(resource str
 // depending only on the initial configuration. This function is usually
 // called once during runtime initialization to set up the baseline state
 func (rt *ResourceGraphDefinitionRuntime) evaluateStaticVariables() error {
-	env, err := krocel.DefaultEnvironment(krocel.WithResourceIDs([]string{"schema"}))
-	if err != nil {
-		return err
-	}
-
 	evalContext := map[string]interface{}{
-		"schema": rt.instance.Unstructured().Object,
+		"schema":           rt.instance.Unstructured().Object,
+		krocel.KroVariable: krocel.KroValue(rt.config),
 	}
 	for _, variable := range rt.expressionsCache {
 		if variable.Kind.IsStatic() {
-			value, err := evaluateExpression(env, evalContext, variable.Expression)
+			value, err := rt.evaluateWithinBudget(evalContext, variable.Expression)
 			if err != nil {
 				return err
 			}
@@ -351,15 +400,15 @@ func (rt *ResourceGraphDefinitionRuntime) evaluateDynamicVariables() error {
 
 	resolvedResources := maps.Keys(rt.resolvedResources)
 	resolvedResources = append(resolvedResources, "schema")
-	env, err := krocel.DefaultEnvironment(krocel.WithResourceIDs(resolvedResources))
-	if err != nil {
-		return err
-	}
 
 	// Let's iterate over any resolved resource and try to resolve
 	// the dynamic variables that depend on it.
 	// Since we have already cached the expressions, we don't need to
 	// loop over all the resources.
+	//
+	// The variables missing data are skipped, so that the others are still
+	// resolved, and the first one is reported once all are evaluated.
+	var incompleteErr *EvalError
 	for _, variable := range rt.expressionsCache {
 		if variable.Kind.IsDynamic() {
 			// Skip the variable if it's already resolved
@@ -380,16 +429,20 @@ func (rt *ResourceGraphDefinitionRuntime) evaluateDynamicVariables() error {
 			}
 
 			evalContext["schema"] = rt.instance.Unstructured().Object
+			evalContext[krocel.KroVariable] = krocel.KroValue(rt.config)
 
-			value, err := evaluateExpression(env, evalContext, variable.Expression)
+			value, err := rt.evaluateWithinBudget(evalContext, variable.Expression)
 			if err != nil {
 				if strings.Contains(err.Error(), "no such key") {
 					// TODO(a-hilaly): I'm not sure if this is the best way to handle
 					// these. Probably need to reiterate here.
-					return &EvalError{
-						IsIncompleteData: true,
-						Err:              err,
+					if incompleteErr == nil {
+						incompleteErr = &EvalError{
+							IsIncompleteData: true,
+							Err:              err,
+						}
 					}
+					continue
 				}
 				return &EvalError{
 					Err: err,
@@ -401,6 +454,9 @@ func (rt *ResourceGraphDefinitionRuntime) evaluateDynamicVariables() error {
 		}
 	}
 
+	if incompleteErr != nil {
+		return incompleteErr
+	}
 	return nil
 }
 
@@ -447,15 +503,56 @@ func (rt *ResourceGraphDefinitionRuntime) evaluateResourceExpressions(resource s
 		exprFields[i] = v.FieldDescriptor
 	}
 
-	rs := resolver.NewResolver(rt.resources[resource].Unstructured().Object, exprValues)
+	object := rt.resourceTemplate(resource)
+	rs := resolver.NewResolver(object, exprValues)
 
 	summary := rs.Resolve(exprFields)
 	if summary.Errors != nil {
 		return fmt.Errorf("failed to resolve resource %s: %v", resource, summary.Errors)
 	}
+	rt.resources[resource].Unstructured().Object = object
 	return nil
 }
 
+// resourceTemplate returns a copy of the unresolved object of a resource.
+func (rt *ResourceGraphDefinitionRuntime) resourceTemplate(resource string) map[string]interface{} {
+	if rt.templates == nil {
+		rt.templates = make(map[string]map[string]interface{})
+	}
+	template, ok := rt.templates[resource]
+	if !ok {
+		template = DeepCopyObject(rt.resources[resource].Unstructured().Object)
+		rt.templates[resource] = template
+	}
+	return DeepCopyObject(template)
+}
+
+// DeepCopyObject returns a deep copy of an object. Unlike
+// runtime.DeepCopyJSON, it accepts any scalar value, e.g. the unsigned
+// integers CEL expressions can return.
+func DeepCopyObject(object map[string]interface{}) map[string]interface{} {
+	return deepCopyValue(object).(map[string]interface{})
+}
+
+func deepCopyValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(value))
+		for k, v := range value {
+			copied[k] = deepCopyValue(v)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(value))
+		for i, v := range value {
+			copied[i] = deepCopyValue(v)
+		}
+		return copied
+	default:
+		return value
+	}
+}
+
 // allExpressionsAreResolved checks if every expression in the runtimes cache
 // has been successfully evaluated
 func (rt *ResourceGraphDefinitionRuntime) allExpressionsAreResolved() bool {
@@ -483,18 +580,13 @@ func (rt *ResourceGraphDefinitionRuntime) IsResourceReady(resourceID string) (bo
 		return true, "", nil
 	}
 
-	// we should not expect errors here since we already compiled it
-	// in the dryRun
-	env, err := krocel.DefaultEnvironment(krocel.WithResourceIDs([]string{resourceID}))
-	if err != nil {
-		return false, "", fmt.Errorf("failed creating new Environment: %w", err)
-	}
 	context := map[string]interface{}{
-		resourceID: observed.Object,
+		resourceID:         observed.Object,
+		krocel.KroVariable: krocel.KroValue(rt.config),
 	}
 
 	for _, expression := range expressions {
-		out, err := evaluateExpression(env, context, expression)
+		out, err := rt.evaluateWithinBudget(context, expression)
 		if err != nil {
 			return false, "", fmt.Errorf("failed evaluating expressison %s: %w", expression, err)
 		}
@@ -539,20 +631,14 @@ func (rt *ResourceGraphDefinitionRuntime) ReadyToProcessResource(resourceID stri
 		return true, nil
 	}
 
-	// we should not expect errors here since we already compiled it
-	// in the dryRun
-	env, err := krocel.DefaultEnvironment(krocel.WithResourceIDs([]string{"schema"}))
-	if err != nil {
-		return false, nil
-	}
-
 	context := map[string]interface{}{
-		"schema": rt.instance.Unstructured().Object,
+		"schema":           rt.instance.Unstructured().Object,
+		krocel.KroVariable: krocel.KroValue(rt.config),
 	}
 
 	for _, includeWhenExpression := range includeWhenExpressions {
 		// We should not expect an error here as well since we checked during dry-run
-		value, err := evaluateExpression(env, context, includeWhenExpression)
+		value, err := rt.evaluateWithinBudget(context, includeWhenExpression)
 		if err != nil {
 			return false, err
 		}
@@ -564,26 +650,78 @@ func (rt *ResourceGraphDefinitionRuntime) ReadyToProcessResource(resourceID stri
 	return true, nil
 }
 
-// evaluateExpression evaluates an CEL expression and returns a value if successful, or error
-func evaluateExpression(env *cel.Env, context map[string]interface{}, expression string) (interface{}, error) {
-	ast, issues := env.Compile(expression)
-	if issues != nil && issues.Err() != nil {
-		return nil, fmt.Errorf("failed compiling expression %s: %w", expression, issues.Err())
+// evaluateWithinBudget evaluates an expression within the cost limits of the
+// runtime, and charges its cost to the reconcile budget. An evaluation is
+// stopped once it exceeds the expression cost limit or the rest of the
+// reconcile budget, rounded down to a power of two, so the total cost never
+// exceeds the reconcile budget.
+func (rt *ResourceGraphDefinitionRuntime) evaluateWithinBudget(
+	context map[string]interface{},
+	expression string,
+) (interface{}, error) {
+	// The evaluation is limited to the expression cost limit, or to what
+	// remains of the reconcile budget if it is lower.
+	limit := rt.costLimits.Expression
+	limitedByReconcile := false
+	if rt.costLimits.Reconcile > 0 {
+		if rt.cost >= rt.costLimits.Reconcile {
+			return nil, fmt.Errorf("reconcile cost limit of %d exhausted before evaluating expression %s",
+				rt.costLimits.Reconcile, expression)
+		}
+		if remaining := rt.costLimits.Reconcile - rt.cost; limit == 0 || remaining < limit {
+			limit = remaining
+			limitedByReconcile = true
+		}
 	}
-	// Here as well
-	program, err := env.Program(ast)
+
+	program, err := rt.program(context, expression)
 	if err != nil {
-		return nil, fmt.Errorf("failed programming expression %s: %w", expression, err)
+		return nil, err
 	}
-	// We get an error here when the value field we're looking for is not yet defined
-	// For now leaving it as error, in the future when we see different scenarios
-	// of this error, we can make some a reason, and others an error
-	val, _, err := program.Eval(context)
+	limited, limit, err := program.withCostLimit(limit)
 	if err != nil {
-		return nil, fmt.Errorf("failed evaluating expression %s: %w", expression, err)
+		return nil, err
+	}
+	value, cost, err := evaluateProgram(limited, context, expression)
+	if krocel.IsCostLimitExceeded(err) {
+		// Cancelled evaluations don't report their cost, which reached the
+		// limit. An expression stopped by the reconcile budget would have
+		// exceeded it, so the budget is exhausted.
+		if limitedByReconcile {
+			rt.cost = rt.costLimits.Reconcile
+			return nil, fmt.Errorf("expression %s exceeded the reconcile cost limit of %d: %w",
+				expression, rt.costLimits.Reconcile, err)
+		}
+		rt.cost += limit
+		return nil, fmt.Errorf("expression %s exceeded the expression cost limit of %d: %w",
+			expression, limit, err)
 	}
+	rt.cost += cost
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
 
-	return krocel.GoNativeType(val)
+// program returns the compiled program of an expression. Expressions are
+// compiled by the graph builder, this only compiles the ones missing, in an
+// environment declaring the variables of the evaluation context.
+func (rt *ResourceGraphDefinitionRuntime) program(context map[string]interface{}, expression string) (*Program, error) {
+	if program, ok := rt.programs[expression]; ok {
+		return program, nil
+	}
+
+	ids := make([]string, 0, len(context))
+	for id := range context {
+		if id != krocel.KroVariable {
+			ids = append(ids, id)
+		}
+	}
+	env, err := krocel.DefaultEnvironment(krocel.WithResourceIDs(ids), krocel.WithConfig())
+	if err != nil {
+		return nil, fmt.Errorf("failed creating new Environment: %w", err)
+	}
+	return compileProgram(env, expression, rt.costLimits.Expression)
 }
 
 // containsAllElements checks if all elements in the inner slice are present
@@ -16,14 +16,18 @@ package runtime
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"reflect"
 	"strings"
 	"testing"
 
 	"github.com/google/cel-go/cel"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
+	"github.com/kubernetes-sigs/kro/api/v1alpha1"
 	krocel "github.com/kubernetes-sigs/kro/pkg/cel"
 	"github.com/kubernetes-sigs/kro/pkg/graph/variable"
 )
@@ -219,7 +223,7 @@ func Test_RuntimeWorkflow(t *testing.T) {
 	}
 
 	// 2. Create runtime
-	rt, err := NewResourceGraphDefinitionRuntime(instance, resources, []string{"configmap", "secret", "deployment", "service"})
+	rt, err := NewResourceGraphDefinitionRuntime(instance, resources, []string{"configmap", "secret", "deployment", "service"}, nil, krocel.CostLimits{}, nil)
 	if err != nil {
 		t.Fatalf("NewResourceGraphDefinitionRuntime() error = %v", err)
 	}
@@ -446,7 +450,7 @@ func Test_NewResourceGraphDefinitionRuntime(t *testing.T) {
 		"service":    service,
 	}
 
-	rt, err := NewResourceGraphDefinitionRuntime(instance, resources, []string{"deployment", "service"})
+	rt, err := NewResourceGraphDefinitionRuntime(instance, resources, []string{"deployment", "service"}, nil, krocel.CostLimits{}, nil)
 	if err != nil {
 		t.Fatalf("NewResourceGraphDefinitionRuntime() error = %v", err)
 	}
@@ -799,6 +803,123 @@ func Test_Synchronize(t *testing.T) {
 	}
 }
 
+func Test_Synchronize_IncompleteData(t *testing.T) {
+	newRuntime := func(t *testing.T) *ResourceGraphDefinitionRuntime {
+		instance := newTestResource(
+			withObject(map[string]interface{}{
+				"spec": map[string]interface{}{
+					"appName": "myapp",
+				},
+			}),
+			withVariables([]*variable.ResourceField{
+				{
+					FieldDescriptor: variable.FieldDescriptor{
+						Path:                 "status.ready",
+						Expressions:          []string{"deployment.status.readyReplicas > 0"},
+						StandaloneExpression: true,
+					},
+					Kind:         variable.ResourceVariableKindDynamic,
+					Dependencies: []string{"deployment"},
+				},
+			}),
+		)
+		deployment := newTestResource(
+			withObject(map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"name": "${schema.spec.appName}",
+				},
+			}),
+			withVariables([]*variable.ResourceField{
+				{
+					FieldDescriptor: variable.FieldDescriptor{
+						Path:                 "metadata.name",
+						Expressions:          []string{"schema.spec.appName"},
+						StandaloneExpression: true,
+					},
+					Kind: variable.ResourceVariableKindStatic,
+				},
+			}),
+		)
+		service := newTestResource(
+			withObject(map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"name": "${deployment.metadata.name}",
+				},
+			}),
+			withVariables([]*variable.ResourceField{
+				{
+					FieldDescriptor: variable.FieldDescriptor{
+						Path:                 "metadata.name",
+						Expressions:          []string{"deployment.metadata.name"},
+						StandaloneExpression: true,
+					},
+					Kind:         variable.ResourceVariableKindDynamic,
+					Dependencies: []string{"deployment"},
+				},
+			}),
+			withDependencies([]string{"deployment"}),
+		)
+		rt, err := NewResourceGraphDefinitionRuntime(instance, map[string]Resource{
+			"deployment": deployment,
+			"service":    service,
+		}, []string{"deployment", "service"}, nil, krocel.CostLimits{}, nil)
+		if err != nil {
+			t.Fatalf("NewResourceGraphDefinitionRuntime() error = %v", err)
+		}
+		if _, err := rt.Synchronize(); err != nil {
+			t.Fatalf("Synchronize() error = %v", err)
+		}
+		return rt
+	}
+
+	// The variables are evaluated in no particular order: whichever comes
+	// first, the status of the instance missing from the deployment must not
+	// prevent the service from being resolved.
+	for i := 0; i < 20; i++ {
+		rt := newRuntime(t)
+		rt.SetResource("deployment", &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"name": "myapp",
+				},
+			},
+		})
+
+		_, err := rt.Synchronize()
+		var evalErr *EvalError
+		if !errors.As(err, &evalErr) || !evalErr.IsIncompleteData {
+			t.Fatalf("Synchronize() error = %v, want incomplete data error", err)
+		}
+		obj, state := rt.GetResource("service")
+		if state != ResourceStateResolved {
+			t.Fatalf("service state = %v, want %v", state, ResourceStateResolved)
+		}
+		if name := obj.GetName(); name != "myapp" {
+			t.Fatalf("service name = %q, want %q", name, "myapp")
+		}
+	}
+
+	// Once the data is observed, the instance status is resolved as well.
+	rt := newRuntime(t)
+	rt.SetResource("deployment", &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"name": "myapp",
+			},
+			"status": map[string]interface{}{
+				"readyReplicas": int64(1),
+			},
+		},
+	})
+	if _, err := rt.Synchronize(); err != nil {
+		t.Fatalf("Synchronize() error = %v", err)
+	}
+	ready, found, err := unstructured.NestedBool(rt.GetInstance().Object, "status", "ready")
+	if err != nil || !found || !ready {
+		t.Errorf("instance status.ready = %v (found %v, error %v), want true", ready, found, err)
+	}
+}
+
 func Test_propagateResourceVariables(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -1037,11 +1158,12 @@ func Test_propagateResourceVariables(t *testing.T) {
 
 func Test_canProcessResource(t *testing.T) {
 	tests := []struct {
-		name             string
-		resources        map[string]Resource
-		runtimeVariables map[string][]*expressionEvaluationState
-		resource         string
-		want             bool
+		name              string
+		resources         map[string]Resource
+		runtimeVariables  map[string][]*expressionEvaluationState
+		resolvedResources map[string]*unstructured.Unstructured
+		resource          string
+		want              bool
 	}{
 		{
 			name: "no dependencies or variables",
@@ -1209,13 +1331,61 @@ func Test_canProcessResource(t *testing.T) {
 			resource: "test",
 			want:     true,
 		},
+		{
+			name: "explicit dependency not observed",
+			resources: map[string]Resource{
+				"test": newTestResource(
+					withExplicitDependencies([]string{"dep1"}),
+				),
+				"dep1": newTestResource(),
+			},
+			resource: "test",
+			want:     false,
+		},
+		{
+			name: "explicit dependency observed and ready",
+			resources: map[string]Resource{
+				"test": newTestResource(
+					withExplicitDependencies([]string{"dep1"}),
+				),
+				"dep1": newTestResource(
+					withReadyExpressions([]string{"dep1.status.ready"}),
+				),
+			},
+			resolvedResources: map[string]*unstructured.Unstructured{
+				"dep1": {Object: map[string]interface{}{
+					"status": map[string]interface{}{"ready": true},
+				}},
+			},
+			resource: "test",
+			want:     true,
+		},
+		{
+			name: "explicit dependency observed but not ready",
+			resources: map[string]Resource{
+				"test": newTestResource(
+					withExplicitDependencies([]string{"dep1"}),
+				),
+				"dep1": newTestResource(
+					withReadyExpressions([]string{"dep1.status.ready"}),
+				),
+			},
+			resolvedResources: map[string]*unstructured.Unstructured{
+				"dep1": {Object: map[string]interface{}{
+					"status": map[string]interface{}{"ready": false},
+				}},
+			},
+			resource: "test",
+			want:     false,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			rt := &ResourceGraphDefinitionRuntime{
-				resources:        tt.resources,
-				runtimeVariables: tt.runtimeVariables,
+				resources:         tt.resources,
+				runtimeVariables:  tt.runtimeVariables,
+				resolvedResources: tt.resolvedResources,
 			}
 
 			got := rt.canProcessResource(tt.resource)
@@ -1359,6 +1529,7 @@ func Test_evaluateStaticVariables(t *testing.T) {
 	tests := []struct {
 		name             string
 		instance         Resource
+		config           map[string]string
 		expressionsCache map[string]*expressionEvaluationState
 		wantCache        map[string]*expressionEvaluationState
 		wantErr          bool
@@ -1423,6 +1594,34 @@ func Test_evaluateStaticVariables(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "global config evaluation",
+			instance: newTestResource(
+				withObject(map[string]interface{}{
+					"spec": map[string]interface{}{
+						"image": "nginx",
+					},
+				}),
+			),
+			config: map[string]string{
+				"registry": "ghcr.io",
+			},
+			expressionsCache: map[string]*expressionEvaluationState{
+				"expr1": {
+					Expression: "kro.config.registry + '/' + schema.spec.image",
+					Kind:       variable.ResourceVariableKindStatic,
+					Resolved:   false,
+				},
+			},
+			wantCache: map[string]*expressionEvaluationState{
+				"expr1": {
+					Expression:    "kro.config.registry + '/' + schema.spec.image",
+					Kind:          variable.ResourceVariableKindStatic,
+					Resolved:      true,
+					ResolvedValue: "ghcr.io/nginx",
+				},
+			},
+		},
 		{
 			name: "invalid expression",
 			instance: newTestResource(
@@ -1445,6 +1644,7 @@ func Test_evaluateStaticVariables(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			rt := &ResourceGraphDefinitionRuntime{
 				instance:         tt.instance,
+				config:           tt.config,
 				expressionsCache: tt.expressionsCache,
 			}
 
@@ -1692,6 +1892,48 @@ func Test_evaluateDynamicVariables(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "incomplete data doesn't block the other variables",
+			expressionsCache: map[string]*expressionEvaluationState{
+				"expr1": {
+					Expression:   "res1.status.ready",
+					Kind:         variable.ResourceVariableKindDynamic,
+					Dependencies: []string{"res1"},
+					Resolved:     false,
+				},
+				"expr2": {
+					Expression:   "res1.spec.count > 0",
+					Kind:         variable.ResourceVariableKindDynamic,
+					Dependencies: []string{"res1"},
+					Resolved:     false,
+				},
+			},
+			resolvedResources: map[string]*unstructured.Unstructured{
+				"res1": {
+					Object: map[string]interface{}{
+						"spec": map[string]interface{}{
+							"count": 5,
+						},
+					},
+				},
+			},
+			wantCache: map[string]*expressionEvaluationState{
+				"expr1": {
+					Expression:   "res1.status.ready",
+					Kind:         variable.ResourceVariableKindDynamic,
+					Dependencies: []string{"res1"},
+					Resolved:     false,
+				},
+				"expr2": {
+					Expression:    "res1.spec.count > 0",
+					Kind:          variable.ResourceVariableKindDynamic,
+					Dependencies:  []string{"res1"},
+					Resolved:      true,
+					ResolvedValue: true,
+				},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -1710,7 +1952,7 @@ func Test_evaluateDynamicVariables(t *testing.T) {
 				return
 			}
 
-			if !tt.wantErr && !reflect.DeepEqual(tt.expressionsCache, tt.wantCache) {
+			if tt.wantCache != nil && !reflect.DeepEqual(tt.expressionsCache, tt.wantCache) {
 				t.Errorf("evaluateDynamicVariables() cache = %v, want %v", tt.expressionsCache, tt.wantCache)
 			}
 		})
@@ -2549,7 +2791,11 @@ func Test_evaluateExpression(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := evaluateExpression(env, tt.context, tt.expression)
+			var got interface{}
+			program, err := compileProgram(env, tt.expression, 0)
+			if err == nil {
+				got, _, err = evaluateProgram(program.program, tt.context, tt.expression)
+			}
 			if (err != nil) != tt.wantErr {
 				t.Errorf("evaluateExpression() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -2561,6 +2807,311 @@ func Test_evaluateExpression(t *testing.T) {
 	}
 }
 
+func Test_evaluateWithinBudget(t *testing.T) {
+	const expensive = "[1, 2, 3, 4, 5].map(x, [1, 2, 3, 4, 5].map(y, x * y))"
+	tests := []struct {
+		name        string
+		limits      krocel.CostLimits
+		expressions []string
+		wantErr     string
+	}{
+		{
+			name:        "no limits",
+			expressions: []string{expensive, expensive},
+		},
+		{
+			name:        "within the limits",
+			limits:      krocel.DefaultCostLimits(),
+			expressions: []string{expensive, expensive},
+		},
+		{
+			name:        "expression limit exceeded",
+			limits:      krocel.CostLimits{Expression: 10},
+			expressions: []string{"1 + 1", expensive},
+			wantErr:     "expression " + expensive + " exceeded the expression cost limit of 10",
+		},
+		{
+			name:        "reconcile limit exceeded",
+			limits:      krocel.CostLimits{Expression: 1000, Reconcile: 1000},
+			expressions: []string{expensive, expensive},
+			wantErr:     "expression " + expensive + " exceeded the reconcile cost limit of 1000",
+		},
+		{
+			name:        "expression within its limit exceeds the rest of the budget",
+			limits:      krocel.CostLimits{Expression: krocel.DefaultExpressionCostLimit, Reconcile: 50},
+			expressions: []string{"1 + 1", expensive},
+			wantErr:     "expression " + expensive + " exceeded the reconcile cost limit of 50",
+		},
+		{
+			name:        "reconcile limit exhausted",
+			limits:      krocel.CostLimits{Reconcile: 10},
+			expressions: []string{expensive, "1 + 1"},
+			wantErr:     "reconcile cost limit of 10 exhausted before evaluating expression 1 + 1",
+		},
+	}
+
+	for _, tt := range tests {
+		for _, precompiled := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%s/precompiled=%t", tt.name, precompiled), func(t *testing.T) {
+				rt := &ResourceGraphDefinitionRuntime{costLimits: tt.limits}
+				if precompiled {
+					programs, err := CompilePrograms(nil, tt.expressions, tt.limits.Expression)
+					if err != nil {
+						t.Fatalf("CompilePrograms() error = %v", err)
+					}
+					rt.programs = programs
+				}
+				// Only the error of the last expression is checked, the previous
+				// ones only consume the budget.
+				var err error
+				for _, expression := range tt.expressions {
+					_, err = rt.evaluateWithinBudget(map[string]interface{}{}, expression)
+				}
+				// The budget is never exceeded, even by the expression
+				// exceeding it.
+				if tt.limits.Reconcile > 0 && rt.cost > tt.limits.Reconcile {
+					t.Errorf("evaluateWithinBudget() spent %d, over the reconcile cost limit of %d",
+						rt.cost, tt.limits.Reconcile)
+				}
+				if tt.wantErr == "" {
+					if err != nil {
+						t.Fatalf("evaluateWithinBudget() unexpected error = %v", err)
+					}
+					if rt.cost == 0 {
+						t.Errorf("evaluateWithinBudget() did not track the cost")
+					}
+					return
+				}
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("evaluateWithinBudget() error = %v, want %q", err, tt.wantErr)
+				}
+			})
+		}
+	}
+}
+
+func Test_evaluateWithinBudget_PlansLimitedProgramsOnce(t *testing.T) {
+	instance, resources := newBenchmarkGraph()
+	topologicalOrder := []string{"configmap", "deployment"}
+
+	expressions := []string{}
+	for _, resource := range append([]Resource{instance}, resources["configmap"], resources["deployment"]) {
+		for _, v := range resource.GetVariables() {
+			expressions = append(expressions, v.Expressions...)
+		}
+		expressions = append(expressions, resource.GetReadyWhenExpressions()...)
+	}
+	// The reconcile budget is lower than the expression cost limit, so every
+	// evaluation is limited by the rest of the budget.
+	limits := krocel.CostLimits{Expression: krocel.DefaultExpressionCostLimit, Reconcile: 10000}
+	programs, err := CompilePrograms([]string{"configmap", "deployment"}, expressions, limits.Expression)
+	if err != nil {
+		t.Fatalf("CompilePrograms() error = %v", err)
+	}
+
+	// planned counts the programs planned: the compiled programs, and the
+	// programs limited to a lower cost.
+	planned := func() int {
+		n := 0
+		for _, program := range programs {
+			n += 1 + len(program.limited)
+		}
+		return n
+	}
+
+	configMap := &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "myapp-config"},
+	}}
+	deployment := &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "myapp"},
+		"status":   map[string]interface{}{"readyReplicas": int64(3)},
+	}}
+	reconcile := func() {
+		rt, err := NewResourceGraphDefinitionRuntime(instance, resources, topologicalOrder, nil, limits, programs)
+		if err != nil {
+			t.Fatalf("NewResourceGraphDefinitionRuntime() error = %v", err)
+		}
+		rt.SetResource("configmap", configMap)
+		if _, err := rt.Synchronize(); err != nil {
+			t.Fatalf("Synchronize() error = %v", err)
+		}
+		rt.SetResource("deployment", deployment)
+		if _, err := rt.Synchronize(); err != nil {
+			t.Fatalf("Synchronize() error = %v", err)
+		}
+		if ready, reason, err := rt.IsResourceReady("deployment"); err != nil || !ready {
+			t.Fatalf("IsResourceReady() = %v, %s, %v", ready, reason, err)
+		}
+		if rt.cost == 0 || rt.cost > limits.Reconcile {
+			t.Fatalf("reconcile spent %d, want within the reconcile cost limit of %d", rt.cost, limits.Reconcile)
+		}
+	}
+
+	reconcile()
+	afterFirst := planned()
+	if afterFirst <= len(programs) || afterFirst > 2*len(programs) {
+		t.Errorf("first reconcile planned %d programs for %d expressions, want one limited program per expression",
+			afterFirst, len(programs))
+	}
+	for i := 0; i < 5; i++ {
+		reconcile()
+	}
+	if got := planned(); got != afterFirst {
+		t.Errorf("reconciles planned %d programs, want the %d planned by the first reconcile", got, afterFirst)
+	}
+}
+
+// newBenchmarkGraph returns the instance and the resources of a small graph:
+// a configmap built from the instance spec, and a deployment reading the
+// configmap, whose readiness is reported in the instance status.
+func newBenchmarkGraph() (Resource, map[string]Resource) {
+	instance := newTestResource(
+		withObject(map[string]interface{}{
+			"spec": map[string]interface{}{
+				"name":     "myapp",
+				"replicas": 3,
+				"port":     8080,
+			},
+		}),
+		withVariables([]*variable.ResourceField{
+			{
+				FieldDescriptor: variable.FieldDescriptor{
+					Path:                 "status.ready",
+					Expressions:          []string{"deployment.status.readyReplicas == schema.spec.replicas"},
+					StandaloneExpression: true,
+				},
+				Kind:         variable.ResourceVariableKindDynamic,
+				Dependencies: []string{"deployment"},
+			},
+		}),
+	)
+	configMap := newTestResource(
+		withObject(map[string]interface{}{
+			"metadata": map[string]interface{}{"name": "${name}"},
+			"data":     map[string]interface{}{"PORT": "${port}"},
+		}),
+		withVariables([]*variable.ResourceField{
+			{
+				FieldDescriptor: variable.FieldDescriptor{
+					Path:                 "metadata.name",
+					Expressions:          []string{"schema.spec.name + '-config'"},
+					StandaloneExpression: true,
+				},
+				Kind: variable.ResourceVariableKindStatic,
+			},
+			{
+				FieldDescriptor: variable.FieldDescriptor{
+					Path:                 "data.PORT",
+					Expressions:          []string{"string(schema.spec.port)"},
+					StandaloneExpression: true,
+				},
+				Kind: variable.ResourceVariableKindStatic,
+			},
+		}),
+	)
+	deployment := newTestResource(
+		withObject(map[string]interface{}{
+			"metadata": map[string]interface{}{"name": "${name}"},
+			"spec": map[string]interface{}{
+				"replicas":  "${replicas}",
+				"configMap": "${configmap}",
+			},
+		}),
+		withDependencies([]string{"configmap"}),
+		withReadyExpressions([]string{"deployment.status.readyReplicas > 0"}),
+		withVariables([]*variable.ResourceField{
+			{
+				FieldDescriptor: variable.FieldDescriptor{
+					Path:                 "metadata.name",
+					Expressions:          []string{"schema.spec.name"},
+					StandaloneExpression: true,
+				},
+				Kind: variable.ResourceVariableKindStatic,
+			},
+			{
+				FieldDescriptor: variable.FieldDescriptor{
+					Path:                 "spec.replicas",
+					Expressions:          []string{"schema.spec.replicas"},
+					StandaloneExpression: true,
+				},
+				Kind: variable.ResourceVariableKindStatic,
+			},
+			{
+				FieldDescriptor: variable.FieldDescriptor{
+					Path:                 "spec.configMap",
+					Expressions:          []string{"configmap.metadata.name"},
+					StandaloneExpression: true,
+				},
+				Kind:         variable.ResourceVariableKindDynamic,
+				Dependencies: []string{"configmap"},
+			},
+		}),
+	)
+	return instance, map[string]Resource{
+		"configmap":  configMap,
+		"deployment": deployment,
+	}
+}
+
+// BenchmarkRuntimeReconcile measures the evaluation of the expressions of an
+// instance reconciliation, with and without the programs compiled by the graph
+// builder.
+func BenchmarkRuntimeReconcile(b *testing.B) {
+	instance, resources := newBenchmarkGraph()
+	topologicalOrder := []string{"configmap", "deployment"}
+
+	expressions := []string{}
+	for _, resource := range append([]Resource{instance}, resources["configmap"], resources["deployment"]) {
+		for _, v := range resource.GetVariables() {
+			expressions = append(expressions, v.Expressions...)
+		}
+		expressions = append(expressions, resource.GetReadyWhenExpressions()...)
+	}
+	precompiled, err := CompilePrograms([]string{"configmap", "deployment"}, expressions, 0)
+	if err != nil {
+		b.Fatalf("CompilePrograms() error = %v", err)
+	}
+
+	configMap := &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "myapp-config"},
+	}}
+	deployment := &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "myapp"},
+		"status":   map[string]interface{}{"readyReplicas": int64(3)},
+	}}
+
+	reconcile := func(b *testing.B, programs Programs) {
+		rt, err := NewResourceGraphDefinitionRuntime(
+			instance, resources, topologicalOrder, nil, krocel.DefaultCostLimits(), programs,
+		)
+		if err != nil {
+			b.Fatalf("NewResourceGraphDefinitionRuntime() error = %v", err)
+		}
+		rt.SetResource("configmap", configMap)
+		if _, err := rt.Synchronize(); err != nil {
+			b.Fatalf("Synchronize() error = %v", err)
+		}
+		rt.SetResource("deployment", deployment)
+		if _, err := rt.Synchronize(); err != nil {
+			b.Fatalf("Synchronize() error = %v", err)
+		}
+		if ready, reason, err := rt.IsResourceReady("deployment"); err != nil || !ready {
+			b.Fatalf("IsResourceReady() = %v, %s, %v", ready, reason, err)
+		}
+	}
+
+	b.Run("compiled per evaluation", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			reconcile(b, nil)
+		}
+	})
+	b.Run("precompiled", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			reconcile(b, precompiled)
+		}
+	})
+}
+
 func Test_containsAllElements(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -2644,6 +3195,7 @@ type mockResource struct {
 	gvr                    schema.GroupVersionResource
 	variables              []*variable.ResourceField
 	dependencies           []string
+	explicitDependencies   []string
 	readyExpressions       []string
 	includeWhenExpressions []string
 	namespaced             bool
@@ -2671,6 +3223,10 @@ func (m *mockResource) GetDependencies() []string {
 	return m.dependencies
 }
 
+func (m *mockResource) GetExplicitDependencies() []string {
+	return m.explicitDependencies
+}
+
 func (m *mockResource) GetReadyWhenExpressions() []string {
 	return m.readyExpressions
 }
@@ -2691,6 +3247,22 @@ func (m *mockResource) IsExternalRef() bool {
 	return m.isExternalRef
 }
 
+func (m *mockResource) GetPropagationPolicy() metav1.DeletionPropagation {
+	return ""
+}
+
+func (m *mockResource) GetUpdateStrategy() v1alpha1.UpdateStrategy {
+	return v1alpha1.UpdateStrategyApply
+}
+
+func (m *mockResource) GetIgnoreFields() []string {
+	return nil
+}
+
+func (m *mockResource) IsForceApply() bool {
+	return true
+}
+
 type mockResourceOption func(*mockResource)
 
 /* func withGVR(group, version, resource string) mockResourceOption {
@@ -2715,6 +3287,13 @@ func withDependencies(deps []string) mockResourceOption {
 	}
 }
 
+func withExplicitDependencies(deps []string) mockResourceOption {
+	return func(m *mockResource) {
+		m.dependencies = deps
+		m.explicitDependencies = deps
+	}
+}
+
 func withReadyExpressions(exprs []string) mockResourceOption {
 	return func(m *mockResource) {
 		m.readyExpressions = exprs
@@ -28,9 +28,17 @@ import (
 // The second input customTypes is a map[string]interface{} where the key is
 // the type name and the value its specification. These custom types will be
 // available as predefined types in the transformer.
-func ToOpenAPISpec(obj map[string]interface{}, customTypes map[string]interface{}) (*extv1.JSONSchemaProps, error) {
+//
+// The optional importedTypes are sets of custom types defined outside of
+// obj (e.g. in a TypeLibrary). They are loaded before customTypes, so that
+// customTypes can refer to them, and none of their names can be redefined.
+func ToOpenAPISpec(
+	obj map[string]interface{},
+	customTypes map[string]interface{},
+	importedTypes ...map[string]interface{},
+) (*extv1.JSONSchemaProps, error) {
 	tf := newTransformer()
-	if err := tf.loadPreDefinedTypes(customTypes); err != nil {
+	if err := tf.loadPreDefinedTypes(customTypes, importedTypes...); err != nil {
 		return nil, err
 	}
 	return tf.buildOpenAPISchema(obj)
@@ -58,17 +58,32 @@ func newTransformer() *transformer {
 // loadPreDefinedTypes loads pre-defined types into the transformer.
 // The pre-defined types are used to resolve references in the schema.
 //
-// As of today, kro doesn't support custom types in the schema - do
-// not use this function.
-func (t *transformer) loadPreDefinedTypes(obj map[string]interface{}) error {
+// Imported types (e.g. the types of a TypeLibrary) are loaded before the
+// types in obj, so that the latter can refer to them. A type can only be
+// defined once across obj and all the imported types.
+func (t *transformer) loadPreDefinedTypes(obj map[string]interface{}, imported ...map[string]interface{}) error {
 	t.preDefinedTypes = make(map[string]predefinedType)
 
+	for _, types := range imported {
+		if err := t.addPreDefinedTypes(types); err != nil {
+			return fmt.Errorf("failed to load imported types: %w", err)
+		}
+	}
+	return t.addPreDefinedTypes(obj)
+}
+
+// addPreDefinedTypes builds the schema of the given types and adds them to the
+// already loaded pre-defined types.
+func (t *transformer) addPreDefinedTypes(obj map[string]interface{}) error {
 	jsonSchemaProps, err := t.buildOpenAPISchema(obj)
 	if err != nil {
 		return fmt.Errorf("failed to build pre-defined types schema: %w", err)
 	}
 
 	for k, properties := range jsonSchemaProps.Properties {
+		if _, ok := t.preDefinedTypes[k]; ok {
+			return fmt.Errorf("type %s is already defined", k)
+		}
 		required := false
 		if slices.Contains(jsonSchemaProps.Required, k) {
 			required = true
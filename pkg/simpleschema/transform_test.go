@@ -1098,3 +1098,95 @@ func TestLoadPreDefinedTypes(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadPreDefinedTypes_Imported(t *testing.T) {
+	tests := []struct {
+		name     string
+		obj      map[string]interface{}
+		imported []map[string]interface{}
+		want     map[string]predefinedType
+		wantErr  bool
+	}{
+		{
+			name: "Local types referring to imported types",
+			obj: map[string]interface{}{
+				"Pod": map[string]interface{}{
+					"containers": "[]Container",
+				},
+			},
+			imported: []map[string]interface{}{
+				{
+					"Container": map[string]interface{}{
+						"image": "string | required=true",
+					},
+				},
+			},
+			want: map[string]predefinedType{
+				"Container": {
+					Schema: extv1.JSONSchemaProps{
+						Type:     "object",
+						Required: []string{"image"},
+						Properties: map[string]extv1.JSONSchemaProps{
+							"image": {Type: "string"},
+						},
+					},
+				},
+				"Pod": {
+					Schema: extv1.JSONSchemaProps{
+						Type: "object",
+						Properties: map[string]extv1.JSONSchemaProps{
+							"containers": {
+								Type: "array",
+								Items: &extv1.JSONSchemaPropsOrArray{
+									Schema: &extv1.JSONSchemaProps{
+										Type:     "object",
+										Required: []string{"image"},
+										Properties: map[string]extv1.JSONSchemaProps{
+											"image": {Type: "string"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Local type redefining an imported type",
+			obj: map[string]interface{}{
+				"Container": "string",
+			},
+			imported: []map[string]interface{}{
+				{
+					"Container": map[string]interface{}{
+						"image": "string",
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Two imports defining the same type",
+			obj:  map[string]interface{}{},
+			imported: []map[string]interface{}{
+				{"Port": "integer"},
+				{"Port": "string"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transformer := newTransformer()
+			err := transformer.loadPreDefinedTypes(tt.obj, tt.imported...)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, transformer.preDefinedTypes)
+		})
+	}
+}
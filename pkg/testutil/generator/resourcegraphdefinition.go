@@ -111,6 +111,35 @@ func WithResource(
 	}
 }
 
+// WithDependsOn sets the explicit dependencies of a resource previously added
+// to the ResourceGraphDefinition.
+func WithDependsOn(id string, dependsOn ...string) ResourceGraphDefinitionOption {
+	return func(rgd *krov1alpha1.ResourceGraphDefinition) {
+		for _, resource := range rgd.Spec.Resources {
+			if resource.ID == id {
+				resource.DependsOn = dependsOn
+			}
+		}
+	}
+}
+
+// WithPropagationPolicy sets the deletion propagation policy of a resource
+// previously added to the ResourceGraphDefinition, or the default policy of
+// the ResourceGraphDefinition if id is empty.
+func WithPropagationPolicy(id string, policy metav1.DeletionPropagation) ResourceGraphDefinitionOption {
+	return func(rgd *krov1alpha1.ResourceGraphDefinition) {
+		if id == "" {
+			rgd.Spec.PropagationPolicy = policy
+			return
+		}
+		for _, resource := range rgd.Spec.Resources {
+			if resource.ID == id {
+				resource.PropagationPolicy = policy
+			}
+		}
+	}
+}
+
 func WithValidation(expression, message string) ResourceGraphDefinitionOption {
 	return func(rgd *krov1alpha1.ResourceGraphDefinition) {
 		rgd.Spec.Schema.Validation = append(rgd.Spec.Schema.Validation, krov1alpha1.Validation{
@@ -119,3 +148,13 @@ func WithValidation(expression, message string) ResourceGraphDefinitionOption {
 		})
 	}
 }
+
+// WithTypeImport adds a TypeLibrary import to the ResourceGraphDefinition schema
+func WithTypeImport(name, version string) ResourceGraphDefinitionOption {
+	return func(rgd *krov1alpha1.ResourceGraphDefinition) {
+		rgd.Spec.Schema.Imports = append(rgd.Spec.Schema.Imports, krov1alpha1.TypeImport{
+			Name:    name,
+			Version: version,
+		})
+	}
+}
@@ -0,0 +1,28 @@
+#!/bin/bash
+
+# Generates the OpenAPI definitions of the Kubernetes built-in types, the ones
+# registered in the client-go scheme, used to resolve their schemas without a
+# cluster.
+
+set -euo pipefail
+
+cd "$(dirname "$0")/.."
+
+packages=(
+  k8s.io/apimachinery/pkg/api/resource
+  k8s.io/apimachinery/pkg/apis/meta/v1
+  k8s.io/apimachinery/pkg/runtime
+  k8s.io/apimachinery/pkg/util/intstr
+  k8s.io/apimachinery/pkg/version
+)
+# The API groups registered in the client-go scheme.
+packages+=($(go list -f '{{join .Imports "\n"}}' k8s.io/client-go/kubernetes/scheme | grep '^k8s.io/api/'))
+
+# openapi-gen is run with its own dependencies, apart from the ones of kro.
+go run k8s.io/kube-openapi/cmd/openapi-gen@v0.0.0-20260821135717-be32def86098 \
+  --go-header-file scripts/hack/boilerplate.go.txt \
+  --output-dir pkg/generated/openapi \
+  --output-pkg github.com/kubernetes-sigs/kro/pkg/generated/openapi \
+  --output-file zz_generated.openapi.go \
+  --report-filename /dev/null \
+  "${packages[@]}"
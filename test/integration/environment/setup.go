@@ -159,6 +159,8 @@ func (e *Environment) setupController() error {
 		dc,
 		e.GraphBuilder,
 		1,
+		0,
+		nil,
 	)
 
 	var err error
@@ -0,0 +1,146 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core_test
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/rand"
+
+	krov1alpha1 "github.com/kubernetes-sigs/kro/api/v1alpha1"
+	"github.com/kubernetes-sigs/kro/pkg/testutil/generator"
+)
+
+var _ = Describe("Deletion", func() {
+	It("should wait for dependents to be gone before deleting their dependencies", func(ctx SpecContext) {
+		namespace := fmt.Sprintf("test-%s", rand.String(5))
+
+		ns := &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: namespace,
+			},
+		}
+		Expect(env.Client.Create(ctx, ns)).To(Succeed())
+		DeferCleanup(func(ctx SpecContext) {
+			Expect(env.Client.Delete(ctx, ns)).To(Succeed())
+		})
+
+		rgd := generator.NewResourceGraphDefinition("test-deletion",
+			generator.WithSchema(
+				"TestDeletion", "v1alpha1",
+				map[string]interface{}{
+					"value": "string | default=test",
+				},
+				nil,
+			),
+			generator.WithResource("config", map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"metadata": map[string]interface{}{
+					"name": "${schema.metadata.name}-config",
+				},
+				"data": map[string]interface{}{
+					"value": "${schema.spec.value}",
+				},
+			}, nil, nil),
+			generator.WithResource("app", map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"metadata": map[string]interface{}{
+					"name": "${schema.metadata.name}-app",
+				},
+				"data": map[string]interface{}{
+					"config": "${config.metadata.name}",
+				},
+			}, nil, nil),
+		)
+
+		Expect(env.Client.Create(ctx, rgd)).To(Succeed())
+		DeferCleanup(func(ctx SpecContext) {
+			Expect(env.Client.Delete(ctx, rgd)).To(Succeed())
+		})
+
+		Eventually(func(g Gomega) {
+			createdRGD := &krov1alpha1.ResourceGraphDefinition{}
+			err := env.Client.Get(ctx, types.NamespacedName{Name: rgd.Name}, createdRGD)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(createdRGD.Status.State).To(Equal(krov1alpha1.ResourceGraphDefinitionStateActive))
+		}, 10*time.Second, time.Second).WithContext(ctx).Should(Succeed())
+
+		instance := &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": fmt.Sprintf("%s/%s", krov1alpha1.KRODomainName, "v1alpha1"),
+				"kind":       "TestDeletion",
+				"metadata": map[string]interface{}{
+					"name":      "test-deletion",
+					"namespace": namespace,
+				},
+				"spec": map[string]interface{}{
+					"value": "test",
+				},
+			},
+		}
+		Expect(env.Client.Create(ctx, instance)).To(Succeed())
+
+		// Block the deletion of the dependent with a finalizer
+		app := &corev1.ConfigMap{}
+		Eventually(func(g Gomega, ctx SpecContext) {
+			err := env.Client.Get(ctx, types.NamespacedName{Name: "test-deletion-app", Namespace: namespace}, app)
+			g.Expect(err).ToNot(HaveOccurred())
+			app.Finalizers = append(app.Finalizers, "kro.run/test-blocker")
+			g.Expect(env.Client.Update(ctx, app)).To(Succeed())
+		}, 20*time.Second, time.Second).WithContext(ctx).Should(Succeed())
+
+		Expect(env.Client.Delete(ctx, instance)).To(Succeed())
+
+		Eventually(func(g Gomega, ctx SpecContext) {
+			err := env.Client.Get(ctx, types.NamespacedName{Name: "test-deletion-app", Namespace: namespace}, app)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(app.DeletionTimestamp).ToNot(BeNil())
+		}, 20*time.Second, time.Second).WithContext(ctx).Should(Succeed())
+
+		// The dependency is kept while the dependent is being deleted
+		Consistently(func(g Gomega, ctx SpecContext) {
+			config := &corev1.ConfigMap{}
+			err := env.Client.Get(ctx, types.NamespacedName{Name: "test-deletion-config", Namespace: namespace}, config)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(config.DeletionTimestamp).To(BeNil())
+		}, 5*time.Second, time.Second).WithContext(ctx).Should(Succeed())
+
+		// Release the dependent
+		Eventually(func(g Gomega, ctx SpecContext) {
+			err := env.Client.Get(ctx, types.NamespacedName{Name: "test-deletion-app", Namespace: namespace}, app)
+			g.Expect(err).ToNot(HaveOccurred())
+			app.Finalizers = nil
+			g.Expect(env.Client.Update(ctx, app)).To(Succeed())
+		}, 10*time.Second, time.Second).WithContext(ctx).Should(Succeed())
+
+		Eventually(func(g Gomega, ctx SpecContext) {
+			config := &corev1.ConfigMap{}
+			err := env.Client.Get(ctx, types.NamespacedName{Name: "test-deletion-config", Namespace: namespace}, config)
+			g.Expect(err).To(MatchError(errors.IsNotFound, "config should be deleted"))
+			err = env.Client.Get(ctx, types.NamespacedName{Name: "test-deletion", Namespace: namespace}, instance)
+			g.Expect(err).To(MatchError(errors.IsNotFound, "instance should be deleted"))
+		}, 20*time.Second, time.Second).WithContext(ctx).Should(Succeed())
+	})
+})
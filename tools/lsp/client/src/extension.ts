@@ -26,9 +26,19 @@ export function activate(context: ExtensionContext) {
     serverModule = context.asAbsolutePath(path.join("..", "server", "kro-lsp"));
   }
 
+  // CRDs to validate against when no cluster is reachable
+  const workspaceFolder = workspace.workspaceFolders?.[0]?.uri.fsPath ?? "";
+  const crdPaths = workspace
+    .getConfiguration("kroLanguageServer")
+    .get<string[]>("crdPaths", []);
+  const args = crdPaths.flatMap((crdPath) => [
+    "--crd-path",
+    path.resolve(workspaceFolder, crdPath),
+  ]);
+
   const serverOptions = {
-    run: { command: serverModule, transport: TransportKind.stdio },
-    debug: { command: serverModule, transport: TransportKind.stdio },
+    run: { command: serverModule, args, transport: TransportKind.stdio },
+    debug: { command: serverModule, args, transport: TransportKind.stdio },
   };
 
   const clientOptions = {
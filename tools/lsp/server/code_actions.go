@@ -0,0 +1,243 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/go-logr/logr"
+	"github.com/kubernetes-sigs/kro/pkg/graph"
+	"github.com/kubernetes-sigs/kro/tools/lsp/server/parser"
+	protocol "github.com/tliron/glsp/protocol_3_16"
+	"gopkg.in/yaml.v3"
+	k8sschema "k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/cel/openapi/resolver"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// readyWhenConditions are the readiness conditions of the well known kinds,
+// formatted with the id of the resource.
+var readyWhenConditions = map[k8sschema.GroupKind]string{
+	{Group: "apps", Kind: "Deployment"}:        "${%[1]s.spec.replicas == %[1]s.status.availableReplicas}",
+	{Group: "apps", Kind: "StatefulSet"}:       "${%[1]s.spec.replicas == %[1]s.status.readyReplicas}",
+	{Group: "apps", Kind: "ReplicaSet"}:        "${%[1]s.spec.replicas == %[1]s.status.readyReplicas}",
+	{Group: "apps", Kind: "DaemonSet"}:         "${%[1]s.status.desiredNumberScheduled == %[1]s.status.numberReady}",
+	{Group: "batch", Kind: "Job"}:              "${%[1]s.status.completionTime != null}",
+	{Group: "", Kind: "Pod"}:                   `${%[1]s.status.phase == "Running"}`,
+	{Group: "", Kind: "PersistentVolumeClaim"}: `${%[1]s.status.phase == "Bound"}`,
+	{Group: "", Kind: "Namespace"}:             `${%[1]s.status.phase == "Active"}`,
+}
+
+// CodeActionManager provides the quick fixes of KRO ResourceGraphDefinition
+// documents: renaming the invalid resource ids, adding the readiness
+// conditions of the well known kinds, and adding the required fields missing
+// from the resource templates.
+type CodeActionManager struct {
+	logger     logr.Logger
+	yamlParser *parser.YAMLParser
+	// schemas looks up the schemas of the resources. Without a schema
+	// resolver, the missing required fields aren't looked for.
+	schemas *schemaLookup
+}
+
+// NewCodeActionManager creates a new code action manager. The schema resolver
+// can be nil when there is nothing to resolve the resource schemas from.
+func NewCodeActionManager(logger logr.Logger, schemaResolver resolver.SchemaResolver) *CodeActionManager {
+	return &CodeActionManager{
+		logger:     logger,
+		yamlParser: parser.NewYAMLParser(logger),
+		schemas:    newSchemaLookup(logger, schemaResolver),
+	}
+}
+
+// CodeActions returns the quick fixes of the resource at the start of the
+// given range of a document. The diagnostics are the ones the client reported
+// in the range, to be attached to the fixes addressing them.
+func (cm *CodeActionManager) CodeActions(
+	uri, content string,
+	actionRange protocol.Range,
+	diagnostics []protocol.Diagnostic,
+) []protocol.CodeAction {
+	actions := []protocol.CodeAction{}
+	result := cm.yamlParser.Parse(content)
+	if result.HasErrors || !cm.yamlParser.IsKROResource(result.Node) {
+		return actions
+	}
+	doc := newRGDDocument(content, result.Node)
+	resource := doc.resourceAt(int(actionRange.Start.Line))
+	if resource == nil {
+		return actions
+	}
+
+	if action := cm.fixResourceID(uri, doc, resource, diagnostics); action != nil {
+		actions = append(actions, *action)
+	}
+	if action := cm.addReadyWhen(uri, resource); action != nil {
+		actions = append(actions, *action)
+	}
+	return append(actions, cm.addRequiredFields(uri, resource)...)
+}
+
+// fixResourceID renames the resource to the lower camelCase form of its id,
+// when its id isn't valid.
+func (cm *CodeActionManager) fixResourceID(
+	uri string,
+	doc *rgdDocument,
+	resource *rgdResource,
+	diagnostics []protocol.Diagnostic,
+) *protocol.CodeAction {
+	if resource.ID == "" || graph.IsValidResourceID(resource.ID) {
+		return nil
+	}
+	newID := lowerCamelCase(resource.ID)
+	if validateNewResourceID(doc, newID) != nil {
+		return nil
+	}
+	edits, err := renameEdits(doc, resource.ID, newID)
+	if err != nil {
+		cm.logger.V(1).Info("Failed to rename resource", "id", resource.ID, "error", err)
+		return nil
+	}
+
+	action := newQuickFix(fmt.Sprintf("Rename resource %s to %s", resource.ID, newID), uri, edits)
+	action.IsPreferred = boolPtr(true)
+	idLine := uint32(resource.IDNode.Line - 1)
+	for _, diagnostic := range diagnostics {
+		if diagnostic.Range.Start.Line == idLine {
+			action.Diagnostics = append(action.Diagnostics, diagnostic)
+		}
+	}
+	return &action
+}
+
+// addReadyWhen adds the readiness condition of the kind of the resource, when
+// it is a well known kind and the resource has no readiness condition.
+func (cm *CodeActionManager) addReadyWhen(uri string, resource *rgdResource) *protocol.CodeAction {
+	if resource.ID == "" || resource.ObjectKey == nil || resource.ObjectKey.Value != "template" ||
+		mappingValue(resource.Item, "readyWhen") != nil {
+		return nil
+	}
+	gvk, ok := resource.GVK()
+	if !ok {
+		return nil
+	}
+	condition, ok := readyWhenConditions[gvk.GroupKind()]
+	if !ok {
+		return nil
+	}
+
+	// The condition is added right after the id of the resource.
+	idKey, _ := mappingField(resource.Item, "id")
+	indent := strings.Repeat(" ", idKey.Column-1)
+	text := fmt.Sprintf("%sreadyWhen:\n%s- %s\n", indent, indent, fmt.Sprintf(condition, resource.ID))
+	edit := protocol.TextEdit{Range: lineRange(idKey.Line, 0, 0), NewText: text}
+	action := newQuickFix(fmt.Sprintf("Add readyWhen for %s %s", gvk.Kind, resource.ID), uri, []protocol.TextEdit{edit})
+	return &action
+}
+
+// addRequiredFields adds the fields required by the schema of the resource
+// that are missing from its template, one quick fix per field.
+func (cm *CodeActionManager) addRequiredFields(uri string, resource *rgdResource) []protocol.CodeAction {
+	if resource.ObjectKey == nil || resource.ObjectKey.Value != "template" {
+		return nil
+	}
+	resourceSchema := cm.schemas.resourceSchema(resource)
+	if resourceSchema == nil {
+		return nil
+	}
+
+	var actions []protocol.CodeAction
+	var visit func(node *yaml.Node, s *spec.Schema, path []string)
+	visit = func(node *yaml.Node, s *spec.Schema, path []string) {
+		if node.Kind != yaml.MappingNode || node.Style&yaml.FlowStyle != 0 || len(node.Content) < 2 {
+			return
+		}
+		for _, name := range s.Required {
+			if mappingValue(node, name) != nil {
+				continue
+			}
+			property := s.Properties[name]
+			fieldPath := append(path[:len(path):len(path)], name)
+			// The field is added right after the first field of the mapping.
+			firstKey, firstValue := node.Content[0], node.Content[1]
+			endLine, _ := nodeEnd(firstValue)
+			text := fmt.Sprintf("%s%s: %s\n", strings.Repeat(" ", firstKey.Column-1), name, placeholderValue(&property))
+			edit := protocol.TextEdit{Range: lineRange(endLine+1, 0, 0), NewText: text}
+			title := fmt.Sprintf("Add required field %s to %s", referencePath(fieldPath), resource.ID)
+			actions = append(actions, newQuickFix(title, uri, []protocol.TextEdit{edit}))
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			name := node.Content[i].Value
+			if property, ok := s.Properties[name]; ok {
+				visit(node.Content[i+1], &property, append(path[:len(path):len(path)], name))
+			}
+		}
+	}
+	visit(resource.Object, resourceSchema, nil)
+	return actions
+}
+
+// placeholderValue returns the value written for a newly added field of the
+// given schema, to be filled in.
+func placeholderValue(s *spec.Schema) string {
+	switch schemaType(s) {
+	case "boolean":
+		return "false"
+	case "integer", "number":
+		return "0"
+	case "object":
+		return "{}"
+	}
+	if strings.HasPrefix(schemaType(s), "[]") {
+		return "[]"
+	}
+	if strings.HasPrefix(schemaType(s), "map[") {
+		return "{}"
+	}
+	return `""`
+}
+
+// lowerCamelCase converts an id like my-resource or my_resource to
+// myResource.
+func lowerCamelCase(id string) string {
+	words := strings.FieldsFunc(id, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var b strings.Builder
+	for i, word := range words {
+		runes := []rune(word)
+		if i == 0 {
+			runes[0] = unicode.ToLower(runes[0])
+		} else {
+			runes[0] = unicode.ToUpper(runes[0])
+		}
+		b.WriteString(string(runes))
+	}
+	return b.String()
+}
+
+// newQuickFix creates a quick fix applying the given edits to a document.
+func newQuickFix(title, uri string, edits []protocol.TextEdit) protocol.CodeAction {
+	kind := protocol.CodeActionKindQuickFix
+	return protocol.CodeAction{
+		Title: title,
+		Kind:  &kind,
+		Edit: &protocol.WorkspaceEdit{
+			Changes: map[protocol.DocumentUri][]protocol.TextEdit{uri: edits},
+		},
+	}
+}
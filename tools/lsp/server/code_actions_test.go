@@ -0,0 +1,174 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	protocol "github.com/tliron/glsp/protocol_3_16"
+	k8sschema "k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// codeActionTitles returns the titles of the code actions.
+func codeActionTitles(actions []protocol.CodeAction) []string {
+	titles := make([]string, 0, len(actions))
+	for _, action := range actions {
+		titles = append(titles, action.Title)
+	}
+	return titles
+}
+
+// codeAction returns the code action with the given title.
+func codeAction(t *testing.T, actions []protocol.CodeAction, title string) protocol.CodeAction {
+	t.Helper()
+	for _, action := range actions {
+		if action.Title == title {
+			return action
+		}
+	}
+	require.Failf(t, "missing code action", "no code action %q in %v", title, codeActionTitles(actions))
+	return protocol.CodeAction{}
+}
+
+// codeActionEdits returns the edits of a quick fix to the test document.
+func codeActionEdits(t *testing.T, action protocol.CodeAction) []protocol.TextEdit {
+	t.Helper()
+	require.NotNil(t, action.Kind)
+	assert.Equal(t, protocol.CodeActionKindQuickFix, *action.Kind)
+	require.NotNil(t, action.Edit)
+	require.Len(t, action.Edit.Changes, 1)
+	return action.Edit.Changes[testURI]
+}
+
+func TestCodeActions_FixResourceID(t *testing.T) {
+	cm := NewCodeActionManager(logr.Discard(), nil)
+
+	content := strings.Replace(testRGDWithDependsOn, "deployment", "my-deployment", -1)
+	diagnostic := protocol.Diagnostic{Range: lineRange(12, 10, len("my-deployment")), Message: "invalid resource id"}
+	other := protocol.Diagnostic{Range: lineRange(17, 16, len("${schema.spec.name}")), Message: "other"}
+
+	actions := cm.CodeActions(testURI, content, lineRange(12, 10, 0), []protocol.Diagnostic{diagnostic, other})
+	action := codeAction(t, actions, "Rename resource my-deployment to myDeployment")
+	require.NotNil(t, action.IsPreferred)
+	assert.True(t, *action.IsPreferred)
+	assert.Equal(t, []protocol.Diagnostic{diagnostic}, action.Diagnostics)
+	assert.Equal(t, []protocol.TextEdit{
+		{Range: lineRange(12, 10, len("my-deployment")), NewText: "myDeployment"},
+		{Range: lineRange(27, 18, len("my-deployment")), NewText: "myDeployment"},
+		{Range: lineRange(22, 10, len("my-deployment")), NewText: "myDeployment"},
+	}, codeActionEdits(t, action))
+
+	// Valid ids, and ids that can't be fixed without clashing with another
+	// resource, have no fix.
+	actions = cm.CodeActions(testURI, testRGD, lineRange(12, 10, 0), nil)
+	assert.NotContains(t, strings.Join(codeActionTitles(actions), "\n"), "Rename resource")
+	content = strings.Replace(testRGD, "- id: service", "- id: Service", 1)
+	content = strings.Replace(content, "- id: deployment", "- id: service", 1)
+	actions = cm.CodeActions(testURI, content, lineRange(20, 10, 0), nil)
+	assert.NotContains(t, strings.Join(codeActionTitles(actions), "\n"), "Rename resource")
+}
+
+func TestCodeActions_AddReadyWhen(t *testing.T) {
+	cm := NewCodeActionManager(logr.Discard(), nil)
+
+	actions := cm.CodeActions(testURI, testRGD, lineRange(15, 8, 0), nil)
+	assert.Equal(t, []string{"Add readyWhen for Deployment deployment"}, codeActionTitles(actions))
+	assert.Equal(t, []protocol.TextEdit{{
+		Range: lineRange(13, 0, 0),
+		NewText: "      readyWhen:\n" +
+			"      - ${deployment.spec.replicas == deployment.status.availableReplicas}\n",
+	}}, codeActionEdits(t, actions[0]))
+
+	// Resources with a readiness condition, or of kinds without a well known
+	// one, have no readiness condition to add.
+	content := strings.Replace(testRGD, "    - id: deployment\n",
+		"    - id: deployment\n      readyWhen:\n        - ${true}\n", 1)
+	assert.Empty(t, cm.CodeActions(testURI, content, lineRange(12, 10, 0), nil))
+	assert.Empty(t, cm.CodeActions(testURI, testRGD, lineRange(20, 10, 0), nil))
+}
+
+func TestCodeActions_AddRequiredFields(t *testing.T) {
+	schemaResolver := newTestSchemaResolver()
+	deploymentSchema, err := schemaResolver.ResolveSchema(
+		k8sschema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"})
+	require.NoError(t, err)
+	deploymentSchema.Required = []string{"apiVersion", "kind", "metadata", "spec"}
+	deploymentSpec := deploymentSchema.Properties["spec"]
+	deploymentSpec.Required = []string{"replicas", "paused"}
+	deploymentSchema.Properties["spec"] = deploymentSpec
+	cm := NewCodeActionManager(logr.Discard(), schemaResolver)
+
+	actions := cm.CodeActions(testURI, testRGD, lineRange(12, 10, 0), nil)
+	assert.Equal(t, []string{
+		"Add readyWhen for Deployment deployment",
+		"Add required field spec.paused to deployment",
+	}, codeActionTitles(actions))
+	assert.Equal(t, []protocol.TextEdit{
+		{Range: lineRange(20, 0, 0), NewText: "          paused: false\n"},
+	}, codeActionEdits(t, actions[1]))
+
+	// Without a schema resolver, the required fields aren't looked for.
+	cm = NewCodeActionManager(logr.Discard(), nil)
+	actions = cm.CodeActions(testURI, testRGD, lineRange(12, 10, 0), nil)
+	assert.Equal(t, []string{"Add readyWhen for Deployment deployment"}, codeActionTitles(actions))
+}
+
+func TestCodeActions_Nothing(t *testing.T) {
+	cm := NewCodeActionManager(logr.Discard(), newTestSchemaResolver())
+
+	tests := []struct {
+		name    string
+		content string
+		line    uint32
+	}{
+		{
+			name:    "outside resources",
+			content: testRGD,
+			line:    9,
+		},
+		{
+			name:    "invalid YAML",
+			content: testRGD + "  [invalid\n",
+			line:    12,
+		},
+		{
+			name:    "other kind",
+			content: strings.Replace(testRGD, "kind: ResourceGraphDefinition", "kind: ConfigMap", 1),
+			line:    12,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Empty(t, cm.CodeActions(testURI, tt.content, lineRange(int(tt.line), 0, 0), nil))
+		})
+	}
+}
+
+func TestLowerCamelCase(t *testing.T) {
+	tests := map[string]string{
+		"my-resource":   "myResource",
+		"my_resource":   "myResource",
+		"MyResource":    "myResource",
+		"my-db-cluster": "myDbCluster",
+		"resource":      "resource",
+	}
+	for id, want := range tests {
+		assert.Equal(t, want, lowerCamelCase(id), id)
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"strings"
+
+	"github.com/go-logr/logr"
+	protocol "github.com/tliron/glsp/protocol_3_16"
+	"k8s.io/apiserver/pkg/cel/openapi/resolver"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// CompletionManager provides the completion items of KRO ResourceGraphDefinition
+// documents. Inside expressions, it completes the resource ids, the fields of
+// the instance spec and the fields of the resources. Outside expressions, it
+// completes the fields of the resource templates.
+type CompletionManager struct {
+	logger logr.Logger
+	// schemas looks up the schemas of the instance and of the resources.
+	// Without a schema resolver, only the resource ids and the fields of the
+	// instance are completed.
+	schemas *schemaLookup
+}
+
+// NewCompletionManager creates a new completion manager. The schema resolver
+// can be nil when there is nothing to resolve the resource schemas from.
+func NewCompletionManager(logger logr.Logger, schemaResolver resolver.SchemaResolver) *CompletionManager {
+	return &CompletionManager{
+		logger:  logger,
+		schemas: newSchemaLookup(logger, schemaResolver),
+	}
+}
+
+// Complete returns the completion items at the given position of a document.
+func (cm *CompletionManager) Complete(content string, position protocol.Position) []protocol.CompletionItem {
+	line, character := int(position.Line), int(position.Character)
+	doc := parseRGDDocument(content, line)
+	lineText := doc.lineText(line)
+
+	if expression, ok := expressionAt(lineText, character); ok {
+		return cm.completeExpression(doc, expression)
+	}
+	return cm.completeTemplateField(doc, line, lineText[:min(character, len(lineText))])
+}
+
+// completeExpression completes the reference being typed at the end of the
+// expression: the variables, or the fields of the variable it refers to.
+func (cm *CompletionManager) completeExpression(doc *rgdDocument, expression string) []protocol.CompletionItem {
+	reference := trailingReference(expression)
+	if len(reference) <= 1 {
+		items := []protocol.CompletionItem{
+			newCompletionItem(schemaVariable, protocol.CompletionItemKindVariable, "instance", ""),
+		}
+		for _, resource := range doc.resources() {
+			if resource.ID == "" {
+				continue
+			}
+			detail := ""
+			if gvk, ok := resource.GVK(); ok {
+				detail = gvk.String()
+			}
+			items = append(items, newCompletionItem(resource.ID, protocol.CompletionItemKindVariable, detail, ""))
+		}
+		return items
+	}
+
+	variableSchema := cm.schemas.variableSchema(doc, reference[0])
+	return fieldCompletionItems(schemaAt(variableSchema, reference[1:len(reference)-1]), "")
+}
+
+// completeTemplateField completes the key being typed in a resource template,
+// prefix being the text of the line up to the position.
+func (cm *CompletionManager) completeTemplateField(
+	doc *rgdDocument,
+	line int,
+	prefix string,
+) []protocol.CompletionItem {
+	// Only keys are completed.
+	if strings.Contains(prefix, ":") {
+		return nil
+	}
+	resource, keys := doc.templateParentKeys(line, prefix+"x")
+	if resource == nil {
+		return nil
+	}
+	return fieldCompletionItems(schemaAt(cm.schemas.resourceSchema(resource), keys), ": ")
+}
+
+// fieldCompletionItems returns the completion items of the properties of s.
+// The insert suffix is appended to the inserted property names.
+func fieldCompletionItems(s *spec.Schema, insertSuffix string) []protocol.CompletionItem {
+	names := propertyNames(s)
+	items := make([]protocol.CompletionItem, 0, len(names))
+	for _, name := range names {
+		property := s.Properties[name]
+		item := newCompletionItem(name, protocol.CompletionItemKindField, schemaType(&property), property.Description)
+		if insertSuffix != "" {
+			item.InsertText = stringPtr(name + insertSuffix)
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// newCompletionItem creates a completion item with the given label, kind,
+// detail and documentation. Empty details and documentation are omitted.
+func newCompletionItem(
+	label string,
+	kind protocol.CompletionItemKind,
+	detail, documentation string,
+) protocol.CompletionItem {
+	item := protocol.CompletionItem{
+		Label: label,
+		Kind:  &kind,
+	}
+	if detail != "" {
+		item.Detail = stringPtr(detail)
+	}
+	if documentation != "" {
+		item.Documentation = documentation
+	}
+	return item
+}
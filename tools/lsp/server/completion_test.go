@@ -0,0 +1,369 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	protocol "github.com/tliron/glsp/protocol_3_16"
+	k8sschema "k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+
+	schemaresolver "github.com/kubernetes-sigs/kro/pkg/graph/schema/resolver"
+	"github.com/kubernetes-sigs/kro/pkg/testutil/k8s"
+)
+
+// testRGD is the ResourceGraphDefinition document of the language feature
+// tests.
+const testRGD = `apiVersion: kro.run/v1alpha1
+kind: ResourceGraphDefinition
+metadata:
+  name: webapp
+spec:
+  schema:
+    apiVersion: v1alpha1
+    kind: WebApp
+    spec:
+      name: string
+      replicas: integer | default=1
+  resources:
+    - id: deployment
+      template:
+        apiVersion: apps/v1
+        kind: Deployment
+        metadata:
+          name: ${schema.spec.name}
+        spec:
+          replicas: ${schema.spec.replicas}
+    - id: service
+      template:
+        apiVersion: v1
+        kind: Service
+        metadata:
+          name: ${deployment.metadata.name}
+        spec:
+          ports:
+            - port: 80
+`
+
+// cursor is the marker of the position of the tests in the documents.
+const cursor = "<|>"
+
+// withCursor returns the document without its cursor marker, and the position
+// of the marker.
+func withCursor(t *testing.T, content string) (string, protocol.Position) {
+	t.Helper()
+	index := strings.Index(content, cursor)
+	require.GreaterOrEqual(t, index, 0, "missing cursor in document")
+	before := content[:index]
+	line := strings.Count(before, "\n")
+	character := len(before) - strings.LastIndex(before, "\n") - 1
+	return before + content[index+len(cursor):], protocol.Position{
+		Line:      protocol.UInteger(line),
+		Character: protocol.UInteger(character),
+	}
+}
+
+// newTestSchemaResolver returns a resolver of the schemas of the Deployments
+// and Services of testRGD.
+func newTestSchemaResolver() *k8s.FakeResolver {
+	schemaResolver, _ := k8s.NewFakeResolver()
+
+	replicas := spec.Int32Property()
+	replicas.Description = "Number of desired pods."
+	deployment := &spec.Schema{}
+	deployment.Properties = map[string]spec.Schema{
+		"apiVersion": *spec.StringProperty(),
+		"kind":       *spec.StringProperty(),
+		"metadata":   *schemaresolver.ObjectMetaSchema(),
+		"spec": {SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"replicas": *replicas,
+				"paused":   *spec.BooleanProperty(),
+			},
+		}},
+		"status": {SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"availableReplicas": *spec.Int32Property(),
+			},
+		}},
+	}
+	schemaResolver.AddSchema(k8sschema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, deployment)
+
+	port := &spec.Schema{}
+	port.Type = []string{"object"}
+	port.Properties = map[string]spec.Schema{
+		"port":     *spec.Int32Property(),
+		"protocol": *spec.StringProperty(),
+	}
+	service := &spec.Schema{}
+	service.Properties = map[string]spec.Schema{
+		"apiVersion": *spec.StringProperty(),
+		"kind":       *spec.StringProperty(),
+		"metadata":   *schemaresolver.ObjectMetaSchema(),
+		"spec": {SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"ports":     *spec.ArrayProperty(port),
+				"clusterIP": *spec.StringProperty(),
+			},
+		}},
+	}
+	schemaResolver.AddSchema(k8sschema.GroupVersionKind{Version: "v1", Kind: "Service"}, service)
+	return schemaResolver
+}
+
+// completionLabels returns the labels of the completion items.
+func completionLabels(items []protocol.CompletionItem) []string {
+	labels := make([]string, 0, len(items))
+	for _, item := range items {
+		labels = append(labels, item.Label)
+	}
+	return labels
+}
+
+// completionItem returns the completion item with the given label.
+func completionItem(t *testing.T, items []protocol.CompletionItem, label string) protocol.CompletionItem {
+	t.Helper()
+	for _, item := range items {
+		if item.Label == label {
+			return item
+		}
+	}
+	require.Failf(t, "missing completion item", "no %q in %v", label, completionLabels(items))
+	return protocol.CompletionItem{}
+}
+
+func TestComplete_Expressions(t *testing.T) {
+	cm := NewCompletionManager(logr.Discard(), newTestSchemaResolver())
+
+	tests := []struct {
+		name string
+		// edit replaces the given text of testRGD, which holds the cursor.
+		old, new string
+		want     []string
+	}{
+		{
+			name: "variables",
+			old:  "name: ${deployment.metadata.name}",
+			new:  "name: ${<|>",
+			want: []string{"schema", "deployment", "service"},
+		},
+		{
+			name: "variables being typed",
+			old:  "name: ${deployment.metadata.name}",
+			new:  "name: ${dep<|>",
+			want: []string{"schema", "deployment", "service"},
+		},
+		{
+			name: "instance fields",
+			old:  "name: ${schema.spec.name}",
+			new:  "name: ${schema.<|>",
+			want: []string{"apiVersion", "kind", "metadata", "spec"},
+		},
+		{
+			name: "instance spec fields",
+			old:  "name: ${schema.spec.name}",
+			new:  "name: ${schema.spec.<|>",
+			want: []string{"name", "replicas"},
+		},
+		{
+			name: "resource fields",
+			old:  "name: ${deployment.metadata.name}",
+			new:  "name: ${deployment.status.<|>",
+			want: []string{"availableReplicas"},
+		},
+		{
+			name: "fields after another expression of the line",
+			old:  "name: ${deployment.metadata.name}",
+			new:  "name: ${schema.spec.name}-${deployment.spec.<|>",
+			want: []string{"paused", "replicas"},
+		},
+		{
+			name: "list item fields",
+			old:  "name: ${deployment.metadata.name}",
+			new:  "name: ${service.spec.ports[0].<|>",
+			want: []string{"port", "protocol"},
+		},
+		{
+			name: "unknown variable",
+			old:  "name: ${deployment.metadata.name}",
+			new:  "name: ${unknown.<|>",
+			want: []string{},
+		},
+		{
+			name: "unknown field",
+			old:  "name: ${deployment.metadata.name}",
+			new:  "name: ${deployment.unknown.<|>",
+			want: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, position := withCursor(t, strings.Replace(testRGD, tt.old, tt.new, 1))
+			assert.Equal(t, tt.want, completionLabels(cm.Complete(content, position)))
+		})
+	}
+}
+
+func TestComplete_ExpressionItems(t *testing.T) {
+	cm := NewCompletionManager(logr.Discard(), newTestSchemaResolver())
+
+	content, position := withCursor(t, strings.Replace(testRGD,
+		"name: ${deployment.metadata.name}", "name: ${<|>", 1))
+	items := cm.Complete(content, position)
+	deployment := completionItem(t, items, "deployment")
+	require.NotNil(t, deployment.Detail)
+	assert.Equal(t, "apps/v1, Kind=Deployment", *deployment.Detail)
+	assert.Equal(t, protocol.CompletionItemKindVariable, *deployment.Kind)
+
+	content, position = withCursor(t, strings.Replace(testRGD,
+		"name: ${deployment.metadata.name}", "name: ${deployment.spec.<|>", 1))
+	items = cm.Complete(content, position)
+	replicas := completionItem(t, items, "replicas")
+	require.NotNil(t, replicas.Detail)
+	assert.Equal(t, "integer", *replicas.Detail)
+	assert.Equal(t, "Number of desired pods.", replicas.Documentation)
+	assert.Equal(t, protocol.CompletionItemKindField, *replicas.Kind)
+	// Fields are inserted as is in expressions.
+	assert.Nil(t, replicas.InsertText)
+}
+
+func TestComplete_TemplateFields(t *testing.T) {
+	cm := NewCompletionManager(logr.Discard(), newTestSchemaResolver())
+
+	tests := []struct {
+		name     string
+		old, new string
+		want     []string
+	}{
+		{
+			name: "top level fields",
+			old:  "        kind: Service\n",
+			new:  "        kind: Service\n        <|>\n",
+			want: []string{"apiVersion", "kind", "metadata", "spec"},
+		},
+		{
+			name: "nested fields",
+			old:  "          ports:\n",
+			new:  "          <|>\n          ports:\n",
+			want: []string{"clusterIP", "ports"},
+		},
+		{
+			name: "fields being typed",
+			old:  "          ports:\n",
+			new:  "          clu<|>\n          ports:\n",
+			want: []string{"clusterIP", "ports"},
+		},
+		{
+			name: "first field of a list item",
+			old:  "            - port: 80\n",
+			new:  "            - <|>\n",
+			want: []string{"port", "protocol"},
+		},
+		{
+			name: "next field of a list item",
+			old:  "            - port: 80\n",
+			new:  "            - port: 80\n              <|>\n",
+			want: []string{"port", "protocol"},
+		},
+		{
+			name: "fields of another resource",
+			old:  "          replicas: ${schema.spec.replicas}\n",
+			new:  "          replicas: ${schema.spec.replicas}\n          <|>\n",
+			want: []string{"paused", "replicas"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, position := withCursor(t, strings.Replace(testRGD, tt.old, tt.new, 1))
+			assert.Equal(t, tt.want, completionLabels(cm.Complete(content, position)))
+		})
+	}
+
+	t.Run("keys are inserted with their separator", func(t *testing.T) {
+		content, position := withCursor(t, strings.Replace(testRGD,
+			"          ports:\n", "          <|>\n          ports:\n", 1))
+		items := cm.Complete(content, position)
+		ports := completionItem(t, items, "ports")
+		require.NotNil(t, ports.InsertText)
+		assert.Equal(t, "ports: ", *ports.InsertText)
+		require.NotNil(t, ports.Detail)
+		assert.Equal(t, "[]object", *ports.Detail)
+	})
+}
+
+func TestComplete_NoCompletion(t *testing.T) {
+	cm := NewCompletionManager(logr.Discard(), newTestSchemaResolver())
+
+	tests := []struct {
+		name     string
+		old, new string
+	}{
+		{
+			name: "values",
+			old:  "kind: Service",
+			new:  "kind: Serv<|>",
+		},
+		{
+			name: "outside templates",
+			old:  "    - id: service\n",
+			new:  "    - id: service\n      <|>\n",
+		},
+		{
+			name: "after a closed expression",
+			old:  "name: ${deployment.metadata.name}",
+			new:  "name: ${deployment.metadata.name}<|>",
+		},
+		{
+			name: "instance schema",
+			old:  "      name: string\n",
+			new:  "      name: string\n      <|>\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, position := withCursor(t, strings.Replace(testRGD, tt.old, tt.new, 1))
+			assert.Empty(t, cm.Complete(content, position))
+		})
+	}
+}
+
+func TestComplete_WithoutSchemaResolver(t *testing.T) {
+	cm := NewCompletionManager(logr.Discard(), nil)
+
+	// The resource ids and the fields of the instance are still completed.
+	content, position := withCursor(t, strings.Replace(testRGD,
+		"name: ${deployment.metadata.name}", "name: ${<|>", 1))
+	assert.Equal(t, []string{"schema", "deployment", "service"}, completionLabels(cm.Complete(content, position)))
+
+	content, position = withCursor(t, strings.Replace(testRGD,
+		"name: ${deployment.metadata.name}", "name: ${schema.spec.<|>", 1))
+	assert.Equal(t, []string{"name", "replicas"}, completionLabels(cm.Complete(content, position)))
+
+	content, position = withCursor(t, strings.Replace(testRGD,
+		"name: ${deployment.metadata.name}", "name: ${deployment.spec.<|>", 1))
+	assert.Empty(t, cm.Complete(content, position))
+
+	content, position = withCursor(t, strings.Replace(testRGD,
+		"          ports:\n", "          <|>\n          ports:\n", 1))
+	assert.Empty(t, cm.Complete(content, position))
+}
@@ -19,6 +19,7 @@ import (
 
 	"github.com/go-logr/logr"
 	protocol "github.com/tliron/glsp/protocol_3_16"
+	"k8s.io/apiserver/pkg/cel/openapi/resolver"
 	"k8s.io/client-go/rest"
 )
 
@@ -47,26 +48,44 @@ type DocumentManager struct {
 	mutex              sync.RWMutex
 	notificationSender NotificationSender
 	validationManager  *ValidationManager
+	completionManager  *CompletionManager
+	hoverManager       *HoverManager
+	navigationManager  *NavigationManager
+	symbolManager      *SymbolManager
+	codeActionManager  *CodeActionManager
 }
 
 // NewDocumentManager creates a new document manager with validation capabilities.
 // The validation manager is created based on the provided client configuration:
 // - If clientConfig is valid: enables online validation with cluster connectivity
-// - If clientConfig is nil/invalid: enables offline validation mode
+// - If clientConfig is nil/invalid: enables offline validation mode, against the CRDs in crdPaths
 // - If validation manager creation fails: operates without validation
-func NewDocumentManager(logger logr.Logger, clientConfig *rest.Config) *DocumentManager {
+func NewDocumentManager(logger logr.Logger, clientConfig *rest.Config, crdPaths []string) *DocumentManager {
 	// Attempt to create validation manager with the provided configuration
-	validationManager, err := NewValidationManager(logger, clientConfig)
+	validationManager, err := NewValidationManager(logger, clientConfig, crdPaths)
 	if err != nil {
 		// Log error but continue without validation rather than failing entirely
 		logger.Error(err, "Failed to create validation manager")
 		validationManager = nil // Graceful degradation - no validation available
 	}
 
+	// Completion, hover and code actions rely on the schema resolver of the
+	// validation manager, when there is one, to describe the fields of the
+	// resources.
+	var schemaResolver resolver.SchemaResolver
+	if validationManager != nil {
+		schemaResolver = validationManager.SchemaResolver()
+	}
+
 	return &DocumentManager{
 		logger:            logger,
 		documents:         make(map[string]*Document),
 		validationManager: validationManager,
+		completionManager: NewCompletionManager(logger, schemaResolver),
+		hoverManager:      NewHoverManager(logger, schemaResolver),
+		navigationManager: NewNavigationManager(logger),
+		symbolManager:     NewSymbolManager(logger),
+		codeActionManager: NewCodeActionManager(logger, schemaResolver),
 	}
 }
 
@@ -145,6 +164,90 @@ func (dm *DocumentManager) GetDocument(uri string) (*Document, bool) {
 	return doc, exists
 }
 
+// Complete handles the LSP textDocument/completion request, returning the
+// completion items at the given position of the document.
+func (dm *DocumentManager) Complete(uri string, position protocol.Position) []protocol.CompletionItem {
+	doc, exists := dm.GetDocument(uri)
+	if !exists {
+		return []protocol.CompletionItem{}
+	}
+	return dm.completionManager.Complete(doc.Content, position)
+}
+
+// Hover handles the LSP textDocument/hover request, returning the hover
+// information at the given position of the document, or nil.
+func (dm *DocumentManager) Hover(uri string, position protocol.Position) *protocol.Hover {
+	doc, exists := dm.GetDocument(uri)
+	if !exists {
+		return nil
+	}
+	return dm.hoverManager.Hover(doc.Content, position)
+}
+
+// Definition handles the LSP textDocument/definition request, returning the
+// location of the definition of the variable at the given position of the
+// document, or nil.
+func (dm *DocumentManager) Definition(uri string, position protocol.Position) *protocol.Location {
+	doc, exists := dm.GetDocument(uri)
+	if !exists {
+		return nil
+	}
+	return dm.navigationManager.Definition(uri, doc.Content, position)
+}
+
+// References handles the LSP textDocument/references request, returning the
+// locations of the expressions reading the variable at the given position of
+// the document.
+func (dm *DocumentManager) References(
+	uri string,
+	position protocol.Position,
+	includeDeclaration bool,
+) []protocol.Location {
+	doc, exists := dm.GetDocument(uri)
+	if !exists {
+		return []protocol.Location{}
+	}
+	return dm.navigationManager.References(uri, doc.Content, position, includeDeclaration)
+}
+
+// Rename handles the LSP textDocument/rename request, returning the edits
+// renaming the resource at the given position of the document.
+func (dm *DocumentManager) Rename(
+	uri string,
+	position protocol.Position,
+	newName string,
+) (*protocol.WorkspaceEdit, error) {
+	doc, exists := dm.GetDocument(uri)
+	if !exists {
+		return nil, nil
+	}
+	return dm.navigationManager.Rename(uri, doc.Content, position, newName)
+}
+
+// DocumentSymbols handles the LSP textDocument/documentSymbol request,
+// returning the outline of the document.
+func (dm *DocumentManager) DocumentSymbols(uri string) []protocol.DocumentSymbol {
+	doc, exists := dm.GetDocument(uri)
+	if !exists {
+		return []protocol.DocumentSymbol{}
+	}
+	return dm.symbolManager.DocumentSymbols(doc.Content)
+}
+
+// CodeActions handles the LSP textDocument/codeAction request, returning the
+// quick fixes available in the given range of the document.
+func (dm *DocumentManager) CodeActions(
+	uri string,
+	actionRange protocol.Range,
+	diagnostics []protocol.Diagnostic,
+) []protocol.CodeAction {
+	doc, exists := dm.GetDocument(uri)
+	if !exists {
+		return []protocol.CodeAction{}
+	}
+	return dm.codeActionManager.CodeActions(uri, doc.Content, actionRange, diagnostics)
+}
+
 // validateAndPublishDiagnostics performs validation on a document and sends results to the client.
 // This is the core method that orchestrates validation and diagnostic reporting.
 // It handles the complete pipeline from document retrieval to client notification.
@@ -0,0 +1,217 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/google/cel-go/cel"
+	krocel "github.com/kubernetes-sigs/kro/pkg/cel"
+	"github.com/kubernetes-sigs/kro/pkg/cel/ast"
+	"github.com/kubernetes-sigs/kro/pkg/graph/emulator"
+	protocol "github.com/tliron/glsp/protocol_3_16"
+	"k8s.io/apiserver/pkg/cel/openapi/resolver"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// HoverManager provides the hover information of KRO ResourceGraphDefinition
+// documents: the GroupVersionKind of the resources, the type and description
+// of the fields, and the type of the expressions.
+type HoverManager struct {
+	logger logr.Logger
+	// schemas looks up the schemas of the instance and of the resources.
+	// Without a schema resolver, only the fields of the instance are described.
+	schemas *schemaLookup
+	// resourceEmulator generates dummy resources to evaluate the expressions
+	// against, the same way the graph builder does to check them.
+	resourceEmulator *emulator.Emulator
+}
+
+// NewHoverManager creates a new hover manager. The schema resolver can be nil
+// when there is nothing to resolve the resource schemas from.
+func NewHoverManager(logger logr.Logger, schemaResolver resolver.SchemaResolver) *HoverManager {
+	return &HoverManager{
+		logger:           logger,
+		schemas:          newSchemaLookup(logger, schemaResolver),
+		resourceEmulator: emulator.NewEmulator(),
+	}
+}
+
+// Hover returns the hover information at the given position of a document, or
+// nil if there is nothing to describe there.
+func (hm *HoverManager) Hover(content string, position protocol.Position) *protocol.Hover {
+	line, character := int(position.Line), int(position.Character)
+	doc := parseRGDDocument(content, line)
+	lineText := doc.lineText(line)
+
+	if expression, ok := lineExpressionAt(lineText, character); ok {
+		return hm.hoverExpression(doc, line, lineText, character, expression)
+	}
+	if resource := resourceOfIDAt(doc, line, character); resource != nil {
+		return newHover(resourceDescription(resource), nodeRange(resource.IDNode))
+	}
+	return hm.hoverTemplateField(doc, line, lineText, character)
+}
+
+// hoverExpression describes the reference under the character, if any, and
+// the type of the expression.
+func (hm *HoverManager) hoverExpression(
+	doc *rgdDocument,
+	line int,
+	lineText string,
+	character int,
+	expression lineExpression,
+) *protocol.Hover {
+	var sections []string
+	hoverRange := lineRange(line, expression.Start, expression.End-expression.Start)
+
+	if reference, start, end := referenceAt(lineText, character); reference != nil {
+		switch {
+		case len(reference) > 1:
+			variableSchema := hm.schemas.variableSchema(doc, reference[0])
+			if fieldSchema := schemaAt(variableSchema, reference[1:]); fieldSchema != nil {
+				sections = append(sections, fieldDescription(referencePath(reference), fieldSchema))
+			}
+		case reference[0] == schemaVariable:
+			sections = append(sections, fmt.Sprintf("**%s**: instance `%s`", schemaVariable, doc.instanceGVK()))
+		case doc.resource(reference[0]) != nil:
+			sections = append(sections, resourceDescription(doc.resource(reference[0])))
+		}
+		if len(sections) > 0 {
+			hoverRange = lineRange(line, start, end-start)
+		}
+	}
+
+	if expressionType := hm.expressionType(doc, expression.Text); expressionType != "" {
+		sections = append(sections, fmt.Sprintf("Expression type: `%s`", expressionType))
+	}
+	if len(sections) == 0 {
+		return nil
+	}
+	return newHover(strings.Join(sections, "\n\n---\n\n"), hoverRange)
+}
+
+// hoverTemplateField describes the template field whose key is under the
+// character, if any.
+func (hm *HoverManager) hoverTemplateField(doc *rgdDocument, line int, lineText string, character int) *protocol.Hover {
+	colon := strings.Index(lineText, ":")
+	if colon < 0 || character >= colon {
+		return nil
+	}
+	resource, keys := doc.templateParentKeys(line, lineText)
+	if resource == nil {
+		return nil
+	}
+	_, indent, key := splitYAMLLine(lineText)
+	path := append(keys, key)
+	fieldSchema := schemaAt(hm.schemas.resourceSchema(resource), path)
+	if fieldSchema == nil {
+		return nil
+	}
+	return newHover(fieldDescription(referencePath(path), fieldSchema), lineRange(line, indent, colon-indent))
+}
+
+// expressionType returns the type of the expression, or an empty string if it
+// can't be inferred. As the variables are untyped, the expression is evaluated
+// against dummy resources generated from the schemas of the variables it
+// reads.
+func (hm *HoverManager) expressionType(doc *rgdDocument, expression string) string {
+	variables := append(doc.resourceIDs(), schemaVariable)
+	env, err := krocel.DefaultEnvironment(krocel.WithResourceIDs(variables), krocel.WithConfig())
+	if err != nil {
+		return ""
+	}
+	checkedAST, issues := env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return ""
+	}
+	if outputType := checkedAST.OutputType(); outputType != cel.DynType {
+		return outputType.String()
+	}
+
+	inspection, err := ast.NewInspectorWithEnv(env, variables).Inspect(expression)
+	if err != nil {
+		return ""
+	}
+	activation := map[string]interface{}{}
+	for _, dependency := range inspection.ResourceDependencies {
+		if _, ok := activation[dependency.ID]; ok {
+			continue
+		}
+		gvk := doc.instanceGVK()
+		if resource := doc.resource(dependency.ID); resource != nil {
+			gvk, _ = resource.GVK()
+		}
+		emulated, err := hm.resourceEmulator.GenerateDummyCR(gvk, hm.schemas.variableSchema(doc, dependency.ID))
+		if err != nil {
+			return ""
+		}
+		activation[dependency.ID] = emulated.Object
+	}
+
+	program, err := env.Program(checkedAST)
+	if err != nil {
+		return ""
+	}
+	output, _, err := program.Eval(activation)
+	if err != nil {
+		hm.logger.V(1).Info("Failed to evaluate expression", "expression", expression, "error", err)
+		return ""
+	}
+	return output.Type().TypeName()
+}
+
+// resourceOfIDAt returns the resource whose id is under the given character,
+// or nil.
+func resourceOfIDAt(doc *rgdDocument, line, character int) *rgdResource {
+	for _, resource := range doc.resources() {
+		if resource.IDNode != nil && nodeContains(resource.IDNode, line, character) {
+			return resource
+		}
+	}
+	return nil
+}
+
+// resourceDescription describes the resource by its id and GroupVersionKind.
+func resourceDescription(resource *rgdResource) string {
+	gvk, ok := resource.GVK()
+	if !ok {
+		return fmt.Sprintf("**%s**: resource", resource.ID)
+	}
+	return fmt.Sprintf("**%s**: `%s`", resource.ID, gvk)
+}
+
+// fieldDescription describes the field at the given path by its type and
+// description.
+func fieldDescription(path string, s *spec.Schema) string {
+	description := fmt.Sprintf("`%s`: `%s`", path, schemaType(s))
+	if s.Description != "" {
+		description += "\n\n" + s.Description
+	}
+	return description
+}
+
+// newHover creates a hover holding the given markdown content.
+func newHover(content string, hoverRange protocol.Range) *protocol.Hover {
+	return &protocol.Hover{
+		Contents: protocol.MarkupContent{
+			Kind:  protocol.MarkupKindMarkdown,
+			Value: content,
+		},
+		Range: &hoverRange,
+	}
+}
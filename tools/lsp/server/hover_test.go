@@ -0,0 +1,165 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	protocol "github.com/tliron/glsp/protocol_3_16"
+)
+
+func TestHover(t *testing.T) {
+	hm := NewHoverManager(logr.Discard(), newTestSchemaResolver())
+
+	tests := []struct {
+		name string
+		// old is replaced by new in testRGD, which holds the cursor.
+		old, new  string
+		want      string
+		wantRange protocol.Range
+	}{
+		{
+			name:      "resource field in an expression",
+			old:       "name: ${deployment.metadata.name}",
+			new:       "name: ${string(deployment.sta<|>tus.availableReplicas)}",
+			want:      "`deployment.status`: `object`\n\n---\n\nExpression type: `string`",
+			wantRange: lineRange(25, 25, len("deployment.status")),
+		},
+		{
+			name:      "field description in an expression",
+			old:       "name: ${deployment.metadata.name}",
+			new:       "name: ${string(deployment.spec.rep<|>licas)}",
+			want:      "`deployment.spec.replicas`: `integer`\n\nNumber of desired pods.\n\n---\n\nExpression type: `string`",
+			wantRange: lineRange(25, 25, len("deployment.spec.replicas")),
+		},
+		{
+			name:      "instance field in an expression",
+			old:       "replicas: ${schema.spec.replicas}",
+			new:       "replicas: ${schema.spec.repl<|>icas}",
+			want:      "`schema.spec.replicas`: `integer`\n\n---\n\nExpression type: `int`",
+			wantRange: lineRange(19, 22, len("schema.spec.replicas")),
+		},
+		{
+			name:      "instance variable",
+			old:       "name: ${schema.spec.name}",
+			new:       "name: ${sch<|>ema.spec.name}",
+			want:      "**schema**: instance `kro.run/v1alpha1, Kind=WebApp`\n\n---\n\nExpression type: `string`",
+			wantRange: lineRange(17, 18, len("schema")),
+		},
+		{
+			name:      "resource variable",
+			old:       "name: ${deployment.metadata.name}",
+			new:       "name: ${deploy<|>ment.metadata.name}",
+			want:      "**deployment**: `apps/v1, Kind=Deployment`\n\n---\n\nExpression type: `string`",
+			wantRange: lineRange(25, 18, len("deployment")),
+		},
+		{
+			name:      "expression type only",
+			old:       "name: ${deployment.metadata.name}",
+			new:       "name: <|>${deployment.metadata.name}",
+			want:      "Expression type: `string`",
+			wantRange: lineRange(25, 16, len("${deployment.metadata.name}")),
+		},
+		{
+			name:      "resource id",
+			old:       "- id: deployment",
+			new:       "- id: deploy<|>ment",
+			want:      "**deployment**: `apps/v1, Kind=Deployment`",
+			wantRange: lineRange(12, 10, len("deployment")),
+		},
+		{
+			name:      "template field",
+			old:       "replicas: ${schema.spec.replicas}",
+			new:       "repl<|>icas: ${schema.spec.replicas}",
+			want:      "`spec.replicas`: `integer`\n\nNumber of desired pods.",
+			wantRange: lineRange(19, 10, len("replicas")),
+		},
+		{
+			name:      "template list item field",
+			old:       "- port: 80",
+			new:       "- po<|>rt: 80",
+			want:      "`spec.ports[].port`: `integer`",
+			wantRange: lineRange(28, 14, len("port")),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, position := withCursor(t, strings.Replace(testRGD, tt.old, tt.new, 1))
+			hover := hm.Hover(content, position)
+			require.NotNil(t, hover)
+			contents, ok := hover.Contents.(protocol.MarkupContent)
+			require.True(t, ok)
+			assert.Equal(t, protocol.MarkupKindMarkdown, contents.Kind)
+			assert.Equal(t, tt.want, contents.Value)
+			require.NotNil(t, hover.Range)
+			assert.Equal(t, tt.wantRange, *hover.Range)
+		})
+	}
+}
+
+func TestHover_Nothing(t *testing.T) {
+	hm := NewHoverManager(logr.Discard(), newTestSchemaResolver())
+
+	tests := []struct {
+		name     string
+		old, new string
+	}{
+		{
+			name: "outside templates",
+			old:  "kind: ResourceGraphDefinition",
+			new:  "ki<|>nd: ResourceGraphDefinition",
+		},
+		{
+			name: "template value",
+			old:  "kind: Service",
+			new:  "kind: Serv<|>ice",
+		},
+		{
+			name: "unknown template field",
+			old:  "- port: 80",
+			new:  "- port: 80\n              unkn<|>own: true",
+		},
+		{
+			name: "invalid expression",
+			old:  "name: ${deployment.metadata.name}",
+			new:  "name: ${unkn<|>own.metadata.name}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, position := withCursor(t, strings.Replace(testRGD, tt.old, tt.new, 1))
+			assert.Nil(t, hm.Hover(content, position))
+		})
+	}
+}
+
+func TestHover_WithoutSchemaResolver(t *testing.T) {
+	hm := NewHoverManager(logr.Discard(), nil)
+
+	// The fields of the instance are still described.
+	content, position := withCursor(t, strings.Replace(testRGD,
+		"replicas: ${schema.spec.replicas}", "replicas: ${schema.spec.repl<|>icas}", 1))
+	hover := hm.Hover(content, position)
+	require.NotNil(t, hover)
+	assert.Contains(t, hover.Contents.(protocol.MarkupContent).Value, "`schema.spec.replicas`: `integer`")
+
+	content, position = withCursor(t, strings.Replace(testRGD,
+		"replicas: ${schema.spec.replicas}", "repl<|>icas: ${schema.spec.replicas}", 1))
+	assert.Nil(t, hm.Hover(content, position))
+}
@@ -15,7 +15,9 @@
 package main
 
 import (
+	"flag"
 	"os"
+	"strings"
 
 	"github.com/go-logr/logr"
 	"github.com/go-logr/zapr"
@@ -29,6 +31,18 @@ var (
 	lsName = "kro-language-server"
 )
 
+// stringSliceFlag is a flag that can be repeated, each value being appended.
+type stringSliceFlag []string
+
+func (f *stringSliceFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *stringSliceFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
 // getKubernetesConfig attempts to create a Kubernetes client configuration
 // by trying different configuration sources in order of preference.
 // Returns nil if no valid configuration can be found.
@@ -49,6 +63,11 @@ func getKubernetesConfig(log logr.Logger) *rest.Config {
 }
 
 func main() {
+	var crdPaths stringSliceFlag
+	flag.Var(&crdPaths, "crd-path",
+		"File or directory of CRD manifests to validate against in offline mode (can be repeated)")
+	flag.Parse()
+
 	// Initialize structured logging with Zap logger in development mode
 	zapLogger, _ := zap.NewDevelopment()
 	log := zapr.NewLogger(zapLogger)
@@ -56,14 +75,16 @@ func main() {
 	log.Info("Starting server", "name", lsName, "version", getVersion())
 
 	// Attempt to get Kubernetes cluster configuration for online validation
-	// If this returns nil, the LSP will operate in offline mode (basic validation only)
+	// If this returns nil, the LSP will operate in offline mode, validating
+	// against the built-in types and the CRDs found in the --crd-path files
 	clientConfig := getKubernetesConfig(log)
 
 	// Create the KRO Language Server with:
 	// - Logger for structured logging
 	// - Kubernetes client config for online CRD and CEL validation (nil = offline mode)
+	// - CRD files and directories for offline validation
 	// - No additional options (nil)
-	kroServer := NewKroServer(log, clientConfig, nil)
+	kroServer := NewKroServer(log, clientConfig, crdPaths, nil)
 
 	// Create the LSP protocol handler with all the language server capabilities
 	handler := kroServer.createHandler()
@@ -0,0 +1,291 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/go-logr/logr"
+	krocel "github.com/kubernetes-sigs/kro/pkg/cel"
+	"github.com/kubernetes-sigs/kro/pkg/cel/ast"
+	"github.com/kubernetes-sigs/kro/pkg/graph"
+	protocol "github.com/tliron/glsp/protocol_3_16"
+	"gopkg.in/yaml.v3"
+)
+
+// NavigationManager provides the navigation between the resources of KRO
+// ResourceGraphDefinition documents and the expressions reading them: from an
+// expression to the definition of a resource, and from a resource to all the
+// expressions reading it. It also renames the resources everywhere they are
+// referenced.
+type NavigationManager struct {
+	logger logr.Logger
+}
+
+// NewNavigationManager creates a new navigation manager.
+func NewNavigationManager(logger logr.Logger) *NavigationManager {
+	return &NavigationManager{
+		logger: logger,
+	}
+}
+
+// Definition returns the location of the definition of the variable at the
+// given position of a document: the id of a resource, or the schema of the
+// instances. It returns nil if there is no variable there.
+func (nm *NavigationManager) Definition(uri, content string, position protocol.Position) *protocol.Location {
+	doc := parseRGDDocument(content, int(position.Line))
+	definition := declarationNode(doc, variableAt(doc, int(position.Line), int(position.Character)))
+	if definition == nil {
+		return nil
+	}
+	return &protocol.Location{URI: uri, Range: nodeRange(definition)}
+}
+
+// References returns the locations of all the expressions, and of all the
+// dependsOn entries, reading the variable at the given position of a document.
+// The declaration of the variable is included if requested.
+func (nm *NavigationManager) References(
+	uri, content string,
+	position protocol.Position,
+	includeDeclaration bool,
+) []protocol.Location {
+	doc := parseRGDDocument(content, int(position.Line))
+	variable := variableAt(doc, int(position.Line), int(position.Character))
+	if variable == "" {
+		return []protocol.Location{}
+	}
+
+	locations := []protocol.Location{}
+	if includeDeclaration {
+		if declaration := declarationNode(doc, variable); declaration != nil {
+			locations = append(locations, protocol.Location{URI: uri, Range: nodeRange(declaration)})
+		}
+	}
+
+	expressions, err := expressionsReading(doc, variable)
+	if err != nil {
+		nm.logger.Error(err, "Failed to inspect expressions")
+	}
+	for _, expression := range expressions {
+		locations = append(locations, protocol.Location{
+			URI:   uri,
+			Range: lineRange(expression.Line, expression.Start, expression.End-expression.Start),
+		})
+	}
+
+	for _, resource := range doc.resources() {
+		for _, dependency := range dependsOnNodes(resource) {
+			if dependency.Value == variable {
+				locations = append(locations, protocol.Location{URI: uri, Range: nodeRange(dependency)})
+			}
+		}
+	}
+	return locations
+}
+
+// Rename returns the edits renaming the resource whose id, or a reference to
+// it, is at the given position of a document. It returns nil if there is no
+// resource there, and an error if the new id is invalid or already used.
+func (nm *NavigationManager) Rename(
+	uri, content string,
+	position protocol.Position,
+	newID string,
+) (*protocol.WorkspaceEdit, error) {
+	doc := parseRGDDocument(content, int(position.Line))
+	id := variableAt(doc, int(position.Line), int(position.Character))
+	if id == "" || id == schemaVariable || doc.resource(id) == nil {
+		return nil, nil
+	}
+	if err := validateNewResourceID(doc, newID); err != nil {
+		return nil, err
+	}
+	edits, err := renameEdits(doc, id, newID)
+	if err != nil {
+		return nil, err
+	}
+	return &protocol.WorkspaceEdit{
+		Changes: map[protocol.DocumentUri][]protocol.TextEdit{uri: edits},
+	}, nil
+}
+
+// documentExpression is an expression of a document, on a given zero-based
+// line.
+type documentExpression struct {
+	lineExpression
+	Line int
+}
+
+// expressionsReading returns the expressions of the document reading the
+// given variable. Expressions that can't be parsed are skipped.
+func expressionsReading(doc *rgdDocument, variable string) ([]documentExpression, error) {
+	variables := append(doc.resourceIDs(), schemaVariable)
+	env, err := krocel.DefaultEnvironment(krocel.WithResourceIDs(variables), krocel.WithConfig())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CEL environment: %w", err)
+	}
+	inspector := ast.NewInspectorWithEnv(env, variables)
+
+	var expressions []documentExpression
+	for _, expression := range documentExpressions(doc) {
+		inspection, err := inspector.Inspect(expression.Text)
+		if err != nil {
+			continue
+		}
+		for _, dependency := range inspection.ResourceDependencies {
+			if dependency.ID == variable {
+				expressions = append(expressions, expression)
+				break
+			}
+		}
+	}
+	return expressions, nil
+}
+
+// documentExpressions returns all the expressions of the document.
+func documentExpressions(doc *rgdDocument) []documentExpression {
+	var expressions []documentExpression
+	for line := range doc.lines {
+		for _, expression := range lineExpressions(doc.lineText(line)) {
+			expressions = append(expressions, documentExpression{lineExpression: expression, Line: line})
+		}
+	}
+	return expressions
+}
+
+// renameEdits returns the edits renaming the resource with the given id
+// everywhere in the document: its id, the expressions reading it, and the
+// dependsOn entries.
+func renameEdits(doc *rgdDocument, id, newID string) ([]protocol.TextEdit, error) {
+	resource := doc.resource(id)
+	if resource == nil {
+		return nil, fmt.Errorf("unknown resource %q", id)
+	}
+	edits := []protocol.TextEdit{{Range: nodeRange(resource.IDNode), NewText: newID}}
+
+	expressions, err := expressionsReading(doc, id)
+	if err != nil {
+		return nil, err
+	}
+	// Invalid ids, like kebab-case ones, aren't parsed as variables by CEL,
+	// so they are looked up in the text of all the expressions instead.
+	if !graph.IsValidResourceID(id) {
+		expressions = documentExpressions(doc)
+	}
+	for _, expression := range expressions {
+		for _, offset := range identifierOffsets(expression.Text, id) {
+			edits = append(edits, protocol.TextEdit{
+				Range:   lineRange(expression.Line, expression.Start+2+offset, len(id)),
+				NewText: newID,
+			})
+		}
+	}
+
+	for _, other := range doc.resources() {
+		for _, dependency := range dependsOnNodes(other) {
+			if dependency.Value == id {
+				edits = append(edits, protocol.TextEdit{Range: nodeRange(dependency), NewText: newID})
+			}
+		}
+	}
+	return edits, nil
+}
+
+// validateNewResourceID returns an error if the id can't be given to a
+// resource of the document.
+func validateNewResourceID(doc *rgdDocument, id string) error {
+	switch {
+	case graph.IsKROReservedWord(id):
+		return fmt.Errorf("id %s is a reserved keyword in KRO", id)
+	case !graph.IsValidResourceID(id):
+		return fmt.Errorf("id %s is not a valid KRO resource id: must be lower camelCase", id)
+	case doc.resource(id) != nil:
+		return fmt.Errorf("id %s is already used by another resource", id)
+	}
+	return nil
+}
+
+// variableAt returns the expression variable at the given character: a
+// variable read by an expression, the id of a resource, or a dependsOn entry.
+// It returns an empty string if there is none.
+func variableAt(doc *rgdDocument, line, character int) string {
+	lineText := doc.lineText(line)
+	if _, ok := lineExpressionAt(lineText, character); ok {
+		if reference, _, _ := referenceAt(lineText, character); reference != nil {
+			return reference[0]
+		}
+		return ""
+	}
+	if resource := resourceOfIDAt(doc, line, character); resource != nil {
+		return resource.ID
+	}
+	for _, resource := range doc.resources() {
+		for _, dependency := range dependsOnNodes(resource) {
+			if nodeContains(dependency, line, character) {
+				return dependency.Value
+			}
+		}
+	}
+	return ""
+}
+
+// declarationNode returns the node declaring the given variable: the id of the
+// resource, or the schema key for the instance. It returns nil if the
+// variable isn't declared in the document.
+func declarationNode(doc *rgdDocument, variable string) *yaml.Node {
+	if variable == schemaVariable {
+		schemaKey, _ := mappingField(mappingValue(doc.root, "spec"), "schema")
+		return schemaKey
+	}
+	if resource := doc.resource(variable); resource != nil {
+		return resource.IDNode
+	}
+	return nil
+}
+
+// dependsOnNodes returns the nodes of the dependsOn entries of the resource.
+func dependsOnNodes(resource *rgdResource) []*yaml.Node {
+	dependsOn := mappingValue(resource.Item, "dependsOn")
+	if dependsOn == nil || dependsOn.Kind != yaml.SequenceNode {
+		return nil
+	}
+	return dependsOn.Content
+}
+
+// nodeContains returns true if the given character is on the value of the
+// scalar node.
+func nodeContains(node *yaml.Node, line, character int) bool {
+	nodeRange := nodeRange(node)
+	return int(nodeRange.Start.Line) == line &&
+		character >= int(nodeRange.Start.Character) && character <= int(nodeRange.End.Character)
+}
+
+// nodeRange returns the range of the value of the scalar node, quotes
+// excluded.
+func nodeRange(node *yaml.Node) protocol.Range {
+	start := node.Column - 1
+	if node.Style == yaml.DoubleQuotedStyle || node.Style == yaml.SingleQuotedStyle {
+		start++
+	}
+	return lineRange(node.Line-1, start, len(node.Value))
+}
+
+// lineRange returns the range of length characters starting at the given
+// zero-based line and character.
+func lineRange(line, character, length int) protocol.Range {
+	return protocol.Range{
+		Start: protocol.Position{Line: uint32(line), Character: uint32(character)},
+		End:   protocol.Position{Line: uint32(line), Character: uint32(character + length)},
+	}
+}
@@ -0,0 +1,253 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	protocol "github.com/tliron/glsp/protocol_3_16"
+)
+
+const testURI = "file:///webapp.yaml"
+
+// testRGDWithDependsOn is testRGD where the service also depends explicitly on
+// the deployment.
+var testRGDWithDependsOn = strings.Replace(testRGD,
+	"    - id: service\n", "    - id: service\n      dependsOn:\n        - deployment\n", 1)
+
+func TestDefinition(t *testing.T) {
+	nm := NewNavigationManager(logr.Discard())
+
+	tests := []struct {
+		name string
+		// old is replaced by new in testRGD, which holds the cursor.
+		old, new string
+		want     *protocol.Range
+	}{
+		{
+			name: "resource read by an expression",
+			old:  "name: ${deployment.metadata.name}",
+			new:  "name: ${deploy<|>ment.metadata.name}",
+			want: rangePtr(lineRange(12, 10, len("deployment"))),
+		},
+		{
+			name: "field of a resource read by an expression",
+			old:  "name: ${deployment.metadata.name}",
+			new:  "name: ${deployment.meta<|>data.name}",
+			want: rangePtr(lineRange(12, 10, len("deployment"))),
+		},
+		{
+			name: "instance read by an expression",
+			old:  "replicas: ${schema.spec.replicas}",
+			new:  "replicas: ${sche<|>ma.spec.replicas}",
+			want: rangePtr(lineRange(5, 2, len("schema"))),
+		},
+		{
+			name: "resource id",
+			old:  "- id: service",
+			new:  "- id: serv<|>ice",
+			want: rangePtr(lineRange(20, 10, len("service"))),
+		},
+		{
+			name: "unknown variable",
+			old:  "name: ${deployment.metadata.name}",
+			new:  "name: ${unkn<|>own.metadata.name}",
+		},
+		{
+			name: "outside expressions",
+			old:  "kind: Service",
+			new:  "kind: Serv<|>ice",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, position := withCursor(t, strings.Replace(testRGD, tt.old, tt.new, 1))
+			location := nm.Definition(testURI, content, position)
+			if tt.want == nil {
+				assert.Nil(t, location)
+				return
+			}
+			require.NotNil(t, location)
+			assert.Equal(t, testURI, location.URI)
+			assert.Equal(t, *tt.want, location.Range)
+		})
+	}
+
+	t.Run("dependsOn entry", func(t *testing.T) {
+		content, position := withCursor(t, strings.Replace(testRGDWithDependsOn,
+			"        - deployment\n", "        - deploy<|>ment\n", 1))
+		location := nm.Definition(testURI, content, position)
+		require.NotNil(t, location)
+		assert.Equal(t, lineRange(12, 10, len("deployment")), location.Range)
+	})
+}
+
+func TestReferences(t *testing.T) {
+	nm := NewNavigationManager(logr.Discard())
+
+	t.Run("resource", func(t *testing.T) {
+		content, position := withCursor(t, strings.Replace(testRGDWithDependsOn,
+			"- id: deployment", "- id: deploy<|>ment", 1))
+
+		locations := nm.References(testURI, content, position, true)
+		assert.Equal(t, []protocol.Location{
+			{URI: testURI, Range: lineRange(12, 10, len("deployment"))},
+			{URI: testURI, Range: lineRange(27, 16, len("${deployment.metadata.name}"))},
+			{URI: testURI, Range: lineRange(22, 10, len("deployment"))},
+		}, locations)
+
+		locations = nm.References(testURI, content, position, false)
+		assert.Len(t, locations, 2)
+	})
+
+	t.Run("instance", func(t *testing.T) {
+		content, position := withCursor(t, strings.Replace(testRGD,
+			"name: ${schema.spec.name}", "name: ${sch<|>ema.spec.name}", 1))
+
+		locations := nm.References(testURI, content, position, false)
+		assert.Equal(t, []protocol.Location{
+			{URI: testURI, Range: lineRange(17, 16, len("${schema.spec.name}"))},
+			{URI: testURI, Range: lineRange(19, 20, len("${schema.spec.replicas}"))},
+		}, locations)
+	})
+
+	t.Run("fields named like the resource aren't references", func(t *testing.T) {
+		content, position := withCursor(t, strings.Replace(testRGD,
+			"- id: service", "- id: serv<|>ice", 1))
+		content = strings.Replace(content, "${schema.spec.name}", "${deployment.service}", 1)
+
+		assert.Empty(t, nm.References(testURI, content, position, false))
+	})
+
+	t.Run("nothing at the position", func(t *testing.T) {
+		content, position := withCursor(t, strings.Replace(testRGD,
+			"kind: Service", "kind: Serv<|>ice", 1))
+		assert.Empty(t, nm.References(testURI, content, position, true))
+	})
+}
+
+func rangePtr(r protocol.Range) *protocol.Range {
+	return &r
+}
+
+func TestRename(t *testing.T) {
+	nm := NewNavigationManager(logr.Discard())
+
+	wantEdits := []protocol.TextEdit{
+		{Range: lineRange(12, 10, len("deployment")), NewText: "web"},
+		{Range: lineRange(27, 18, len("deployment")), NewText: "web"},
+		{Range: lineRange(22, 10, len("deployment")), NewText: "web"},
+	}
+	tests := []struct {
+		name     string
+		old, new string
+	}{
+		{
+			name: "from the resource id",
+			old:  "- id: deployment",
+			new:  "- id: deploy<|>ment",
+		},
+		{
+			name: "from an expression",
+			old:  "name: ${deployment.metadata.name}",
+			new:  "name: ${deploy<|>ment.metadata.name}",
+		},
+		{
+			name: "from a dependsOn entry",
+			old:  "        - deployment\n",
+			new:  "        - deploy<|>ment\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, position := withCursor(t, strings.Replace(testRGDWithDependsOn, tt.old, tt.new, 1))
+			edit, err := nm.Rename(testURI, content, position, "web")
+			require.NoError(t, err)
+			require.NotNil(t, edit)
+			assert.Equal(t, map[protocol.DocumentUri][]protocol.TextEdit{testURI: wantEdits}, edit.Changes)
+		})
+	}
+}
+
+func TestRename_Invalid(t *testing.T) {
+	nm := NewNavigationManager(logr.Discard())
+	content, position := withCursor(t, strings.Replace(testRGD, "- id: deployment", "- id: deploy<|>ment", 1))
+
+	tests := []struct {
+		name    string
+		newID   string
+		wantErr string
+	}{
+		{
+			name:    "reserved word",
+			newID:   "schema",
+			wantErr: "reserved keyword",
+		},
+		{
+			name:    "invalid id",
+			newID:   "my-deployment",
+			wantErr: "lower camelCase",
+		},
+		{
+			name:    "id of another resource",
+			newID:   "service",
+			wantErr: "already used",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			edit, err := nm.Rename(testURI, content, position, tt.newID)
+			assert.ErrorContains(t, err, tt.wantErr)
+			assert.Nil(t, edit)
+		})
+	}
+}
+
+func TestRename_Nothing(t *testing.T) {
+	nm := NewNavigationManager(logr.Discard())
+
+	tests := []struct {
+		name     string
+		old, new string
+	}{
+		{
+			name: "instance",
+			old:  "name: ${schema.spec.name}",
+			new:  "name: ${sch<|>ema.spec.name}",
+		},
+		{
+			name: "unknown variable",
+			old:  "name: ${deployment.metadata.name}",
+			new:  "name: ${unkn<|>own.metadata.name}",
+		},
+		{
+			name: "outside expressions",
+			old:  "kind: Service",
+			new:  "kind: Serv<|>ice",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, position := withCursor(t, strings.Replace(testRGD, tt.old, tt.new, 1))
+			edit, err := nm.Rename(testURI, content, position, "web")
+			assert.NoError(t, err)
+			assert.Nil(t, edit)
+		})
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"strings"
 
 	"github.com/go-logr/logr"
+	"github.com/kubernetes-sigs/kro/pkg/graph/fieldpath"
 	protocol "github.com/tliron/glsp/protocol_3_16"
 	"gopkg.in/yaml.v3"
 )
@@ -103,6 +104,85 @@ func (p *YAMLParser) IsKROResource(node *yaml.Node) bool {
 	return isKROResource
 }
 
+// FindFieldRange returns the range of the field at the given path, like
+// spec.resources[2].template.spec.replicas, in the parsed document. When the
+// path goes through fields missing from the document, the range of the deepest
+// field found is returned. Scalar values holding the given expression are
+// narrowed down to the expression.
+func (p *YAMLParser) FindFieldRange(node *yaml.Node, path, expression string) (protocol.Range, bool) {
+	current := p.getRootMappingNode(node)
+	if current == nil {
+		return protocol.Range{}, false
+	}
+	segments, err := fieldpath.Parse(path)
+	if err != nil {
+		p.logger.V(1).Info("Failed to parse field path", "path", path, "error", err)
+		return protocol.Range{}, false
+	}
+
+	// key is the key of the current node in its parent mapping, if any.
+	var key *yaml.Node
+	for _, segment := range segments {
+		var next, nextKey *yaml.Node
+		if segment.Index >= 0 {
+			if current.Kind == yaml.SequenceNode && segment.Index < len(current.Content) {
+				next = current.Content[segment.Index]
+			}
+		} else {
+			nextKey, next = p.getField(current, segment.Name)
+		}
+		if next == nil {
+			break
+		}
+		current, key = next, nextKey
+	}
+
+	// Only the values written on a single line are pointed at directly.
+	singleLine := current.Style&(yaml.LiteralStyle|yaml.FoldedStyle) == 0 && !strings.Contains(current.Value, "\n")
+	if current.Kind == yaml.ScalarNode && singleLine {
+		start := current.Column - 1
+		length := len(current.Value)
+		if current.Style == yaml.DoubleQuotedStyle || current.Style == yaml.SingleQuotedStyle {
+			start++
+		}
+		if expression != "" {
+			if i := strings.Index(current.Value, expression); i >= 0 {
+				start += i
+				length = len(expression)
+			}
+		}
+		return lineRange(current.Line-1, start, length), true
+	}
+	// Mappings and sequences are pointed at through their key.
+	if key != nil {
+		return lineRange(key.Line-1, key.Column-1, len(key.Value)), true
+	}
+	return lineRange(current.Line-1, current.Column-1, 0), true
+}
+
+// getField returns the key and value nodes of the field with the given name
+// in the mapping node, or nil if there is no such field.
+func (p *YAMLParser) getField(mappingNode *yaml.Node, fieldName string) (*yaml.Node, *yaml.Node) {
+	if mappingNode.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+	for i := 0; i+1 < len(mappingNode.Content); i += 2 {
+		if mappingNode.Content[i].Value == fieldName {
+			return mappingNode.Content[i], mappingNode.Content[i+1]
+		}
+	}
+	return nil, nil
+}
+
+// lineRange returns the range of length characters starting at the given
+// zero-based line and character.
+func lineRange(line, character, length int) protocol.Range {
+	return protocol.Range{
+		Start: protocol.Position{Line: uint32(line), Character: uint32(character)},
+		End:   protocol.Position{Line: uint32(line), Character: uint32(character + length)},
+	}
+}
+
 // Note: This Parser will be improved in the future with proper diagnostics positioning
 func (p *YAMLParser) getRootMappingNode(node *yaml.Node) *yaml.Node {
 	if node == nil {
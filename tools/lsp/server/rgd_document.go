@@ -0,0 +1,590 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/kubernetes-sigs/kro/api/v1alpha1"
+	"github.com/kubernetes-sigs/kro/pkg/graph/schema"
+	schemaresolver "github.com/kubernetes-sigs/kro/pkg/graph/schema/resolver"
+	"github.com/kubernetes-sigs/kro/pkg/simpleschema"
+	"gopkg.in/yaml.v3"
+	k8sschema "k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// rgdDocument is a ResourceGraphDefinition document parsed to provide language
+// features. Unlike validation, language features are requested while the
+// document is being edited, so the document is parsed on a best effort basis.
+type rgdDocument struct {
+	lines []string
+	// root is the root mapping node of the document, nil if the document
+	// couldn't be parsed.
+	root *yaml.Node
+}
+
+// rgdResource is a resource of a ResourceGraphDefinition document.
+type rgdResource struct {
+	// ID is the id of the resource.
+	ID string
+	// Item is the node of the resource in spec.resources.
+	Item *yaml.Node
+	// IDNode is the value node of the id of the resource.
+	IDNode *yaml.Node
+	// ObjectKey and Object are the key and value nodes of the template, or of
+	// the external reference, of the resource.
+	ObjectKey *yaml.Node
+	Object    *yaml.Node
+}
+
+// parseRGDDocument parses the given content. The document being edited is
+// often invalid YAML because of the line being typed, in which case the
+// document is parsed again without that line.
+func parseRGDDocument(content string, line int) *rgdDocument {
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &node); err != nil {
+		lines := strings.Split(content, "\n")
+		if line < 0 || line >= len(lines) {
+			return newRGDDocument(content, nil)
+		}
+		lines[line] = ""
+		node = yaml.Node{}
+		if err := yaml.Unmarshal([]byte(strings.Join(lines, "\n")), &node); err != nil {
+			return newRGDDocument(content, nil)
+		}
+	}
+	return newRGDDocument(content, &node)
+}
+
+// newRGDDocument creates a document from its content and the node parsed from
+// it, which can be nil if the content couldn't be parsed.
+func newRGDDocument(content string, node *yaml.Node) *rgdDocument {
+	doc := &rgdDocument{lines: strings.Split(content, "\n")}
+	if node != nil && node.Kind == yaml.DocumentNode && len(node.Content) > 0 &&
+		node.Content[0].Kind == yaml.MappingNode {
+		doc.root = node.Content[0]
+	}
+	return doc
+}
+
+// lineText returns the text of the given zero-based line.
+func (d *rgdDocument) lineText(line int) string {
+	if line < 0 || line >= len(d.lines) {
+		return ""
+	}
+	return strings.TrimSuffix(d.lines[line], "\r")
+}
+
+// resources returns the resources of the document, in order.
+func (d *rgdDocument) resources() []*rgdResource {
+	resourcesNode := mappingValue(mappingValue(d.root, "spec"), "resources")
+	if resourcesNode == nil || resourcesNode.Kind != yaml.SequenceNode {
+		return nil
+	}
+	resources := make([]*rgdResource, 0, len(resourcesNode.Content))
+	for _, item := range resourcesNode.Content {
+		resource := &rgdResource{Item: item}
+		if _, idNode := mappingField(item, "id"); idNode != nil {
+			resource.ID, resource.IDNode = idNode.Value, idNode
+		}
+		resource.ObjectKey, resource.Object = mappingField(item, "template")
+		if resource.Object == nil {
+			resource.ObjectKey, resource.Object = mappingField(item, "externalRef")
+		}
+		resources = append(resources, resource)
+	}
+	return resources
+}
+
+// resource returns the resource with the given id, or nil.
+func (d *rgdDocument) resource(id string) *rgdResource {
+	for _, resource := range d.resources() {
+		if resource.ID == id {
+			return resource
+		}
+	}
+	return nil
+}
+
+// resourceAt returns the resource defined at the given zero-based line, or nil.
+func (d *rgdDocument) resourceAt(line int) *rgdResource {
+	var found *rgdResource
+	for _, resource := range d.resources() {
+		if resource.Item.Line-1 > line {
+			break
+		}
+		found = resource
+	}
+	return found
+}
+
+// resourceIDs returns the ids of the resources of the document.
+func (d *rgdDocument) resourceIDs() []string {
+	var ids []string
+	for _, resource := range d.resources() {
+		if resource.ID != "" {
+			ids = append(ids, resource.ID)
+		}
+	}
+	return ids
+}
+
+// GVK returns the GroupVersionKind of the resource, read from its template or
+// external reference.
+func (r *rgdResource) GVK() (k8sschema.GroupVersionKind, bool) {
+	apiVersion := mappingValue(r.Object, "apiVersion")
+	kind := mappingValue(r.Object, "kind")
+	if apiVersion == nil || kind == nil || apiVersion.Value == "" || kind.Value == "" {
+		return k8sschema.GroupVersionKind{}, false
+	}
+	return k8sschema.FromAPIVersionAndKind(apiVersion.Value, kind.Value), true
+}
+
+// instanceGVK returns the GroupVersionKind of the instances, read from the
+// schema of the document.
+func (d *rgdDocument) instanceGVK() k8sschema.GroupVersionKind {
+	schemaNode := mappingValue(mappingValue(d.root, "spec"), "schema")
+	gvk := k8sschema.GroupVersionKind{Group: v1alpha1.KRODomainName}
+	if group := mappingValue(schemaNode, "group"); group != nil && group.Value != "" {
+		gvk.Group = group.Value
+	}
+	if apiVersion := mappingValue(schemaNode, "apiVersion"); apiVersion != nil {
+		gvk.Version = apiVersion.Value
+	}
+	if kind := mappingValue(schemaNode, "kind"); kind != nil {
+		gvk.Kind = kind.Value
+	}
+	return gvk
+}
+
+// templateParentKeys returns the resource whose template holds the key
+// starting in the given text of a line, along with the keys of the mappings
+// enclosing that key in the template. The resource is nil if the line isn't
+// part of a template.
+func (d *rgdDocument) templateParentKeys(line int, text string) (*rgdResource, []string) {
+	resource := d.resourceAt(line)
+	if resource == nil || resource.ObjectKey == nil || resource.ObjectKey.Value != "template" ||
+		resource.ObjectKey.Line-1 >= line {
+		return nil, nil
+	}
+
+	dashIndent, indent, _ := splitYAMLLine(text)
+	if dashIndent >= 0 {
+		indent = dashIndent
+	}
+	if indent <= resource.ObjectKey.Column-1 {
+		return nil, nil
+	}
+	return resource, parentKeys(d.lines, line, resource.ObjectKey.Line-1, indent, dashIndent >= 0)
+}
+
+// instanceSchema returns the schema of the instances, as seen by the
+// expressions through the schema variable. The spec is built from the
+// SimpleSchema of the document, without the imported types.
+func (d *rgdDocument) instanceSchema() *spec.Schema {
+	schemaNode := mappingValue(d.root, "spec")
+	schemaNode = mappingValue(schemaNode, "schema")
+	instanceSchema := &spec.Schema{}
+	instanceSchema.Properties = map[string]spec.Schema{
+		"apiVersion": *spec.StringProperty(),
+		"kind":       *spec.StringProperty(),
+		"metadata":   *schemaresolver.ObjectMetaSchema(),
+	}
+
+	specObject := map[string]interface{}{}
+	customTypes := map[string]interface{}{}
+	if specNode := mappingValue(schemaNode, "spec"); specNode == nil || specNode.Decode(&specObject) != nil {
+		return instanceSchema
+	}
+	if typesNode := mappingValue(schemaNode, "types"); typesNode != nil {
+		_ = typesNode.Decode(&customTypes)
+	}
+	specProps, err := simpleschema.ToOpenAPISpec(specObject, customTypes)
+	if err != nil {
+		return instanceSchema
+	}
+	specSchema, err := schema.ConvertJSONSchemaPropsToSpecSchema(specProps)
+	if err != nil {
+		return instanceSchema
+	}
+	instanceSchema.Properties["spec"] = *specSchema
+	return instanceSchema
+}
+
+// mappingField returns the key and value nodes of the field with the given
+// name in the mapping node, or nil if there is no such field.
+func mappingField(node *yaml.Node, name string) (*yaml.Node, *yaml.Node) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == name {
+			return node.Content[i], node.Content[i+1]
+		}
+	}
+	return nil, nil
+}
+
+// nodeEnd returns the zero-based line and character where the node ends,
+// including its children.
+func nodeEnd(node *yaml.Node) (int, int) {
+	if (node.Kind == yaml.MappingNode || node.Kind == yaml.SequenceNode) && len(node.Content) > 0 {
+		return nodeEnd(node.Content[len(node.Content)-1])
+	}
+	line, character := node.Line-1, node.Column-1+len(node.Value)
+	switch {
+	case node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0:
+		// Block scalars start on the line following their indicator.
+		line += strings.Count(strings.TrimRight(node.Value, "\n"), "\n") + 1
+		character = 0
+	case node.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle) != 0:
+		character += 2
+	case node.Kind == yaml.MappingNode || node.Kind == yaml.SequenceNode:
+		// Empty flow collections, like {} and [].
+		character += 2
+	}
+	return line, character
+}
+
+// mappingValue returns the value node of the field with the given name in the
+// mapping node, or nil if there is no such field.
+func mappingValue(node *yaml.Node, name string) *yaml.Node {
+	_, value := mappingField(node, name)
+	return value
+}
+
+// schemaAt returns the schema of the field at the given path in s, or nil if
+// there is no such field. List items are denoted by "[]" segments.
+func schemaAt(s *spec.Schema, path []string) *spec.Schema {
+	for _, segment := range path {
+		if s == nil {
+			return nil
+		}
+		switch {
+		case segment == "[]":
+			if s.Items == nil {
+				return nil
+			}
+			s = s.Items.Schema
+		case s.Properties != nil:
+			property, ok := s.Properties[segment]
+			if !ok {
+				return nil
+			}
+			s = &property
+		case s.AdditionalProperties != nil:
+			s = s.AdditionalProperties.Schema
+		default:
+			return nil
+		}
+	}
+	return s
+}
+
+// schemaType returns a short description of the type of s, like string or
+// []object.
+func schemaType(s *spec.Schema) string {
+	if s == nil {
+		return ""
+	}
+	if len(s.Type) == 0 {
+		if intOrString, _ := s.Extensions.GetBool("x-kubernetes-int-or-string"); intOrString {
+			return "int-or-string"
+		}
+		return "any"
+	}
+	switch s.Type[0] {
+	case "array":
+		if s.Items != nil {
+			return "[]" + schemaType(s.Items.Schema)
+		}
+	case "object":
+		if s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil {
+			return "map[string]" + schemaType(s.AdditionalProperties.Schema)
+		}
+	}
+	return s.Type[0]
+}
+
+// propertyNames returns the names of the properties of s, sorted.
+func propertyNames(s *spec.Schema) []string {
+	if s == nil {
+		return nil
+	}
+	names := make([]string, 0, len(s.Properties))
+	for name := range s.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// expressionAt returns the text of the expression enclosing the given
+// character of the line, from its start to the character, and whether the
+// character is inside an expression.
+func expressionAt(lineText string, character int) (string, bool) {
+	if character > len(lineText) {
+		character = len(lineText)
+	}
+	prefix := lineText[:character]
+	start := strings.LastIndex(prefix, "${")
+	if start < 0 || strings.Contains(prefix[start:], "}") {
+		return "", false
+	}
+	return prefix[start+2:], true
+}
+
+// lineExpression is an expression written on a line of a document.
+type lineExpression struct {
+	// Text is the expression, without its ${ and } delimiters.
+	Text string
+	// Start and End are the characters of the line where the expression,
+	// delimiters included, starts and ends.
+	Start, End int
+}
+
+// lineExpressions returns the complete expressions written on the line. The
+// braces of the map literals and of the string literals of the expressions
+// are skipped when looking for their end.
+func lineExpressions(lineText string) []lineExpression {
+	var expressions []lineExpression
+	for offset := 0; ; {
+		start := strings.Index(lineText[offset:], "${")
+		if start < 0 {
+			return expressions
+		}
+		start += offset
+		end := expressionEnd(lineText, start+2)
+		if end < 0 {
+			return expressions
+		}
+		expressions = append(expressions, lineExpression{
+			Text:  lineText[start+2 : end-1],
+			Start: start,
+			End:   end,
+		})
+		offset = end
+	}
+}
+
+// expressionEnd returns the character following the closing brace of the
+// expression whose text starts at the given character, or -1 if the
+// expression isn't closed on the line.
+func expressionEnd(lineText string, start int) int {
+	depth := 0
+	var quote byte
+	for i := start; i < len(lineText); i++ {
+		c := lineText[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '{':
+			depth++
+		case c == '}':
+			if depth == 0 {
+				return i + 1
+			}
+			depth--
+		}
+	}
+	return -1
+}
+
+// lineExpressionAt returns the complete expression of the line enclosing the
+// given character, delimiters included.
+func lineExpressionAt(lineText string, character int) (lineExpression, bool) {
+	for _, expression := range lineExpressions(lineText) {
+		if character >= expression.Start && character < expression.End {
+			return expression, true
+		}
+	}
+	return lineExpression{}, false
+}
+
+// identifierOffsets returns the offsets of the given variable in the
+// expression, skipping the string literals and the fields named like it.
+func identifierOffsets(expression, name string) []int {
+	var offsets []int
+	var quote byte
+	for i := 0; i < len(expression); i++ {
+		c := expression[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case strings.HasPrefix(expression[i:], name):
+			end := i + len(name)
+			if (i > 0 && (isIdentifierChar(expression[i-1]) || expression[i-1] == '.')) ||
+				(end < len(expression) && isIdentifierChar(expression[end])) {
+				continue
+			}
+			offsets = append(offsets, i)
+			i = end - 1
+		}
+	}
+	return offsets
+}
+
+// isIdentifierChar returns true if c can be part of an identifier.
+func isIdentifierChar(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// referenceAt returns the segments of the field reference of the line at the
+// given character, up to the segment holding the character, along with the
+// characters where that part of the reference starts and ends. For example,
+// it returns ["vpc", "status"] when the character is on "status" in
+// "vpc.status.vpcID".
+func referenceAt(lineText string, character int) ([]string, int, int) {
+	if character < 0 || character >= len(lineText) || !isReferenceChar(lineText[character]) {
+		return nil, 0, 0
+	}
+	start := character
+	for start > 0 && isReferenceChar(lineText[start-1]) {
+		start--
+	}
+	end := character
+	for end < len(lineText) && isReferenceChar(lineText[end]) && lineText[end] != '.' && lineText[end] != '[' {
+		end++
+	}
+	reference := trailingReference(lineText[start:end])
+	if len(reference) == 0 || reference[len(reference)-1] == "" {
+		return nil, 0, 0
+	}
+	return reference, start, end
+}
+
+// referencePath returns the text of a reference from its segments, like
+// spec.containers[].image.
+func referencePath(segments []string) string {
+	var b strings.Builder
+	for i, segment := range segments {
+		if i > 0 && segment != "[]" {
+			b.WriteByte('.')
+		}
+		b.WriteString(segment)
+	}
+	return b.String()
+}
+
+// trailingReference returns the segments of the field reference at the end
+// of the expression, like ["vpc", "status", "vpcID"] for
+// "vpc.status.vpcID". Indexes are returned as "[]" segments, and the last
+// segment is empty when the expression ends with a dot.
+func trailingReference(expression string) []string {
+	start := len(expression)
+	for start > 0 && isReferenceChar(expression[start-1]) {
+		start--
+	}
+	reference := strings.ReplaceAll(expression[start:], "?", "")
+	if reference == "" || reference[0] == '.' || reference[0] == '[' || (reference[0] >= '0' && reference[0] <= '9') {
+		return nil
+	}
+
+	var segments []string
+	for _, part := range strings.Split(reference, ".") {
+		name, rest, _ := strings.Cut(part, "[")
+		segments = append(segments, name)
+		for ; rest != ""; _, rest, _ = strings.Cut(rest, "[") {
+			segments = append(segments, "[]")
+		}
+	}
+	return segments
+}
+
+// isReferenceChar returns true if c can be part of a field reference.
+func isReferenceChar(c byte) bool {
+	return c == '_' || c == '.' || c == '[' || c == ']' || c == '?' ||
+		(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// parentKeys returns the keys of the mappings enclosing a key starting at the
+// given indentation on the given line, down to the stop line excluded. When
+// the key is the first one of a list item, indent is the indentation of the
+// list item marker. It relies on the indentation of the lines rather than on
+// the parsed document, which may not hold the line being typed. List items
+// are returned as "[]".
+func parentKeys(lines []string, line, stop, indent int, inListItem bool) []string {
+	var keys []string
+	if inListItem {
+		keys = append(keys, "[]")
+	}
+	// ownerAtIndent is set when looking for the key of a list, which can be at
+	// the same indentation as its items.
+	ownerAtIndent := inListItem
+	for l := line - 1; l > stop && l >= 0; l-- {
+		dashIndent, contentIndent, key := splitYAMLLine(lines[l])
+		if contentIndent < 0 {
+			continue
+		}
+		isParent := contentIndent < indent
+		if ownerAtIndent {
+			isParent = contentIndent <= indent && dashIndent != indent
+		} else if dashIndent >= 0 && contentIndent == indent {
+			// The key is part of the same list item as this line.
+			keys = append(keys, "[]")
+			indent, ownerAtIndent = dashIndent, true
+			continue
+		}
+		if !isParent {
+			continue
+		}
+		keys = append(keys, key)
+		indent, ownerAtIndent = contentIndent, false
+		if dashIndent >= 0 {
+			keys = append(keys, "[]")
+			indent, ownerAtIndent = dashIndent, true
+		}
+	}
+
+	// The keys were collected from the innermost one.
+	for i, j := 0, len(keys)-1; i < j; i, j = i+1, j-1 {
+		keys[i], keys[j] = keys[j], keys[i]
+	}
+	return keys
+}
+
+// splitYAMLLine returns the indentation of the list item marker of the line,
+// or -1 if there is none, the indentation of its content and the key it
+// holds. The indentations are -1 for blank and comment lines.
+func splitYAMLLine(text string) (int, int, string) {
+	trimmed := strings.TrimLeft(text, " ")
+	if strings.TrimSpace(trimmed) == "" || strings.HasPrefix(trimmed, "#") {
+		return -1, -1, ""
+	}
+	indent := len(text) - len(trimmed)
+	dashIndent := -1
+	if trimmed == "-" || strings.HasPrefix(trimmed, "- ") {
+		dashIndent = indent
+		rest := strings.TrimLeft(trimmed[1:], " ")
+		indent += len(trimmed) - len(rest)
+		trimmed = rest
+	}
+	key, _, _ := strings.Cut(trimmed, ":")
+	return dashIndent, indent, strings.Trim(strings.TrimSpace(key), `"'`)
+}
@@ -0,0 +1,73 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"github.com/go-logr/logr"
+	"k8s.io/apiserver/pkg/cel/openapi/resolver"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// schemaVariable is the variable holding the instance in the expressions.
+const schemaVariable = "schema"
+
+// schemaLookup looks up the schemas of the instance and of the resources of
+// ResourceGraphDefinition documents, for the language features.
+type schemaLookup struct {
+	logger logr.Logger
+	// schemaResolver resolves the OpenAPI schemas of the resources. It is nil
+	// without a validation manager, where only the schema of the instance is
+	// known.
+	schemaResolver resolver.SchemaResolver
+}
+
+// newSchemaLookup creates a new schema lookup. The schema resolver can be nil
+// when there is nothing to resolve the resource schemas from.
+func newSchemaLookup(logger logr.Logger, schemaResolver resolver.SchemaResolver) *schemaLookup {
+	return &schemaLookup{
+		logger:         logger,
+		schemaResolver: schemaResolver,
+	}
+}
+
+// variableSchema returns the schema of the given expression variable, or nil
+// if it is unknown.
+func (l *schemaLookup) variableSchema(doc *rgdDocument, name string) *spec.Schema {
+	if name == schemaVariable {
+		return doc.instanceSchema()
+	}
+	if resource := doc.resource(name); resource != nil {
+		return l.resourceSchema(resource)
+	}
+	return nil
+}
+
+// resourceSchema returns the OpenAPI schema of the resource, or nil if it
+// can't be resolved.
+func (l *schemaLookup) resourceSchema(resource *rgdResource) *spec.Schema {
+	if l.schemaResolver == nil {
+		return nil
+	}
+	gvk, ok := resource.GVK()
+	if !ok {
+		return nil
+	}
+	resourceSchema, err := l.schemaResolver.ResolveSchema(gvk)
+	if err != nil {
+		l.logger.V(1).Info("Failed to resolve resource schema", "gvk", gvk.String(), "error", err)
+		return nil
+	}
+	return resourceSchema
+}
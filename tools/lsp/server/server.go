@@ -73,11 +73,17 @@ type kroServer struct {
 // Parameters:
 //   - logger: Structured logger for server operations
 //   - clientConfig: Kubernetes client configuration (nil for offline mode)
+//   - crdPaths: Files or directories of the CRDs to validate against in offline mode
 //   - lspServer: GLSP server instance (can be nil, will be set later)
-func NewKroServer(logger logr.Logger, clientConfig *rest.Config, lspServer *server.Server) *kroServer {
+func NewKroServer(
+	logger logr.Logger,
+	clientConfig *rest.Config,
+	crdPaths []string,
+	lspServer *server.Server,
+) *kroServer {
 	return &kroServer{
 		logger:          logger,
-		documentManager: NewDocumentManager(logger, clientConfig),
+		documentManager: NewDocumentManager(logger, clientConfig, crdPaths),
 		server:          lspServer,
 	}
 }
@@ -226,8 +232,65 @@ func (s *kroServer) DidSave(context *glsp.Context, params *protocol.DidSaveTextD
 	return nil
 }
 
+// Completion handles the textDocument/completion request from the client.
+// Completes the resource ids and fields inside expressions, and the template
+// fields outside of them.
+func (s *kroServer) Completion(context *glsp.Context, params *protocol.CompletionParams) (any, error) {
+	s.currentContext = context
+	return s.documentManager.Complete(params.TextDocument.URI, params.Position), nil
+}
+
+// Hover handles the textDocument/hover request from the client.
+// Describes the resources, the template fields, and the references and types
+// of the expressions.
+func (s *kroServer) Hover(context *glsp.Context, params *protocol.HoverParams) (*protocol.Hover, error) {
+	s.currentContext = context
+	return s.documentManager.Hover(params.TextDocument.URI, params.Position), nil
+}
+
+// Definition handles the textDocument/definition request from the client.
+// Goes from a variable of an expression, or a dependsOn entry, to the id of
+// the resource it refers to.
+func (s *kroServer) Definition(context *glsp.Context, params *protocol.DefinitionParams) (any, error) {
+	s.currentContext = context
+	if location := s.documentManager.Definition(params.TextDocument.URI, params.Position); location != nil {
+		return *location, nil
+	}
+	return nil, nil
+}
+
+// References handles the textDocument/references request from the client.
+// Lists the expressions and dependsOn entries reading a resource.
+func (s *kroServer) References(context *glsp.Context, params *protocol.ReferenceParams) ([]protocol.Location, error) {
+	s.currentContext = context
+	return s.documentManager.References(params.TextDocument.URI, params.Position, params.Context.IncludeDeclaration), nil
+}
+
+// Rename handles the textDocument/rename request from the client.
+// Renames a resource id everywhere it is referenced.
+func (s *kroServer) Rename(context *glsp.Context, params *protocol.RenameParams) (*protocol.WorkspaceEdit, error) {
+	s.currentContext = context
+	return s.documentManager.Rename(params.TextDocument.URI, params.Position, params.NewName)
+}
+
+// DocumentSymbol handles the textDocument/documentSymbol request from the client.
+// Outlines the schema, its types and the resources by id.
+func (s *kroServer) DocumentSymbol(context *glsp.Context, params *protocol.DocumentSymbolParams) (any, error) {
+	s.currentContext = context
+	return s.documentManager.DocumentSymbols(params.TextDocument.URI), nil
+}
+
+// CodeAction handles the textDocument/codeAction request from the client.
+// Offers quick fixes for the resource under the cursor.
+func (s *kroServer) CodeAction(context *glsp.Context, params *protocol.CodeActionParams) (any, error) {
+	s.currentContext = context
+	return s.documentManager.CodeActions(params.TextDocument.URI, params.Range, params.Context.Diagnostics), nil
+}
+
 // createServerCapabilities defines what LSP features this server supports.
-// Currently supports full document synchronization and save notifications.
+// Currently supports full document synchronization, save notifications,
+// completion, hover, go-to-definition, find-references, rename, document
+// symbols and quick fixes.
 func (s *kroServer) createServerCapabilities() protocol.ServerCapabilities {
 	// Use full document sync (client sends entire document on changes)
 	syncKind := protocol.TextDocumentSyncKindFull
@@ -239,11 +302,22 @@ func (s *kroServer) createServerCapabilities() protocol.ServerCapabilities {
 				IncludeText: boolPtr(true), // Include document text in save events
 			},
 		},
-		// Future capabilities can be added here:
-		// - CompletionProvider (auto-completion)
-		// - HoverProvider (hover information)
-		// - DefinitionProvider (go-to-definition)
-		// - CodeActionProvider (quick fixes)
+		// Complete references inside expressions as they are typed
+		CompletionProvider: &protocol.CompletionOptions{
+			TriggerCharacters: []string{"{", "."},
+		},
+		// Describe resources, fields and expressions
+		HoverProvider: true,
+		// Navigate between the resources and the expressions reading them
+		DefinitionProvider: true,
+		ReferencesProvider: true,
+		RenameProvider:     true,
+		// Outline the schema and the resources
+		DocumentSymbolProvider: true,
+		// Offer quick fixes for the resources
+		CodeActionProvider: &protocol.CodeActionOptions{
+			CodeActionKinds: []protocol.CodeActionKind{protocol.CodeActionKindQuickFix},
+		},
 	}
 
 	return capabilities
@@ -276,10 +350,14 @@ func (s *kroServer) createHandler() *protocol.Handler {
 		// Optional notifications
 		SetTrace: s.SetTrace,
 
-		// Language feature methods would be added here:
-		// TextDocumentCompletion: s.Completion,
-		// TextDocumentHover: s.Hover,
-		// TextDocumentDefinition: s.Definition,
+		// Language feature methods
+		TextDocumentCompletion:     s.Completion,
+		TextDocumentHover:          s.Hover,
+		TextDocumentDefinition:     s.Definition,
+		TextDocumentReferences:     s.References,
+		TextDocumentRename:         s.Rename,
+		TextDocumentDocumentSymbol: s.DocumentSymbol,
+		TextDocumentCodeAction:     s.CodeAction,
 	}
 
 	return handler
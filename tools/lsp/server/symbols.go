@@ -0,0 +1,150 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/kubernetes-sigs/kro/tools/lsp/server/parser"
+	protocol "github.com/tliron/glsp/protocol_3_16"
+	"gopkg.in/yaml.v3"
+)
+
+// SymbolManager provides the outline of KRO ResourceGraphDefinition documents:
+// the schema of the instances, with its custom types, and the resources by id.
+type SymbolManager struct {
+	logger     logr.Logger
+	yamlParser *parser.YAMLParser
+}
+
+// NewSymbolManager creates a new symbol manager.
+func NewSymbolManager(logger logr.Logger) *SymbolManager {
+	return &SymbolManager{
+		logger:     logger,
+		yamlParser: parser.NewYAMLParser(logger),
+	}
+}
+
+// DocumentSymbols returns the outline of a document. Documents that can't be
+// parsed, or that aren't ResourceGraphDefinitions, have no outline.
+func (sm *SymbolManager) DocumentSymbols(content string) []protocol.DocumentSymbol {
+	symbols := []protocol.DocumentSymbol{}
+	result := sm.yamlParser.Parse(content)
+	if result.HasErrors || !sm.yamlParser.IsKROResource(result.Node) {
+		return symbols
+	}
+	doc := newRGDDocument(content, result.Node)
+	spec := mappingValue(doc.root, "spec")
+
+	if schemaKey, schemaNode := mappingField(spec, "schema"); schemaNode != nil {
+		symbol := pairSymbol(schemaKey, schemaNode, protocol.SymbolKindClass)
+		if kind := mappingValue(schemaNode, "kind"); kind != nil && kind.Value != "" {
+			symbol.Detail = stringPtr(kind.Value)
+		}
+		for _, field := range []struct {
+			name string
+			kind protocol.SymbolKind
+		}{
+			{"spec", protocol.SymbolKindStruct},
+			{"status", protocol.SymbolKindStruct},
+			{"types", protocol.SymbolKindNamespace},
+		} {
+			if key, value := mappingField(schemaNode, field.name); value != nil {
+				child := pairSymbol(key, value, field.kind)
+				child.Children = fieldSymbols(value)
+				symbol.Children = append(symbol.Children, child)
+			}
+		}
+		symbols = append(symbols, symbol)
+	}
+
+	if resourcesKey, resourcesNode := mappingField(spec, "resources"); resourcesNode != nil {
+		symbol := pairSymbol(resourcesKey, resourcesNode, protocol.SymbolKindArray)
+		for i, resource := range doc.resources() {
+			symbol.Children = append(symbol.Children, resourceSymbol(i, resource))
+		}
+		symbols = append(symbols, symbol)
+	}
+	return symbols
+}
+
+// resourceSymbol returns the symbol of the resource at the given index, named
+// after its id and detailed by its kind.
+func resourceSymbol(index int, resource *rgdResource) protocol.DocumentSymbol {
+	endLine, endCharacter := nodeEnd(resource.Item)
+	symbol := protocol.DocumentSymbol{
+		Name: resource.ID,
+		Kind: protocol.SymbolKindObject,
+		Range: protocol.Range{
+			Start: protocol.Position{Line: uint32(resource.Item.Line - 1), Character: uint32(resource.Item.Column - 1)},
+			End:   protocol.Position{Line: uint32(endLine), Character: uint32(endCharacter)},
+		},
+	}
+	symbol.SelectionRange = lineRange(resource.Item.Line-1, resource.Item.Column-1, 0)
+	if resource.IDNode != nil {
+		symbol.SelectionRange = nodeRange(resource.IDNode)
+	}
+	if symbol.Name == "" {
+		symbol.Name = fmt.Sprintf("resources[%d]", index)
+	}
+	if gvk, ok := resource.GVK(); ok {
+		symbol.Detail = stringPtr(gvk.Kind)
+	}
+	return symbol
+}
+
+// fieldSymbols returns the symbols of the fields of a mapping node, nested
+// mappings holding the symbols of their own fields. The fields are detailed by
+// their values, like the SimpleSchema types or the status expressions.
+func fieldSymbols(node *yaml.Node) []protocol.DocumentSymbol {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	symbols := make([]protocol.DocumentSymbol, 0, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if key.Value == "" {
+			continue
+		}
+		if value.Kind == yaml.MappingNode {
+			symbol := pairSymbol(key, value, protocol.SymbolKindStruct)
+			symbol.Children = fieldSymbols(value)
+			symbols = append(symbols, symbol)
+			continue
+		}
+		symbol := pairSymbol(key, value, protocol.SymbolKindField)
+		if value.Kind == yaml.ScalarNode && value.Value != "" {
+			symbol.Detail = stringPtr(value.Value)
+		}
+		symbols = append(symbols, symbol)
+	}
+	return symbols
+}
+
+// pairSymbol returns a symbol named after the key of a mapping field, spanning
+// the key and its value.
+func pairSymbol(key, value *yaml.Node, kind protocol.SymbolKind) protocol.DocumentSymbol {
+	endLine, endCharacter := nodeEnd(value)
+	return protocol.DocumentSymbol{
+		Name: key.Value,
+		Kind: kind,
+		Range: protocol.Range{
+			Start: protocol.Position{Line: uint32(key.Line - 1), Character: uint32(key.Column - 1)},
+			End:   protocol.Position{Line: uint32(endLine), Character: uint32(endCharacter)},
+		},
+		SelectionRange: nodeRange(key),
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	protocol "github.com/tliron/glsp/protocol_3_16"
+)
+
+// outlineSymbol is the outline of a symbol, without its ranges.
+type outlineSymbol struct {
+	Name     string
+	Kind     protocol.SymbolKind
+	Detail   string
+	Children []outlineSymbol
+}
+
+// outline returns the outline of the symbols, without their ranges.
+func outline(symbols []protocol.DocumentSymbol) []outlineSymbol {
+	var outlines []outlineSymbol
+	for _, symbol := range symbols {
+		o := outlineSymbol{Name: symbol.Name, Kind: symbol.Kind, Children: outline(symbol.Children)}
+		if symbol.Detail != nil {
+			o.Detail = *symbol.Detail
+		}
+		outlines = append(outlines, o)
+	}
+	return outlines
+}
+
+func TestDocumentSymbols(t *testing.T) {
+	sm := NewSymbolManager(logr.Discard())
+
+	symbols := sm.DocumentSymbols(testRGD)
+	assert.Equal(t, []outlineSymbol{
+		{Name: "schema", Kind: protocol.SymbolKindClass, Detail: "WebApp", Children: []outlineSymbol{
+			{Name: "spec", Kind: protocol.SymbolKindStruct, Children: []outlineSymbol{
+				{Name: "name", Kind: protocol.SymbolKindField, Detail: "string"},
+				{Name: "replicas", Kind: protocol.SymbolKindField, Detail: "integer | default=1"},
+			}},
+		}},
+		{Name: "resources", Kind: protocol.SymbolKindArray, Children: []outlineSymbol{
+			{Name: "deployment", Kind: protocol.SymbolKindObject, Detail: "Deployment"},
+			{Name: "service", Kind: protocol.SymbolKindObject, Detail: "Service"},
+		}},
+	}, outline(symbols))
+
+	// The symbols span their whole definition, and select their name.
+	require.Len(t, symbols, 2)
+	schema, resources := symbols[0], symbols[1]
+	assert.Equal(t, protocol.Position{Line: 5, Character: 2}, schema.Range.Start)
+	assert.Equal(t, lineRange(5, 2, len("schema")), schema.SelectionRange)
+	assert.Equal(t, lineRange(9, 6, len("name")), schema.Children[0].Children[0].SelectionRange)
+	require.Len(t, resources.Children, 2)
+	deployment, service := resources.Children[0], resources.Children[1]
+	assert.Equal(t, protocol.Position{Line: 12, Character: 6}, deployment.Range.Start)
+	assert.Equal(t, lineRange(12, 10, len("deployment")), deployment.SelectionRange)
+	assert.Less(t, deployment.Range.End.Line, service.Range.Start.Line)
+}
+
+func TestDocumentSymbols_SchemaFields(t *testing.T) {
+	sm := NewSymbolManager(logr.Discard())
+
+	content := strings.Replace(testRGD, "      replicas: integer | default=1\n", `      replicas: integer | default=1
+      ingress:
+        enabled: boolean
+    status:
+      availableReplicas: ${deployment.status.availableReplicas}
+    types:
+      Port:
+        number: integer
+`, 1)
+	symbols := sm.DocumentSymbols(content)
+	require.NotEmpty(t, symbols)
+	assert.Equal(t, []outlineSymbol{
+		{Name: "spec", Kind: protocol.SymbolKindStruct, Children: []outlineSymbol{
+			{Name: "name", Kind: protocol.SymbolKindField, Detail: "string"},
+			{Name: "replicas", Kind: protocol.SymbolKindField, Detail: "integer | default=1"},
+			{Name: "ingress", Kind: protocol.SymbolKindStruct, Children: []outlineSymbol{
+				{Name: "enabled", Kind: protocol.SymbolKindField, Detail: "boolean"},
+			}},
+		}},
+		{Name: "status", Kind: protocol.SymbolKindStruct, Children: []outlineSymbol{
+			{Name: "availableReplicas", Kind: protocol.SymbolKindField, Detail: "${deployment.status.availableReplicas}"},
+		}},
+		{Name: "types", Kind: protocol.SymbolKindNamespace, Children: []outlineSymbol{
+			{Name: "Port", Kind: protocol.SymbolKindStruct, Children: []outlineSymbol{
+				{Name: "number", Kind: protocol.SymbolKindField, Detail: "integer"},
+			}},
+		}},
+	}, outline(symbols[0].Children))
+}
+
+func TestDocumentSymbols_ResourceWithoutID(t *testing.T) {
+	sm := NewSymbolManager(logr.Discard())
+
+	symbols := sm.DocumentSymbols(strings.Replace(testRGD, "    - id: service\n      template:", "    - template:", 1))
+	require.Len(t, symbols, 2)
+	assert.Equal(t, []outlineSymbol{
+		{Name: "deployment", Kind: protocol.SymbolKindObject, Detail: "Deployment"},
+		{Name: "resources[1]", Kind: protocol.SymbolKindObject, Detail: "Service"},
+	}, outline(symbols[1].Children))
+}
+
+func TestDocumentSymbols_NoOutline(t *testing.T) {
+	sm := NewSymbolManager(logr.Discard())
+
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{
+			name:    "invalid YAML",
+			content: "apiVersion: kro.run/v1alpha1\nkind: [ResourceGraphDefinition\n",
+		},
+		{
+			name:    "other kind",
+			content: "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: config\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Empty(t, sm.DocumentSymbols(tt.content))
+		})
+	}
+}
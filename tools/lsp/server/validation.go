@@ -15,24 +15,34 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 
 	"github.com/go-logr/logr"
-	"github.com/kro-run/kro/api/v1alpha1"
-	"github.com/kro-run/kro/pkg/graph"
-	"github.com/kro-run/kro/tools/lsp/server/parser"
+	"github.com/kubernetes-sigs/kro/api/v1alpha1"
+	"github.com/kubernetes-sigs/kro/pkg/graph"
+	"github.com/kubernetes-sigs/kro/pkg/graph/schema/offline"
+	"github.com/kubernetes-sigs/kro/tools/lsp/server/parser"
 	protocol "github.com/tliron/glsp/protocol_3_16"
+	yaml3 "gopkg.in/yaml.v3"
+	"k8s.io/apiserver/pkg/cel/openapi/resolver"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/yaml"
 )
 
 // ValidationManager handles comprehensive validation of KRO ResourceGraphDefinition documents.
 // It supports both online validation (with cluster connectivity for CRD and CEL validation)
-// and offline validation (basic structure and YAML syntax validation only).
+// and offline validation, where the schemas of the resources are those of the built-in
+// types and of the CRDs found in local files.
 type ValidationManager struct {
 	logger     logr.Logger
 	yamlParser *parser.YAMLParser
 	builder    *graph.Builder
+	// offline tells whether the resource schemas come from local files rather
+	// than from a cluster, in which case the unknown kinds are only warned
+	// about, their CRDs being possibly missing from the local files.
+	offline bool
 }
 
 // ValidationResult represents the outcome of document validation, containing
@@ -44,40 +54,50 @@ type ValidationResult struct {
 
 // NewValidationManager creates a new validation manager with the appropriate mode.
 // If clientConfig is provided and valid, enables online mode with full KRO validation.
-// If clientConfig is nil or invalid, falls back to offline mode with basic validation.
-func NewValidationManager(logger logr.Logger, clientConfig *rest.Config) (*ValidationManager, error) {
+// If clientConfig is nil or invalid, falls back to offline mode, where the documents are
+// still fully validated, against the built-in types and the CRDs found in crdPaths.
+// crdPaths are files or directories of CRD manifests, like the output of
+// `kubectl get crds -o yaml`.
+func NewValidationManager(logger logr.Logger, clientConfig *rest.Config, crdPaths []string) (*ValidationManager, error) {
 	// Always create YAML parser for basic syntax validation
 	yamlParser := parser.NewYAMLParser(logger)
 
-	// If no client config is provided, operate in offline mode
-	if clientConfig == nil {
-		return &ValidationManager{
-			logger:     logger,
-			yamlParser: yamlParser,
-			builder:    nil, // nil builder indicates offline mode
-		}, nil
-	}
-
 	// Try to create KRO Builder for online validation
 	// This enables CRD schema validation and CEL expression evaluation
-	builder, err := graph.NewBuilder(clientConfig)
-	if err != nil {
+	if clientConfig != nil {
+		builder, err := graph.NewBuilder(clientConfig)
+		if err == nil {
+			// Successfully created builder - online mode enabled
+			return &ValidationManager{
+				logger:     logger,
+				yamlParser: yamlParser,
+				builder:    builder, // Online mode with full validation capabilities
+			}, nil
+		}
 		// If builder creation fails, gracefully fall back to offline mode
-		return &ValidationManager{
-			logger:     logger,
-			yamlParser: yamlParser,
-			builder:    nil, // Offline mode fallback
-		}, nil
+		logger.Error(err, "Failed to create builder, falling back to offline mode")
 	}
 
-	// Successfully created builder - online mode enabled
+	// Offline mode: the builder resolves the schemas from local files
+	localResolver := offline.NewLocalResolver()
+	if err := localResolver.LoadCRDs(crdPaths...); err != nil {
+		// Log error but keep the CRDs that could be loaded
+		logger.Error(err, "Failed to load some CRDs", "paths", crdPaths)
+	}
 	return &ValidationManager{
 		logger:     logger,
 		yamlParser: yamlParser,
-		builder:    builder, // Online mode with full validation capabilities
+		builder:    graph.NewOfflineBuilder(localResolver, localResolver),
+		offline:    true,
 	}, nil
 }
 
+// SchemaResolver returns the resolver of the OpenAPI schemas of the resources,
+// from the cluster in online mode and from the local files in offline mode.
+func (vm *ValidationManager) SchemaResolver() resolver.SchemaResolver {
+	return vm.builder.SchemaResolver()
+}
+
 // ValidateDocument performs comprehensive validation of a KRO ResourceGraphDefinition document.
 // Validation occurs in multiple stages, from basic YAML syntax to advanced KRO semantics.
 // Returns validation results suitable for LSP diagnostic reporting.
@@ -126,26 +146,18 @@ func (vm *ValidationManager) ValidateDocument(content string) ValidationResult {
 		return result
 	}
 
-	// Stage 4: KRO validation (online vs offline mode)
-	if vm.builder != nil {
-		// Online mode: Full KRO validation including:
-		// - CRD schema validation against live cluster
-		// - CEL expression validation with cluster context
-		// - Cross-resource dependency validation
-		// - Field type validation against OpenAPI schemas
-		_, err := vm.builder.NewResourceGraphDefinition(rgd)
-		if err != nil {
-			diagnostic := vm.createErrorDiagnostic(0, 0, fmt.Sprintf("KRO validation failed: %s", err.Error()))
-			result.Diagnostics = append(result.Diagnostics, diagnostic)
-			result.HasErrors = true
-		}
-	} else {
-		// Offline mode: Basic structure validation only
-		// Limited to static checks without cluster connectivity
-		if err := vm.validateBasicStructure(rgd); err != nil {
-			diagnostic := vm.createErrorDiagnostic(0, 0, fmt.Sprintf("Structure validation failed: %s", err.Error()))
-			result.Diagnostics = append(result.Diagnostics, diagnostic)
-			result.HasErrors = true
+	// Stage 4: KRO validation, including:
+	// - CRD schema validation against the cluster or the local CRDs
+	// - CEL expression validation
+	// - Cross-resource dependency validation
+	// - Field type validation against OpenAPI schemas
+	if _, err := vm.builder.NewResourceGraphDefinition(context.Background(), rgd); err != nil {
+		diagnostics := vm.createBuildDiagnostics(parseResult.Node, err)
+		result.Diagnostics = append(result.Diagnostics, diagnostics...)
+		for _, diagnostic := range diagnostics {
+			if *diagnostic.Severity == protocol.DiagnosticSeverityError {
+				result.HasErrors = true
+			}
 		}
 	}
 
@@ -163,32 +175,45 @@ func (vm *ValidationManager) parseRGDToStruct(content string) (*v1alpha1.Resourc
 	return &rgd, nil
 }
 
-// validateBasicStructure performs offline validation of RGD structure.
-// Checks for essential fields and basic structural requirements without cluster connectivity.
-// This is a fallback validation method when online validation is not available.
-func (vm *ValidationManager) validateBasicStructure(rgd *v1alpha1.ResourceGraphDefinition) error {
-	// Validate required spec.schema section
-	if rgd.Spec.Schema == nil {
-		return fmt.Errorf("missing spec.schema section")
+// createBuildDiagnostics creates LSP diagnostics for the errors returned by the
+// KRO builder. Each BuildError is reported at the range of the field it points
+// at, the other errors at the start of the document.
+func (vm *ValidationManager) createBuildDiagnostics(node *yaml3.Node, err error) []protocol.Diagnostic {
+	var buildErrs graph.BuildErrors
+	if !errors.As(err, &buildErrs) {
+		var buildErr *graph.BuildError
+		if !errors.As(err, &buildErr) {
+			return []protocol.Diagnostic{
+				vm.createErrorDiagnostic(0, 0, fmt.Sprintf("KRO validation failed: %s", err.Error())),
+			}
+		}
+		buildErrs = graph.BuildErrors{buildErr}
 	}
 
-	// Validate required schema.kind field
-	if rgd.Spec.Schema.Kind == "" {
-		return fmt.Errorf("missing spec.schema.kind")
+	diagnostics := make([]protocol.Diagnostic, 0, len(buildErrs))
+	for _, buildErr := range buildErrs {
+		message := buildErr.Err.Error()
+		if buildErr.Suggestion != "" {
+			message = fmt.Sprintf("%s (%s)", message, buildErr.Suggestion)
+		}
+		diagnostic := vm.createErrorDiagnostic(0, 0, message)
+		if r, ok := vm.yamlParser.FindFieldRange(node, buildErr.Path, buildErr.Expression); ok {
+			diagnostic.Range = r
+		}
+		diagnostic.Code = &protocol.IntegerOrString{Value: string(buildErr.Code)}
+		if vm.offline && buildErr.Code == graph.ErrorCodeUnknownKind {
+			diagnostic.Severity = &[]protocol.DiagnosticSeverity{protocol.DiagnosticSeverityWarning}[0]
+			diagnostic.Message += " (offline mode: add its CustomResourceDefinition with --crd-path)"
+		}
+		diagnostics = append(diagnostics, diagnostic)
 	}
-
-	// Additional basic validations could be added here:
-	// - Check for required resources section
-	// - Validate resource IDs are unique
-	// - Check for basic template structure
-
-	return nil
+	return diagnostics
 }
 
 // createErrorDiagnostic creates an LSP diagnostic for validation errors.
 // Diagnostics are used by LSP clients (editors) to display errors, warnings, and info messages.
-// Currently creates error-level diagnostics at position (0,0) - this could be enhanced
-// to provide precise line/column positions using YAML node information.
+// The diagnostics are created at the given position, and can be widened to the
+// range of the field holding the error.
 func (vm *ValidationManager) createErrorDiagnostic(line, character int, message string) protocol.Diagnostic {
 	return protocol.Diagnostic{
 		Range: protocol.Range{